@@ -3,10 +3,17 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/EstefiS/uala-challenge/configs"
+	"github.com/EstefiS/uala-challenge/internal/adapters/blobstore"
+	"github.com/EstefiS/uala-challenge/internal/adapters/events"
+	"github.com/EstefiS/uala-challenge/internal/adapters/fetcher"
 	httpAdapter "github.com/EstefiS/uala-challenge/internal/adapters/http"
+	"github.com/EstefiS/uala-challenge/internal/adapters/jobs"
+	"github.com/EstefiS/uala-challenge/internal/adapters/moderation"
 	"github.com/EstefiS/uala-challenge/internal/adapters/repository"
 	"github.com/EstefiS/uala-challenge/internal/core/ports"
 	"github.com/EstefiS/uala-challenge/internal/core/services"
@@ -19,6 +26,75 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+type repositories struct {
+	user          ports.UserRepository
+	tweet         ports.TweetRepository
+	timeline      ports.TimelineRepository
+	mention       ports.MentionRepository
+	hashtag       ports.HashtagRepository
+	trend         ports.TrendRepository
+	search        ports.SearchRepository
+	notification  ports.NotificationRepository
+	broker        ports.EventBroker
+	relationship  ports.RelationshipRepository
+	filter        ports.FilterRepository
+	followRequest ports.FollowRequestRepository
+	message       ports.MessageRepository
+	bookmark      ports.BookmarkRepository
+	list          ports.ListRepository
+	suggestion    ports.SuggestionRepository
+	suggestions   ports.SuggestionCache
+	scheduled     ports.ScheduledTweetRepository
+	draft         ports.DraftRepository
+	media         ports.MediaRepository
+	poll          ports.PollRepository
+	link          ports.LinkRepository
+	held          ports.HeldTweetRepository
+	report        ports.ReportRepository
+	accountData   ports.AccountDataRepository
+	profile       ports.ProfileRepository
+	retention     ports.RetentionRepository
+	partition     ports.PartitionRepository
+	blobs         ports.BlobStore
+}
+
+// newBlobStore picks where uploaded media are kept. The local store is only
+// safe with a single replica or a shared volume.
+func newBlobStore(cfg *configs.Config, logger *slog.Logger) ports.BlobStore {
+	if cfg.MediaStore == "s3" {
+		logger.Info("Storing media in S3", "endpoint", cfg.S3Endpoint, "bucket", cfg.S3Bucket)
+		return blobstore.NewS3BlobStore(blobstore.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Bucket:          cfg.S3Bucket,
+			Region:          cfg.S3Region,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		}, &http.Client{Timeout: 30 * time.Second})
+	}
+	logger.Info("Storing media on the local filesystem", "dir", cfg.MediaDir)
+	return blobstore.NewLocalBlobStore(cfg.MediaDir)
+}
+
+// newModerationPolicy loads the moderation rules. Without a rules file every
+// tweet is allowed.
+func newModerationPolicy(cfg *configs.Config, logger *slog.Logger) ports.ModerationPolicy {
+	var rules []moderation.Rule
+	if cfg.ModerationRulesFile != "" {
+		var err error
+		if rules, err = moderation.LoadRules(cfg.ModerationRulesFile); err != nil {
+			logger.Error("Could not load the moderation rules", "error", err)
+			os.Exit(1)
+		}
+	}
+	engine, err := moderation.NewRuleEngine(rules)
+	if err != nil {
+		logger.Error("Invalid moderation rules", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("Loaded moderation rules", "count", len(rules))
+	return engine
+}
+
 // @title           Uala Challenge - Microblogging API
 // @version         1.0
 // @description     This is an API for a microblogging platform, similar to Twitter, built with Go and Hexagonal Architecture..
@@ -33,7 +109,7 @@ import (
 
 // @host      localhost:8080
 // @BasePath  /api/v1
-func setupDependencies(ctx context.Context, cfg *configs.Config, logger *slog.Logger) (ports.UserRepository, ports.TweetRepository, ports.TimelineRepository) {
+func setupDependencies(ctx context.Context, cfg *configs.Config, logger *slog.Logger) repositories {
 	if cfg.AppEnv == "prod" {
 		logger.Info("Using production configuration: PostgreSQL + Redis Cache")
 
@@ -55,14 +131,81 @@ func setupDependencies(ctx context.Context, cfg *configs.Config, logger *slog.Lo
 		}
 
 		postgresRepo := repository.NewPostgresRepository(dbpool, logger)
-		cachingRepo := repository.NewCachingRepository(redisClient, postgresRepo, postgresRepo, postgresRepo, logger)
+		cachingRepo := repository.NewCachingRepository(redisClient, postgresRepo, postgresRepo, postgresRepo, postgresRepo, postgresRepo, logger)
+		listCachingRepo := repository.NewListCachingRepository(redisClient, postgresRepo, cachingRepo, logger)
+		trendingRepo := repository.NewTrendingRepository(redisClient, listCachingRepo, postgresRepo, cachingRepo, cfg.TrendWindow+cfg.TrendBaselineWindow, logger)
+		broker := events.NewRedisBroker(ctx, redisClient, cfg.StreamHistorySize, logger)
+		suggestionRepo := repository.NewSuggestionCachingRepository(redisClient, postgresRepo, logger)
+		streamingRepo := repository.NewStreamingRepository(broker, trendingRepo, cachingRepo, logger)
+		erasureRepo := repository.NewErasureCachingRepository(redisClient, postgresRepo, postgresRepo, postgresRepo, logger)
 
-		return cachingRepo, cachingRepo, cachingRepo
+		return repositories{
+			user:          cachingRepo,
+			tweet:         streamingRepo,
+			timeline:      cachingRepo,
+			mention:       postgresRepo,
+			hashtag:       trendingRepo,
+			trend:         trendingRepo,
+			search:        postgresRepo,
+			notification:  postgresRepo,
+			broker:        broker,
+			relationship:  cachingRepo,
+			filter:        postgresRepo,
+			followRequest: cachingRepo,
+			message:       postgresRepo,
+			bookmark:      postgresRepo,
+			list:          listCachingRepo,
+			suggestion:    suggestionRepo,
+			suggestions:   suggestionRepo,
+			scheduled:     postgresRepo,
+			draft:         postgresRepo,
+			media:         postgresRepo,
+			poll:          postgresRepo,
+			link:          postgresRepo,
+			held:          postgresRepo,
+			report:        postgresRepo,
+			accountData:   erasureRepo,
+			profile:       postgresRepo,
+			retention:     postgresRepo,
+			partition:     postgresRepo,
+			blobs:         newBlobStore(cfg, logger),
+		}
 	}
 
 	logger.Info("Using development configuration: In-memory Mock Repository")
 	mockRepo := repository.NewMockRepository()
-	return mockRepo, mockRepo, mockRepo
+	broker := events.NewMemoryBroker(cfg.StreamHistorySize)
+	return repositories{
+		user:          mockRepo,
+		tweet:         repository.NewStreamingRepository(broker, mockRepo, mockRepo, logger),
+		timeline:      mockRepo,
+		mention:       mockRepo,
+		hashtag:       mockRepo,
+		trend:         mockRepo,
+		search:        mockRepo,
+		notification:  mockRepo,
+		broker:        broker,
+		relationship:  mockRepo,
+		filter:        mockRepo,
+		followRequest: mockRepo,
+		message:       mockRepo,
+		bookmark:      mockRepo,
+		list:          mockRepo,
+		suggestion:    mockRepo,
+		suggestions:   mockRepo,
+		scheduled:     mockRepo,
+		draft:         mockRepo,
+		media:         mockRepo,
+		poll:          mockRepo,
+		link:          mockRepo,
+		held:          mockRepo,
+		report:        mockRepo,
+		accountData:   mockRepo,
+		profile:       mockRepo,
+		retention:     mockRepo,
+		partition:     mockRepo,
+		blobs:         newBlobStore(cfg, logger),
+	}
 }
 
 func main() {
@@ -72,17 +215,102 @@ func main() {
 	cfg := configs.LoadConfig()
 	logger.Info("Starting application", "environment", cfg.AppEnv)
 
-	userRepo, tweetRepo, timelineRepo := setupDependencies(ctx, cfg, logger)
+	repos := setupDependencies(ctx, cfg, logger)
+
+	notificationSvc := services.NewNotificationService(repos.notification, repos.broker, logger)
+	tweetSvc := services.NewTweetService(repos.tweet, repos.user, repos.media, repos.held, newModerationPolicy(cfg, logger), notificationSvc, cfg.TweetEditWindow, time.Now)
+	moderationSvc := services.NewModerationService(repos.held, repos.tweet, repos.user, tweetSvc, logger)
+	reportSvc := services.NewReportService(repos.report, repos.tweet, repos.user, logger, time.Now)
+	scheduleSvc := services.NewScheduleService(repos.scheduled, tweetSvc, logger, time.Now)
+	draftSvc := services.NewDraftService(repos.draft, tweetSvc, logger, time.Now)
+	mediaSvc := services.NewMediaService(repos.media, repos.blobs, repos.user, time.Now)
+	pollSvc := services.NewPollService(repos.poll, repos.tweet, repos.user, time.Now)
+	pageFetcher := fetcher.NewHTTPFetcher(fetcher.Config{
+		Policy: fetcher.Policy{
+			AllowedHosts: cfg.UnfurlAllowedHosts,
+			DeniedHosts:  cfg.UnfurlDeniedHosts,
+		},
+		Timeout:  cfg.UnfurlTimeout,
+		MaxBytes: int64(cfg.UnfurlMaxBytes),
+	})
+	linkSvc := services.NewLinkService(repos.link, pageFetcher, logger, time.Now)
+	followSvc := services.NewFollowService(repos.user, repos.followRequest, notificationSvc)
+	accountSvc := services.NewAccountService(repos.user)
+	privacySvc := services.NewPrivacyService(repos.accountData, repos.user, repos.blobs, repos.broker, logger, time.Now)
+	timelineSvc := services.NewTimelineService(repos.timeline, repos.filter, repos.user, time.Now)
+	profileSvc := services.NewProfileService(repos.profile, repos.relationship, repos.user)
+	retentionSvc := services.NewRetentionService(repos.retention, services.RetentionConfig{
+		TimelineMaxEntries: cfg.TimelineMaxEntries,
+		TimelineMaxAge:     cfg.TimelineMaxAge,
+		ArchiveAfter:       cfg.TweetArchiveAfter,
+	}, logger, time.Now)
+	partitionSvc := services.NewPartitionService(repos.partition, services.PartitionConfig{
+		MonthsAhead:    cfg.PartitionMonthsAhead,
+		TimelineMaxAge: cfg.TimelineMaxAge,
+		ArchiveAfter:   cfg.TweetArchiveAfter,
+		DropDetached:   cfg.PartitionDropDetached,
+	}, logger, time.Now)
+	filterSvc := services.NewFilterService(repos.filter, time.Now)
+	relationshipSvc := services.NewRelationshipService(repos.relationship)
+	messageSvc := services.NewMessageService(repos.message, repos.relationship, time.Now)
+	bookmarkSvc := services.NewBookmarkService(repos.bookmark, repos.tweet, repos.user, time.Now)
+	listSvc := services.NewListService(repos.list, repos.relationship, repos.user)
+	suggestionSvc := services.NewSuggestionService(repos.suggestion, repos.suggestions, repos.user, repos.relationship, cfg.SuggestionRefreshInterval, time.Now)
+	mentionSvc := services.NewMentionService(repos.mention, repos.user)
+	hashtagSvc := services.NewHashtagService(repos.hashtag, repos.trend, repos.user, services.TrendConfig{
+		Window:         cfg.TrendWindow,
+		BaselineWindow: cfg.TrendBaselineWindow,
+	}, time.Now)
+	searchSvc := services.NewSearchService(repos.search, repos.user)
+	streamSvc := services.NewStreamService(repos.broker, repos.relationship, repos.filter, repos.user, cfg.StreamBufferSize, time.Now)
+
+	// The server does not take any request until the partitions are
+	// prepared, so that tweets do not pile up in the default partition.
+	// Detaching is left to the job, so that a busy table does not stop the
+	// server from booting.
+	if err := partitionSvc.PreparePartitions(ctx); err != nil {
+		logger.Error("Could not prepare the table partitions", "error", err)
+		os.Exit(1)
+	}
 
-	tweetSvc := services.NewTweetService(tweetRepo)
-	followSvc := services.NewFollowService(userRepo)
-	timelineSvc := services.NewTimelineService(timelineRepo)
+	jobs.Start(ctx, logger,
+		jobs.Job{Name: "refresh-trends", Interval: cfg.TrendRefreshInterval, Run: hashtagSvc.RefreshTrends},
+		jobs.Job{Name: "refresh-suggestions", Interval: cfg.SuggestionRefreshInterval, Run: suggestionSvc.RefreshSuggestions},
+		jobs.Job{Name: "publish-scheduled-tweets", Interval: cfg.SchedulerInterval, Run: scheduleSvc.PublishDueTweets},
+		jobs.Job{Name: "unfurl-links", Interval: cfg.UnfurlInterval, Run: linkSvc.UnfurlLinks},
+		jobs.Job{Name: "erase-accounts", Interval: cfg.ErasureInterval, Run: privacySvc.EraseAccounts},
+		jobs.Job{Name: "apply-retention", Interval: cfg.RetentionInterval, Run: retentionSvc.ApplyRetention},
+		jobs.Job{Name: "maintain-partitions", Interval: cfg.PartitionInterval, Run: partitionSvc.MaintainPartitions},
+	)
 
 	apiDeps := httpAdapter.HandlerDependencies{
-		TweetSvc:    tweetSvc,
-		FollowSvc:   followSvc,
-		TimelineSvc: timelineSvc,
-		Logger:      logger,
+		TweetSvc:        tweetSvc,
+		ScheduleSvc:     scheduleSvc,
+		DraftSvc:        draftSvc,
+		MediaSvc:        mediaSvc,
+		PollSvc:         pollSvc,
+		ModerationSvc:   moderationSvc,
+		ReportSvc:       reportSvc,
+		FollowSvc:       followSvc,
+		AccountSvc:      accountSvc,
+		PrivacySvc:      privacySvc,
+		RelationshipSvc: relationshipSvc,
+		TimelineSvc:     timelineSvc,
+		ProfileSvc:      profileSvc,
+		MentionSvc:      mentionSvc,
+		HashtagSvc:      hashtagSvc,
+		SearchSvc:       searchSvc,
+		NotificationSvc: notificationSvc,
+		FilterSvc:       filterSvc,
+		MessageSvc:      messageSvc,
+		BookmarkSvc:     bookmarkSvc,
+		ListSvc:         listSvc,
+		SuggestionSvc:   suggestionSvc,
+		StreamSvc:       streamSvc,
+		StreamHeartbeat: cfg.StreamHeartbeat,
+		AdminUserIDs:    cfg.AdminUserIDs,
+		AdminToken:      cfg.AdminToken,
+		Logger:          logger,
 	}
 
 	gin.SetMode(gin.ReleaseMode)
@@ -3,15 +3,50 @@ package configs
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	AppEnv      string
-	Port        string
-	DatabaseURL string
-	RedisURL    string
+	AppEnv                    string
+	Port                      string
+	DatabaseURL               string
+	RedisURL                  string
+	TrendWindow               time.Duration
+	TrendBaselineWindow       time.Duration
+	TrendRefreshInterval      time.Duration
+	SuggestionRefreshInterval time.Duration
+	SchedulerInterval         time.Duration
+	TweetEditWindow           time.Duration
+	MediaStore                string
+	MediaDir                  string
+	S3Endpoint                string
+	S3Bucket                  string
+	S3Region                  string
+	S3AccessKeyID             string
+	S3SecretAccessKey         string
+	ModerationRulesFile       string
+	AdminUserIDs              []string
+	AdminToken                string
+	UnfurlInterval            time.Duration
+	UnfurlTimeout             time.Duration
+	UnfurlMaxBytes            int
+	UnfurlAllowedHosts        []string
+	UnfurlDeniedHosts         []string
+	StreamBufferSize          int
+	StreamHistorySize         int
+	StreamHeartbeat           time.Duration
+	ErasureInterval           time.Duration
+	RetentionInterval         time.Duration
+	TimelineMaxEntries        int
+	TimelineMaxAge            time.Duration
+	TweetArchiveAfter         time.Duration
+	PartitionInterval         time.Duration
+	PartitionMonthsAhead      int
+	PartitionDropDetached     bool
 }
 
 func LoadConfig() *Config {
@@ -26,10 +61,42 @@ func LoadConfig() *Config {
 	}
 
 	return &Config{
-		AppEnv:      getEnv("APP_ENV", "dev"),
-		Port:        getEnv("SERVER_PORT", "8080"),
-		DatabaseURL: getEnv("DATABASE_URL", ""),
-		RedisURL:    getEnv("REDIS_URL", ""),
+		AppEnv:                    getEnv("APP_ENV", "dev"),
+		Port:                      getEnv("SERVER_PORT", "8080"),
+		DatabaseURL:               getEnv("DATABASE_URL", ""),
+		RedisURL:                  getEnv("REDIS_URL", ""),
+		TrendWindow:               getEnvDuration("TREND_WINDOW", time.Hour),
+		TrendBaselineWindow:       getEnvDuration("TREND_BASELINE_WINDOW", 24*time.Hour),
+		TrendRefreshInterval:      getEnvDuration("TREND_REFRESH_INTERVAL", time.Minute),
+		SuggestionRefreshInterval: getEnvDuration("SUGGESTION_REFRESH_INTERVAL", time.Hour),
+		SchedulerInterval:         getEnvDuration("SCHEDULER_INTERVAL", 10*time.Second),
+		TweetEditWindow:           getEnvDuration("TWEET_EDIT_WINDOW", time.Hour),
+		MediaStore:                getEnv("MEDIA_STORE", "local"),
+		MediaDir:                  getEnv("MEDIA_DIR", "data/media"),
+		S3Endpoint:                getEnv("S3_ENDPOINT", ""),
+		S3Bucket:                  getEnv("S3_BUCKET", ""),
+		S3Region:                  getEnv("S3_REGION", "us-east-1"),
+		S3AccessKeyID:             getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:         getEnv("S3_SECRET_ACCESS_KEY", ""),
+		ModerationRulesFile:       getEnv("MODERATION_RULES_FILE", ""),
+		AdminUserIDs:              getEnvList("ADMIN_USER_IDS"),
+		AdminToken:                getEnv("ADMIN_TOKEN", ""),
+		UnfurlInterval:            getEnvDuration("UNFURL_INTERVAL", 15*time.Second),
+		UnfurlTimeout:             getEnvDuration("UNFURL_TIMEOUT", 5*time.Second),
+		UnfurlMaxBytes:            getEnvInt("UNFURL_MAX_BYTES", 512<<10),
+		UnfurlAllowedHosts:        getEnvList("UNFURL_ALLOWED_HOSTS"),
+		UnfurlDeniedHosts:         getEnvList("UNFURL_DENIED_HOSTS"),
+		StreamBufferSize:          getEnvInt("STREAM_BUFFER_SIZE", 64),
+		StreamHistorySize:         getEnvInt("STREAM_HISTORY_SIZE", 100),
+		StreamHeartbeat:           getEnvDuration("STREAM_HEARTBEAT_INTERVAL", 15*time.Second),
+		ErasureInterval:           getEnvDuration("ERASURE_INTERVAL", time.Minute),
+		RetentionInterval:         getEnvDuration("RETENTION_INTERVAL", 10*time.Minute),
+		TimelineMaxEntries:        getEnvInt("TIMELINE_MAX_ENTRIES", 800),
+		TimelineMaxAge:            getEnvDuration("TIMELINE_MAX_AGE", 0),
+		TweetArchiveAfter:         getEnvDuration("TWEET_ARCHIVE_AFTER", 0),
+		PartitionInterval:         getEnvDuration("PARTITION_INTERVAL", time.Hour),
+		PartitionMonthsAhead:      getEnvInt("PARTITION_MONTHS_AHEAD", 3),
+		PartitionDropDetached:     getEnvBool("PARTITION_DROP_DETACHED", false),
 	}
 }
 
@@ -39,3 +106,53 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Advertencia: Invalid duration %q for %s. Using %s.", value, key, fallback)
+		return fallback
+	}
+	return d
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Advertencia: Invalid integer %q for %s. Using %d.", value, key, fallback)
+		return fallback
+	}
+	return n
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Advertencia: Invalid boolean %q for %s. Using %t.", value, key, fallback)
+		return fallback
+	}
+	return b
+}
+
+// getEnvList splits a comma-separated variable, ignoring empty items.
+func getEnvList(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
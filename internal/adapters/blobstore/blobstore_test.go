@@ -0,0 +1,130 @@
+package blobstore
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success: should store, read and delete blobs", func(t *testing.T) {
+		store := NewLocalBlobStore(t.TempDir())
+
+		require.NoError(t, store.Put(ctx, "media-1/original", "image/png", []byte("png")))
+		data, err := store.Get(ctx, "media-1/original")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("png"), data)
+
+		assert.NoError(t, store.Delete(ctx, "media-1/original"))
+		_, err = store.Get(ctx, "media-1/original")
+		assert.Equal(t, domain.ErrBlobNotFound, err)
+		assert.NoError(t, store.Delete(ctx, "media-1/original"))
+	})
+
+	t.Run("Failure: should reject keys outside the root", func(t *testing.T) {
+		store := NewLocalBlobStore(t.TempDir())
+
+		for _, key := range []string{"", "/etc/passwd", "../secret", "media/../../secret", "media//original"} {
+			assert.Equal(t, ErrInvalidKey, store.Put(ctx, key, "image/png", []byte("png")), key)
+		}
+	})
+}
+
+// fakeS3 is a local stand-in for an S3-compatible service. It keeps objects
+// in memory and only accepts requests whose SigV4 signature, recomputed from
+// the request as received, matches.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	signer  *S3BlobStore
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	expected, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	f.signer.sign(expected, body)
+	if r.Header.Get("Authorization") != expected.Header.Get("Authorization") {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, "SignatureDoesNotMatch")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = body
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3BlobStore(t *testing.T) {
+	ctx := context.Background()
+	now := func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+	cfg := S3Config{Bucket: "media", Region: "us-east-1", AccessKeyID: "AKID", SecretAccessKey: "secret"}
+
+	newStore := func(t *testing.T, serverCfg S3Config) (*S3BlobStore, *fakeS3) {
+		fake := &fakeS3{objects: make(map[string][]byte), signer: &S3BlobStore{cfg: serverCfg, now: now}}
+		server := httptest.NewServer(fake)
+		t.Cleanup(server.Close)
+
+		clientCfg := cfg
+		clientCfg.Endpoint = server.URL
+		store := NewS3BlobStore(clientCfg, server.Client())
+		store.now = now
+		return store, fake
+	}
+
+	t.Run("Success: should store, read and delete objects with signed requests", func(t *testing.T) {
+		store, fake := newStore(t, cfg)
+
+		require.NoError(t, store.Put(ctx, "media-1/original", "image/png", []byte("png")))
+		assert.Equal(t, []byte("png"), fake.objects["/media/media-1/original"])
+
+		data, err := store.Get(ctx, "media-1/original")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("png"), data)
+
+		assert.NoError(t, store.Delete(ctx, "media-1/original"))
+		_, err = store.Get(ctx, "media-1/original")
+		assert.Equal(t, domain.ErrBlobNotFound, err)
+	})
+
+	t.Run("Failure: should surface requests rejected by the service", func(t *testing.T) {
+		serverCfg := cfg
+		serverCfg.SecretAccessKey = "other-secret"
+		store, _ := newStore(t, serverCfg)
+
+		err := store.Put(ctx, "media-1/original", "image/png", []byte("png"))
+		assert.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "403"))
+	})
+}
+
+func TestSigningKey(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation.
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	assert.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
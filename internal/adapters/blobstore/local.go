@@ -0,0 +1,83 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+)
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// LocalBlobStore keeps every blob in a file under root, at the path given by
+// its key. It suits development and single-replica deployments.
+type LocalBlobStore struct {
+	root string
+}
+
+func NewLocalBlobStore(root string) *LocalBlobStore {
+	return &LocalBlobStore{root: root}
+}
+
+// Put writes to a temporary file first, so readers never see a partial blob.
+func (s *LocalBlobStore) Put(_ context.Context, key, _ string, data []byte) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("error creating blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("error creating blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing blob: %w", err)
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (s *LocalBlobStore) Get(_ context.Context, key string) ([]byte, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrBlobNotFound
+	}
+	return data, err
+}
+
+func (s *LocalBlobStore) Delete(_ context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps a key to a file under root, rejecting keys that would escape it.
+func (s *LocalBlobStore) path(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
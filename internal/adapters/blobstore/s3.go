@@ -0,0 +1,159 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+)
+
+// S3Config points an S3BlobStore at a bucket. Endpoint is the base URL of
+// any S3-compatible service (AWS, MinIO, ...); objects are addressed
+// path-style as Endpoint/Bucket/key.
+type S3Config struct {
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3BlobStore stores blobs as objects of an S3-compatible bucket, signing
+// every request with AWS Signature Version 4.
+type S3BlobStore struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3BlobStore(cfg S3Config, client *http.Client) *S3BlobStore {
+	return &S3BlobStore{cfg: cfg, client: client, now: time.Now}
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, contentType, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return unexpectedStatus(http.MethodPut, key, resp)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, domain.ErrBlobNotFound
+	default:
+		return nil, unexpectedStatus(http.MethodGet, key, resp)
+	}
+}
+
+// Delete succeeds for missing objects too, as S3 itself does.
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return unexpectedStatus(http.MethodDelete, key, resp)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) do(ctx context.Context, method, key, contentType string, body []byte) (*http.Response, error) {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	target := strings.TrimRight(s.cfg.Endpoint, "/") + "/" + url.PathEscape(s.cfg.Bucket) + "/" + strings.Join(segments, "/")
+
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body)
+
+	return s.client.Do(req)
+}
+
+// sign adds the SigV4 headers. Only host, x-amz-content-sha256 and
+// x-amz-date are signed, which is all S3 requires.
+func (s *S3BlobStore) sign(req *http.Request, body []byte) {
+	payloadHash := sha256Hex(body)
+	amzDate := s.now().UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	req.Header.Set("x-amz-date", amzDate)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	signature := hex.EncodeToString(hmacSHA256(signingKey(s.cfg.SecretAccessKey, date, s.cfg.Region, "s3"), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func signingKey(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func unexpectedStatus(method, key string, resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("s3 %s %s: unexpected status %d: %s", method, key, resp.StatusCode, bytes.TrimSpace(message))
+}
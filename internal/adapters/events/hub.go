@@ -0,0 +1,98 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+)
+
+// hub keeps the subscribers connected to this process and hands them the
+// events of their topic. A subscriber whose buffer is full is dropped rather
+// than blocking the publisher; it can resume from its last event ID.
+type hub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[*subscriber]bool
+}
+
+type subscriber struct {
+	ch     chan domain.Event
+	lastID string
+	closed bool
+}
+
+func newHub() *hub {
+	return &hub{subscribers: make(map[string]map[*subscriber]bool)}
+}
+
+// add registers a subscriber whose channel already holds the replayed events,
+// so that live events up to lastEventID or the replay are not delivered twice.
+func (h *hub) add(topic, lastEventID string, replay []domain.Event, buffer int) *subscriber {
+	sub := &subscriber{ch: make(chan domain.Event, len(replay)+buffer), lastID: lastEventID}
+	for _, e := range replay {
+		sub.ch <- e
+		sub.lastID = e.ID
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.subscribers[topic]
+	if !ok {
+		subs = make(map[*subscriber]bool)
+		h.subscribers[topic] = subs
+	}
+	subs[sub] = true
+	return sub
+}
+
+func (h *hub) remove(topic string, sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closeLocked(topic, sub)
+}
+
+func (h *hub) closeLocked(topic string, sub *subscriber) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.ch)
+
+	delete(h.subscribers[topic], sub)
+	if len(h.subscribers[topic]) == 0 {
+		delete(h.subscribers, topic)
+	}
+}
+
+func (h *hub) deliver(e domain.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[e.Topic] {
+		h.sendLocked(e, sub)
+	}
+}
+
+// catchUp hands a subscriber the events it may have missed while it was
+// being registered.
+func (h *hub) catchUp(sub *subscriber, events []domain.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, e := range events {
+		h.sendLocked(e, sub)
+	}
+}
+
+func (h *hub) sendLocked(e domain.Event, sub *subscriber) {
+	if sub.closed || (sub.lastID != "" && !domain.EventIDAfter(e.ID, sub.lastID)) {
+		return
+	}
+	select {
+	case sub.ch <- e:
+		sub.lastID = e.ID
+	default:
+		h.closeLocked(e.Topic, sub)
+	}
+}
@@ -0,0 +1,89 @@
+package events
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+)
+
+// MemoryBroker is an in-process EventBroker for development and tests. It
+// only reaches subscribers connected to the same process.
+type MemoryBroker struct {
+	mu          sync.Mutex
+	hub         *hub
+	seq         uint64
+	history     map[string][]domain.Event
+	historySize int
+}
+
+func NewMemoryBroker(historySize int) *MemoryBroker {
+	return &MemoryBroker{
+		hub:         newHub(),
+		history:     make(map[string][]domain.Event),
+		historySize: historySize,
+	}
+}
+
+func (b *MemoryBroker) Publish(_ context.Context, events ...domain.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, e := range events {
+		b.seq++
+		e.ID = strconv.FormatUint(b.seq, 10)
+
+		history := append(b.history[e.Topic], e)
+		if len(history) > b.historySize {
+			history = history[len(history)-b.historySize:]
+		}
+		b.history[e.Topic] = history
+
+		b.hub.deliver(e)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(ctx context.Context, topic, lastEventID string, buffer int) (<-chan domain.Event, error) {
+	b.mu.Lock()
+	var replay []domain.Event
+	if lastEventID != "" {
+		for _, e := range b.history[topic] {
+			if domain.EventIDAfter(e.ID, lastEventID) {
+				replay = append(replay, e)
+			}
+		}
+	}
+	sub := b.hub.add(topic, lastEventID, replay, buffer)
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.hub.remove(topic, sub)
+	}()
+	return sub.ch, nil
+}
+
+func (b *MemoryBroker) DeleteHistory(_ context.Context, topics ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, topic := range topics {
+		delete(b.history, topic)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) DeleteUserEvents(_ context.Context, userID string, topics ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, topic := range topics {
+		b.history[topic] = slices.DeleteFunc(b.history[topic], func(e domain.Event) bool {
+			return e.InvolvesUser(userID)
+		})
+	}
+	return nil
+}
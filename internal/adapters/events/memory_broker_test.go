@@ -0,0 +1,115 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func tweetEvent(topic, tweetID string) domain.Event {
+	return domain.Event{Topic: topic, Type: domain.EventTweet, Tweet: &domain.Tweet{ID: tweetID}}
+}
+
+func TestMemoryBroker(t *testing.T) {
+	t.Run("Success: should deliver events only to subscribers of the topic", func(t *testing.T) {
+		broker := NewMemoryBroker(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := broker.Subscribe(ctx, "timeline:user-1", "", 4)
+		assert.NoError(t, err)
+
+		assert.NoError(t, broker.Publish(ctx, tweetEvent("timeline:user-2", "t1"), tweetEvent("timeline:user-1", "t2")))
+
+		e := <-events
+		assert.Equal(t, "t2", e.Tweet.ID)
+		assert.Equal(t, "2", e.ID)
+		assert.Empty(t, events)
+	})
+
+	t.Run("Success: should replay retained events after Last-Event-ID", func(t *testing.T) {
+		broker := NewMemoryBroker(2)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		topic := "timeline:user-1"
+		assert.NoError(t, broker.Publish(ctx, tweetEvent(topic, "t1"), tweetEvent(topic, "t2"), tweetEvent(topic, "t3")))
+
+		events, err := broker.Subscribe(ctx, topic, "1", 4)
+		assert.NoError(t, err)
+		assert.NoError(t, broker.Publish(ctx, tweetEvent(topic, "t4")))
+
+		var ids []string
+		for range 3 {
+			ids = append(ids, (<-events).Tweet.ID)
+		}
+		assert.Equal(t, []string{"t2", "t3", "t4"}, ids)
+	})
+
+	t.Run("Success: should drop subscribers that fall behind", func(t *testing.T) {
+		broker := NewMemoryBroker(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		topic := "timeline:user-1"
+		events, err := broker.Subscribe(ctx, topic, "", 1)
+		assert.NoError(t, err)
+
+		assert.NoError(t, broker.Publish(ctx, tweetEvent(topic, "t1"), tweetEvent(topic, "t2")))
+
+		e, ok := <-events
+		assert.True(t, ok)
+		assert.Equal(t, "t1", e.Tweet.ID)
+		_, ok = <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("Success: should close the channel when the subscriber leaves", func(t *testing.T) {
+		broker := NewMemoryBroker(10)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		events, err := broker.Subscribe(ctx, "timeline:user-1", "", 1)
+		assert.NoError(t, err)
+		cancel()
+
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("Success: should not replay the events of a deleted history", func(t *testing.T) {
+		broker := NewMemoryBroker(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		topic := "timeline:user-1"
+		assert.NoError(t, broker.Publish(ctx, tweetEvent(topic, "t1"), tweetEvent("timeline:user-2", "t2")))
+		assert.NoError(t, broker.DeleteHistory(ctx, topic))
+
+		events, err := broker.Subscribe(ctx, topic, "0", 4)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+
+		other, err := broker.Subscribe(ctx, "timeline:user-2", "0", 4)
+		assert.NoError(t, err)
+		assert.Equal(t, "t2", (<-other).Tweet.ID)
+	})
+
+	t.Run("Success: should only drop the retained events of the user", func(t *testing.T) {
+		broker := NewMemoryBroker(10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		topic := "timeline:user-1"
+		erased := domain.Event{Topic: topic, Type: domain.EventTweet, Tweet: &domain.Tweet{ID: "t1", UserID: "user-2"}}
+		kept := domain.Event{Topic: topic, Type: domain.EventTweet, Tweet: &domain.Tweet{ID: "t2", UserID: "user-3"}}
+		assert.NoError(t, broker.Publish(ctx, erased, kept))
+		assert.NoError(t, broker.DeleteUserEvents(ctx, "user-2", topic))
+
+		events, err := broker.Subscribe(ctx, topic, "0", 4)
+		assert.NoError(t, err)
+		assert.Equal(t, "t2", (<-events).Tweet.ID)
+		assert.Empty(t, events)
+	})
+}
@@ -0,0 +1,210 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	eventSeqPrefix     = "events:seq:"
+	eventChannelPrefix = "events:topic:"
+	eventHistoryPrefix = "events:history:"
+	eventHistoryTTL    = time.Hour
+)
+
+// publishScript assigns the next ID of the topic, retains the event and
+// publishes it in one step. Redis runs scripts one at a time, so the events of
+// a topic are published, retained and delivered in the order of their IDs
+// even when several replicas publish to it at once; subscribers can then drop
+// anything that is not after the last event they got. The ID never goes below
+// the server clock in microseconds, so it keeps growing after the sequence
+// expires along with an idle topic.
+var publishScript = redis.NewScript(`
+local t = redis.call('TIME')
+local id = math.max(redis.call('INCR', KEYS[1]), t[1] * 1000000 + t[2])
+redis.call('SET', KEYS[1], string.format('%d', id), 'EX', ARGV[3])
+local msg = string.format('%d', id) .. ' ' .. ARGV[1]
+redis.call('RPUSH', KEYS[2], msg)
+redis.call('LTRIM', KEYS[2], -tonumber(ARGV[2]), -1)
+redis.call('EXPIRE', KEYS[2], ARGV[3])
+redis.call('PUBLISH', KEYS[3], msg)
+return id
+`)
+
+// RedisBroker shares events between replicas through Redis pub/sub. Every
+// replica holds a single pattern subscription and fans events out to its own
+// connected subscribers; a capped list per topic allows resuming streams.
+// Messages are the event ID, a space and the event as JSON.
+type RedisBroker struct {
+	client      *redis.Client
+	hub         *hub
+	historySize int
+	logger      *slog.Logger
+}
+
+// NewRedisBroker starts listening for events right away and stops when ctx is
+// cancelled.
+func NewRedisBroker(ctx context.Context, client *redis.Client, historySize int, logger *slog.Logger) *RedisBroker {
+	b := &RedisBroker{
+		client:      client,
+		hub:         newHub(),
+		historySize: historySize,
+		logger:      logger.With("component", "RedisBroker"),
+	}
+	go b.listen(ctx)
+	return b
+}
+
+func (b *RedisBroker) listen(ctx context.Context) {
+	pubsub := b.client.PSubscribe(ctx, eventChannelPrefix+"*")
+	defer pubsub.Close()
+
+	for msg := range pubsub.Channel() {
+		e, err := decodeEvent(msg.Payload)
+		if err != nil {
+			b.logger.Error("Failed to decode event", "error", err, "channel", msg.Channel)
+			continue
+		}
+		b.hub.deliver(e)
+	}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	pipe := b.client.Pipeline()
+	for _, e := range events {
+		e.ID = ""
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		keys := []string{eventSeqPrefix + e.Topic, eventHistoryPrefix + e.Topic, eventChannelPrefix + e.Topic}
+		publishScript.Eval(ctx, pipe, keys, data, b.historySize, int(eventHistoryTTL.Seconds()))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("error publishing events: %w", err)
+	}
+	return nil
+}
+
+func (b *RedisBroker) Subscribe(ctx context.Context, topic, lastEventID string, buffer int) (<-chan domain.Event, error) {
+	var replay []domain.Event
+	if lastEventID != "" {
+		history, err := b.history(ctx, topic, lastEventID)
+		if err != nil {
+			return nil, err
+		}
+		replay = history
+	}
+
+	sub := b.hub.add(topic, lastEventID, replay, buffer)
+	go func() {
+		<-ctx.Done()
+		b.hub.remove(topic, sub)
+	}()
+
+	// Events published between reading the history and registering the
+	// subscriber only reached Redis; read the history again to pick them up.
+	if lastEventID != "" {
+		missed, err := b.history(ctx, topic, lastEventID)
+		if err != nil {
+			b.logger.Warn("Failed to catch up subscriber", "error", err, "topic", topic)
+		} else {
+			b.hub.catchUp(sub, missed)
+		}
+	}
+	return sub.ch, nil
+}
+
+// historyDeleteBatch bounds the topics handled per command, since the
+// followers of a popular user can add up to many.
+const historyDeleteBatch = 1000
+
+func (b *RedisBroker) DeleteHistory(ctx context.Context, topics ...string) error {
+	for chunk := range slices.Chunk(topics, historyDeleteBatch) {
+		keys := make([]string, len(chunk))
+		for i, topic := range chunk {
+			keys[i] = eventHistoryPrefix + topic
+		}
+		if err := b.client.Del(ctx, keys...).Err(); err != nil {
+			return fmt.Errorf("error deleting event history: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteUserEvents removes each matching message by value, so events
+// published to the topic in the meantime are kept.
+func (b *RedisBroker) DeleteUserEvents(ctx context.Context, userID string, topics ...string) error {
+	for chunk := range slices.Chunk(topics, historyDeleteBatch) {
+		reads := make([]*redis.StringSliceCmd, len(chunk))
+		pipe := b.client.Pipeline()
+		for i, topic := range chunk {
+			reads[i] = pipe.LRange(ctx, eventHistoryPrefix+topic, 0, -1)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return fmt.Errorf("error reading event history: %w", err)
+		}
+
+		pipe = b.client.Pipeline()
+		for i, read := range reads {
+			for _, msg := range read.Val() {
+				if e, err := decodeEvent(msg); err == nil && e.InvolvesUser(userID) {
+					pipe.LRem(ctx, eventHistoryPrefix+chunk[i], 0, msg)
+				}
+			}
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return fmt.Errorf("error deleting events: %w", err)
+		}
+	}
+	return nil
+}
+
+func (b *RedisBroker) history(ctx context.Context, topic, lastEventID string) ([]domain.Event, error) {
+	values, err := b.client.LRange(ctx, eventHistoryPrefix+topic, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error reading event history: %w", err)
+	}
+
+	var events []domain.Event
+	for _, v := range values {
+		e, err := decodeEvent(v)
+		if err != nil {
+			continue
+		}
+		if domain.EventIDAfter(e.ID, lastEventID) {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+var errMalformedEvent = errors.New("malformed event message")
+
+func decodeEvent(msg string) (domain.Event, error) {
+	var e domain.Event
+	id, data, ok := strings.Cut(msg, " ")
+	if _, err := strconv.ParseUint(id, 10, 64); !ok || err != nil {
+		return e, errMalformedEvent
+	}
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		return e, err
+	}
+	e.ID = id
+	return e, nil
+}
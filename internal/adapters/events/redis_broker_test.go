@@ -0,0 +1,29 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	t.Run("Success: should take the ID from the message prefix", func(t *testing.T) {
+		e, err := decodeEvent(`1718000000000001 {"ID":"","Topic":"timeline:ana","Type":"tweet","Tweet":{"ID":"t1"}}`)
+
+		require.NoError(t, err)
+		assert.Equal(t, "1718000000000001", e.ID)
+		assert.Equal(t, "timeline:ana", e.Topic)
+		assert.Equal(t, domain.EventType("tweet"), e.Type)
+		assert.Equal(t, "t1", e.Tweet.ID)
+	})
+
+	t.Run("Failure: should reject messages without a numeric ID", func(t *testing.T) {
+		_, err := decodeEvent(`{"ID":"3","Topic":"timeline:ana"}`)
+		assert.Error(t, err)
+
+		_, err = decodeEvent(`abc {"Topic":"timeline:ana"}`)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,202 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+)
+
+// Both errors wrap domain.ErrPageNotUnfurlable, like every failure that a
+// later fetch would run into again.
+var (
+	ErrBlockedDestination = fmt.Errorf("%w: destination not allowed", domain.ErrPageNotUnfurlable)
+	ErrNotHTML            = fmt.Errorf("%w: page is not HTML", domain.ErrPageNotUnfurlable)
+)
+
+// maxRedirects is how many redirects a fetch follows before giving up.
+const maxRedirects = 3
+
+// Policy decides which pages the fetcher may request. Hosts match
+// themselves and their subdomains. Addresses that are not publicly routable
+// (loopback, private, link-local, cloud metadata, ...) are always refused
+// unless they fall in AllowedNetworks.
+type Policy struct {
+	// AllowedHosts, when not empty, is the only set of hosts reachable.
+	AllowedHosts []string
+	DeniedHosts  []string
+	// AllowedNetworks lets trusted internal addresses through.
+	AllowedNetworks []netip.Prefix
+}
+
+type Config struct {
+	Policy Policy
+	// Timeout bounds a whole fetch: connecting, redirects and reading the
+	// body.
+	Timeout time.Duration
+	// MaxBytes is how much of a page is read; the rest is ignored, which
+	// keeps the <head>, where the metadata lives, of any reasonable page.
+	MaxBytes int64
+}
+
+// HTTPFetcher downloads web pages on behalf of the server without letting
+// users reach internal services through it. Destinations are checked on
+// every redirect and again on the address actually dialed, so a hostname
+// that resolves to an internal address is refused too.
+type HTTPFetcher struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewHTTPFetcher(cfg Config) *HTTPFetcher {
+	f := &HTTPFetcher{cfg: cfg}
+
+	dialer := &net.Dialer{
+		Timeout: cfg.Timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			return f.checkAddress(address)
+		},
+	}
+	f.client = &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &http.Transport{
+			// Never go through a proxy: the dialed address is what the
+			// policy checks.
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   cfg.Timeout,
+			ResponseHeaderTimeout: cfg.Timeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return fmt.Errorf("%w: too many redirects", domain.ErrPageNotUnfurlable)
+			}
+			return f.checkURL(req.URL)
+		},
+	}
+	return f
+}
+
+func (f *HTTPFetcher) FetchPage(ctx context.Context, rawURL string) ([]byte, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrPageNotUnfurlable, err)
+	}
+	if err := f.checkURL(target); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "uala-challenge-unfurler/1.0")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if permanentStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("%w: fetching %s: status %d", domain.ErrPageNotUnfurlable, rawURL, resp.StatusCode)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("fetching %s: unexpected status %d", rawURL, resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, f.cfg.MaxBytes))
+}
+
+// permanentStatus reports the client errors that mean the page is not there
+// for us, as opposed to a timeout or rate limit that may clear up.
+func permanentStatus(code int) bool {
+	return code >= 400 && code <= 499 && code != http.StatusRequestTimeout && code != http.StatusTooManyRequests
+}
+
+// checkURL applies the scheme and host rules of the policy.
+func (f *HTTPFetcher) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrBlockedDestination
+	}
+	if u.User != nil {
+		return ErrBlockedDestination
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" || matchesHost(host, f.cfg.Policy.DeniedHosts) {
+		return ErrBlockedDestination
+	}
+	if len(f.cfg.Policy.AllowedHosts) > 0 && !matchesHost(host, f.cfg.Policy.AllowedHosts) {
+		return ErrBlockedDestination
+	}
+	return nil
+}
+
+// checkAddress refuses to connect to addresses that are not publicly
+// routable, unless the policy trusts them.
+func (f *HTTPFetcher) checkAddress(address string) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return ErrBlockedDestination
+	}
+	addr := addrPort.Addr().Unmap()
+
+	for _, network := range f.cfg.Policy.AllowedNetworks {
+		if network.Contains(addr) {
+			return nil
+		}
+	}
+	if !isPublic(addr) {
+		return ErrBlockedDestination
+	}
+	return nil
+}
+
+var nonPublicNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+func isPublic(addr netip.Addr) bool {
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesHost(host string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(strings.ToLower(pattern), ".")
+		if host == pattern || strings.HasSuffix(host, "."+pattern) {
+			return true
+		}
+	}
+	return false
+}
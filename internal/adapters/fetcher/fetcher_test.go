@@ -0,0 +1,178 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const page = `<html><head><title>Hola</title></head><body>mundo</body></html>`
+
+// trustLoopback lets the fetcher reach the local httptest server, which the
+// default policy refuses like any other internal address.
+func trustLoopback(cfg Config) Config {
+	cfg.Policy.AllowedNetworks = append(cfg.Policy.AllowedNetworks, netip.MustParsePrefix("127.0.0.0/8"))
+	return cfg
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(page))
+	})
+	mux.HandleFunc("/big", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(strings.Repeat("a", 4096)))
+	})
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG"))
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(page))
+	})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusNotFound)
+	})
+	mux.HandleFunc("/down", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Query().Get("to"), http.StatusFound)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPFetcher_FetchPage(t *testing.T) {
+	ctx := context.Background()
+	server := newServer(t)
+	cfg := Config{Timeout: time.Second, MaxBytes: 1024}
+
+	t.Run("Success: should return the page", func(t *testing.T) {
+		fetcher := NewHTTPFetcher(trustLoopback(cfg))
+
+		body, err := fetcher.FetchPage(ctx, server.URL+"/page")
+
+		require.NoError(t, err)
+		assert.Equal(t, page, string(body))
+	})
+
+	t.Run("Success: should read at most MaxBytes of the page", func(t *testing.T) {
+		fetcher := NewHTTPFetcher(trustLoopback(cfg))
+
+		body, err := fetcher.FetchPage(ctx, server.URL+"/big")
+
+		require.NoError(t, err)
+		assert.Len(t, body, 1024)
+	})
+
+	t.Run("Failure: should refuse internal addresses by default", func(t *testing.T) {
+		fetcher := NewHTTPFetcher(cfg)
+
+		_, err := fetcher.FetchPage(ctx, server.URL+"/page")
+
+		assert.ErrorIs(t, err, ErrBlockedDestination)
+	})
+
+	t.Run("Failure: should refuse hostnames that resolve to internal addresses", func(t *testing.T) {
+		fetcher := NewHTTPFetcher(cfg)
+		url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+		_, err := fetcher.FetchPage(ctx, url+"/page")
+
+		assert.ErrorIs(t, err, ErrBlockedDestination)
+	})
+
+	t.Run("Failure: should check every redirect against the policy", func(t *testing.T) {
+		denied := trustLoopback(cfg)
+		denied.Policy.DeniedHosts = []string{"localhost"}
+		fetcher := NewHTTPFetcher(denied)
+		target := strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/page"
+
+		_, err := fetcher.FetchPage(ctx, server.URL+"/redirect?to="+target)
+
+		assert.ErrorIs(t, err, ErrBlockedDestination)
+	})
+
+	t.Run("Failure: should only reach the allowed hosts when some are set", func(t *testing.T) {
+		allowed := trustLoopback(cfg)
+		allowed.Policy.AllowedHosts = []string{"example.com"}
+		fetcher := NewHTTPFetcher(allowed)
+
+		_, err := fetcher.FetchPage(ctx, server.URL+"/page")
+
+		assert.ErrorIs(t, err, ErrBlockedDestination)
+	})
+
+	t.Run("Failure: should refuse other schemes and credentials", func(t *testing.T) {
+		fetcher := NewHTTPFetcher(trustLoopback(cfg))
+
+		_, err := fetcher.FetchPage(ctx, "file:///etc/passwd")
+		assert.ErrorIs(t, err, ErrBlockedDestination)
+
+		_, err = fetcher.FetchPage(ctx, strings.Replace(server.URL, "http://", "http://user:pass@", 1)+"/page")
+		assert.ErrorIs(t, err, ErrBlockedDestination)
+	})
+
+	t.Run("Failure: should reject responses that are not HTML", func(t *testing.T) {
+		fetcher := NewHTTPFetcher(trustLoopback(cfg))
+
+		_, err := fetcher.FetchPage(ctx, server.URL+"/image")
+
+		assert.ErrorIs(t, err, ErrNotHTML)
+	})
+
+	t.Run("Failure: should tell missing pages apart from server errors", func(t *testing.T) {
+		fetcher := NewHTTPFetcher(trustLoopback(cfg))
+
+		_, err := fetcher.FetchPage(ctx, server.URL+"/gone")
+		assert.ErrorIs(t, err, domain.ErrPageNotUnfurlable)
+
+		_, err = fetcher.FetchPage(ctx, server.URL+"/down")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, domain.ErrPageNotUnfurlable)
+	})
+
+	t.Run("Failure: should give up on slow servers", func(t *testing.T) {
+		slow := trustLoopback(cfg)
+		slow.Timeout = 50 * time.Millisecond
+		fetcher := NewHTTPFetcher(slow)
+
+		_, err := fetcher.FetchPage(ctx, server.URL+"/slow")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestIsPublic(t *testing.T) {
+	for address, public := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	} {
+		assert.Equal(t, public, isPublic(netip.MustParseAddr(address)), address)
+	}
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+)
+
+// exportProfile is the profile.json of a data export.
+type exportProfile struct {
+	User       domain.User
+	Following  []string
+	Followers  []string
+	ExportedAt time.Time
+}
+
+// writeDataExport packs the export as a zip archive: the profile and the
+// tweets as JSON, plus the tweets and both sides of the follow graph as CSV
+// for spreadsheets.
+func writeDataExport(w io.Writer, export *domain.DataExport) error {
+	archive := zip.NewWriter(w)
+
+	profile := exportProfile{
+		User:       export.User,
+		Following:  nonNil(export.Following),
+		Followers:  nonNil(export.Followers),
+		ExportedAt: export.ExportedAt,
+	}
+	if err := writeJSONFile(archive, "profile.json", profile, export.ExportedAt); err != nil {
+		return err
+	}
+	if err := writeJSONFile(archive, "tweets.json", nonNil(export.Tweets), export.ExportedAt); err != nil {
+		return err
+	}
+
+	tweetRows := [][]string{{"id", "created_at", "edited_at", "text", "media_ids"}}
+	for _, tweet := range export.Tweets {
+		editedAt := ""
+		if tweet.EditedAt != nil {
+			editedAt = tweet.EditedAt.Format(time.RFC3339)
+		}
+		tweetRows = append(tweetRows, []string{tweet.ID, tweet.CreatedAt.Format(time.RFC3339), editedAt, tweet.Text, strings.Join(tweet.MediaIDs, " ")})
+	}
+	if err := writeCSVFile(archive, "tweets.csv", tweetRows, export.ExportedAt); err != nil {
+		return err
+	}
+	if err := writeCSVFile(archive, "following.csv", userRows(export.Following), export.ExportedAt); err != nil {
+		return err
+	}
+	if err := writeCSVFile(archive, "followers.csv", userRows(export.Followers), export.ExportedAt); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func writeJSONFile(archive *zip.Writer, name string, v any, modified time.Time) error {
+	f, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeCSVFile(archive *zip.Writer, name string, rows [][]string, modified time.Time) error {
+	f, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	return csv.NewWriter(f).WriteAll(rows)
+}
+
+func userRows(userIDs []string) [][]string {
+	rows := [][]string{{"user_id"}}
+	for _, id := range userIDs {
+		rows = append(rows, []string{id})
+	}
+	return rows
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -1,9 +1,18 @@
 package http
 
 import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/EstefiS/uala-challenge/internal/core/services"
@@ -29,6 +38,41 @@ func (h *GinHandler) badRequest(c *gin.Context, errorCode, message string) {
 	})
 }
 
+func (h *GinHandler) forbidden(c *gin.Context, errorCode, message string) {
+	c.JSON(http.StatusForbidden, ErrorResponse{
+		ErrorCode: errorCode,
+		Message:   message,
+	})
+}
+
+func (h *GinHandler) notFound(c *gin.Context, errorCode, message string) {
+	c.JSON(http.StatusNotFound, ErrorResponse{
+		ErrorCode: errorCode,
+		Message:   message,
+	})
+}
+
+func (h *GinHandler) conflict(c *gin.Context, errorCode, message string) {
+	c.JSON(http.StatusConflict, ErrorResponse{
+		ErrorCode: errorCode,
+		Message:   message,
+	})
+}
+
+func (h *GinHandler) payloadTooLarge(c *gin.Context, errorCode, message string) {
+	c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+		ErrorCode: errorCode,
+		Message:   message,
+	})
+}
+
+func (h *GinHandler) unprocessableEntity(c *gin.Context, errorCode, message string) {
+	c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+		ErrorCode: errorCode,
+		Message:   message,
+	})
+}
+
 func (h *GinHandler) internalServerError(c *gin.Context, err error, attributes ...slog.Attr) {
 	h.logger.Error("Internal server error", "error", err, "attributes", attributes)
 	c.JSON(http.StatusInternalServerError, ErrorResponse{
@@ -52,13 +96,105 @@ func extractUserID() gin.HandlerFunc {
 	}
 }
 
+// requireAdmin only lets the configured admins through; it must run after
+// extractUserID. X-User-ID cannot be trusted on its own, since user IDs are
+// public, so the request must also carry the admin token as a bearer
+// credential. Without a configured token the admin API is closed.
+func requireAdmin(adminIDs []string, adminToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		validToken := ok && adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+		if !validToken || !slices.Contains(adminIDs, c.GetString("userID")) {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+				ErrorCode: "ADMIN_REQUIRED",
+				Message:   "this operation is restricted to admins",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
 func (h *GinHandler) SetupRoutes(router *gin.Engine) {
 	api := router.Group("/api/v1")
 	api.Use(extractUserID())
 	{
 		api.POST("/tweets", h.publishTweet)
+		api.PUT("/tweets/:id", h.editTweet)
+		api.DELETE("/tweets/:id", h.deleteTweet)
+		api.GET("/tweets/:id/history", h.getTweetHistory)
+		api.GET("/tweets/:id/poll", h.getPollResults)
+		api.POST("/tweets/:id/poll/votes", h.votePoll)
+		api.POST("/media", h.uploadMedia)
+		api.GET("/media/:id", h.getMedia)
+		api.GET("/media/:id/thumbnail", h.getMediaThumbnail)
+		api.GET("/drafts", h.getDrafts)
+		api.POST("/drafts", h.createDraft)
+		api.PUT("/drafts/:id", h.updateDraft)
+		api.DELETE("/drafts/:id", h.deleteDraft)
+		api.POST("/drafts/:id/publish", h.publishDraft)
+		api.GET("/scheduled-tweets", h.getScheduledTweets)
+		api.PUT("/scheduled-tweets/:id", h.updateScheduledTweet)
+		api.DELETE("/scheduled-tweets/:id", h.cancelScheduledTweet)
+		api.POST("/tweets/:id/bookmark", h.bookmarkTweet)
+		api.DELETE("/tweets/:id/bookmark", h.removeBookmark)
+		api.GET("/bookmarks", h.getBookmarks)
+		api.PATCH("/me", h.updateAccount)
+		api.DELETE("/me", h.deleteAccount)
+		api.GET("/me/export", h.exportData)
+		api.POST("/me/deactivate", h.deactivateAccount)
+		api.POST("/me/reactivate", h.reactivateAccount)
+		api.GET("/users/suggestions", h.getSuggestions)
+		api.GET("/users/:id/tweets", h.getProfileTweets)
 		api.POST("/users/:id/follow", h.followUser)
+		api.GET("/follow-requests", h.getFollowRequests)
+		api.POST("/follow-requests/:id/approve", h.approveFollowRequest)
+		api.POST("/follow-requests/:id/reject", h.rejectFollowRequest)
+		api.POST("/users/:id/block", h.blockUser)
+		api.DELETE("/users/:id/block", h.unblockUser)
+		api.POST("/users/:id/mute", h.muteUser)
+		api.DELETE("/users/:id/mute", h.unmuteUser)
 		api.GET("/timeline", h.getTimeline)
+		api.GET("/timeline/stream", h.streamTimeline)
+		api.GET("/ws", h.serveWebSocket)
+		api.GET("/mentions", h.getMentions)
+		api.GET("/hashtags/:tag/tweets", h.getHashtagTweets)
+		api.GET("/trends", h.getTrends)
+		api.GET("/search", h.searchTweets)
+		api.GET("/notifications", h.getNotifications)
+		api.POST("/notifications/read", h.markNotificationsRead)
+		api.GET("/conversations", h.getConversations)
+		api.POST("/conversations", h.startConversation)
+		api.GET("/conversations/:id/messages", h.getMessages)
+		api.POST("/conversations/:id/messages", h.sendMessage)
+		api.POST("/conversations/:id/read", h.markConversationRead)
+		api.GET("/lists", h.getLists)
+		api.POST("/lists", h.createList)
+		api.GET("/lists/:id", h.getList)
+		api.PUT("/lists/:id", h.updateList)
+		api.DELETE("/lists/:id", h.deleteList)
+		api.GET("/lists/:id/members", h.getListMembers)
+		api.POST("/lists/:id/members/:user_id", h.addListMember)
+		api.DELETE("/lists/:id/members/:user_id", h.removeListMember)
+		api.GET("/lists/:id/timeline", h.getListTimeline)
+		api.GET("/filters", h.getFilters)
+		api.POST("/filters", h.addFilter)
+		api.DELETE("/filters/:id", h.removeFilter)
+		api.POST("/reports", h.createReport)
+	}
+
+	admin := api.Group("/admin")
+	admin.Use(requireAdmin(h.deps.AdminUserIDs, h.deps.AdminToken))
+	{
+		admin.GET("/held-tweets", h.getHeldTweets)
+		admin.POST("/held-tweets/:id/approve", h.approveHeldTweet)
+		admin.POST("/held-tweets/:id/reject", h.rejectHeldTweet)
+		admin.GET("/reports", h.getReports)
+		admin.POST("/reports/:id/triage", h.triageReport)
+		admin.POST("/reports/:id/resolve", h.resolveReport)
+		admin.POST("/users/:id/suspend", h.suspendUser)
+		admin.DELETE("/users/:id/suspend", h.unsuspendUser)
+		admin.DELETE("/tweets/:id", h.removeTweet)
 	}
 }
 
@@ -71,48 +207,1327 @@ func (h *GinHandler) publishTweet(c *gin.Context) {
 		return
 	}
 
-	tweet, err := h.deps.TweetSvc.PublishTweet(c.Request.Context(), userID, req.Text)
+	if req.PublishAt != nil {
+		if len(req.MediaIDs) > 0 || req.Poll != nil {
+			h.badRequest(c, "SCHEDULED_ATTACHMENTS_NOT_SUPPORTED", "scheduled tweets cannot have media or polls")
+			return
+		}
+		scheduled, err := h.deps.ScheduleSvc.ScheduleTweet(c.Request.Context(), userID, req.Text, *req.PublishAt)
+		if err != nil {
+			h.scheduleError(c, err, slog.String("userID", userID))
+			return
+		}
+		c.JSON(http.StatusCreated, scheduled)
+		return
+	}
+
+	content := domain.TweetContent{Text: req.Text, MediaIDs: req.MediaIDs}
+	if req.Poll != nil {
+		content.Poll = &domain.PollSpec{
+			Options:  req.Poll.Options,
+			Duration: time.Duration(req.Poll.DurationMinutes) * time.Minute,
+		}
+	}
+	tweet, err := h.deps.TweetSvc.PublishTweet(c.Request.Context(), userID, content)
 	if err != nil {
-		if errors.Is(err, domain.ErrTweetTooLong) {
+		if h.moderationOutcome(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrTweetTooLong):
 			h.badRequest(c, "TWEET_TOO_LONG", err.Error())
+		case errors.Is(err, domain.ErrTooManyMedia):
+			h.badRequest(c, "TOO_MANY_MEDIA", err.Error())
+		case errors.Is(err, domain.ErrMediaNotFound):
+			h.badRequest(c, "INVALID_MEDIA", err.Error())
+		case errors.Is(err, domain.ErrInvalidPollOptions), errors.Is(err, domain.ErrPollOptionTooLong):
+			h.badRequest(c, "INVALID_POLL_OPTIONS", err.Error())
+		case errors.Is(err, domain.ErrInvalidPollDuration):
+			h.badRequest(c, "INVALID_POLL_DURATION", err.Error())
+		default:
+			h.internalServerError(c, err, slog.String("userID", userID))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, tweet)
+}
+
+// moderationOutcome answers for tweets stopped by moderation: held tweets
+// are accepted for review, rejected ones report the reason code of the
+// decision as the error code and users whose account is not active are
+// forbidden to publish. It reports whether err was one of them.
+func (h *GinHandler) moderationOutcome(c *gin.Context, err error) bool {
+	var rejection *domain.RejectionError
+	switch {
+	case errors.As(err, &rejection):
+		h.unprocessableEntity(c, rejection.Reason, err.Error())
+	case errors.Is(err, domain.ErrTweetHeldForReview):
+		c.JSON(http.StatusAccepted, StatusResponse{Status: "held"})
+	default:
+		return h.accountStatusError(c, err)
+	}
+	return true
+}
+
+func (h *GinHandler) getHeldTweets(c *gin.Context) {
+	tweets, err := h.deps.ModerationSvc.GetHeldTweets(c.Request.Context())
+	if err != nil {
+		h.internalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tweets)
+}
+
+func (h *GinHandler) approveHeldTweet(c *gin.Context) {
+	heldTweetID := c.Param("id")
+
+	tweet, err := h.deps.ModerationSvc.ApproveHeldTweet(c.Request.Context(), heldTweetID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrHeldTweetNotFound):
+			h.notFound(c, "HELD_TWEET_NOT_FOUND", err.Error())
+		case errors.Is(err, domain.ErrMediaNotFound):
+			h.conflict(c, "INVALID_MEDIA", err.Error())
+		case errors.Is(err, domain.ErrAccountSuspended):
+			h.conflict(c, "ACCOUNT_SUSPENDED", err.Error())
+		case errors.Is(err, domain.ErrAccountDeactivated):
+			h.conflict(c, "ACCOUNT_DEACTIVATED", err.Error())
+		default:
+			h.internalServerError(c, err, slog.String("heldTweetID", heldTweetID))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, tweet)
+}
+
+func (h *GinHandler) rejectHeldTweet(c *gin.Context) {
+	heldTweetID := c.Param("id")
+
+	if err := h.deps.ModerationSvc.RejectHeldTweet(c.Request.Context(), heldTweetID); err != nil {
+		if errors.Is(err, domain.ErrHeldTweetNotFound) {
+			h.notFound(c, "HELD_TWEET_NOT_FOUND", err.Error())
 			return
 		}
+		h.internalServerError(c, err, slog.String("heldTweetID", heldTweetID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) createReport(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req CreateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	report, err := h.deps.ReportSvc.CreateReport(c.Request.Context(), userID, domain.ReportInput{
+		TargetType: domain.ReportTarget(req.TargetType),
+		TargetID:   req.TargetID,
+		Reason:     domain.ReportReason(req.Reason),
+		Comment:    req.Comment,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidReportTarget):
+			h.badRequest(c, "INVALID_REPORT_TARGET", err.Error())
+		case errors.Is(err, domain.ErrInvalidReportReason):
+			h.badRequest(c, "INVALID_REPORT_REASON", err.Error())
+		case errors.Is(err, domain.ErrReportCommentTooLong):
+			h.badRequest(c, "REPORT_COMMENT_TOO_LONG", err.Error())
+		case errors.Is(err, domain.ErrCannotReportSelf):
+			h.badRequest(c, "CANNOT_REPORT_SELF", err.Error())
+		case errors.Is(err, domain.ErrTweetNotFound):
+			h.notFound(c, "TWEET_NOT_FOUND", err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			h.notFound(c, "USER_NOT_FOUND", err.Error())
+		case errors.Is(err, domain.ErrAlreadyReported):
+			h.conflict(c, "ALREADY_REPORTED", err.Error())
+		default:
+			h.internalServerError(c, err, slog.String("userID", userID))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// getReports lists the reports with the status given in the query, the
+// open ones by default.
+func (h *GinHandler) getReports(c *gin.Context) {
+	status, err := domain.ParseReportStatus(c.DefaultQuery("status", string(domain.ReportOpen)))
+	if err != nil {
+		h.badRequest(c, "INVALID_REPORT_STATUS", err.Error())
+		return
+	}
+
+	reports, err := h.deps.ReportSvc.GetReports(c.Request.Context(), status)
+	if err != nil {
+		h.internalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+func (h *GinHandler) triageReport(c *gin.Context) {
+	adminID := c.GetString("userID")
+	reportID := c.Param("id")
+
+	report, err := h.deps.ReportSvc.TriageReport(c.Request.Context(), adminID, reportID)
+	if err != nil {
+		h.reportError(c, err, slog.String("reportID", reportID))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *GinHandler) resolveReport(c *gin.Context) {
+	adminID := c.GetString("userID")
+	reportID := c.Param("id")
+
+	var req ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	report, err := h.deps.ReportSvc.ResolveReport(c.Request.Context(), adminID, reportID, domain.ReportResolution(req.Resolution), req.Note)
+	if err != nil {
+		h.reportError(c, err, slog.String("reportID", reportID))
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *GinHandler) reportError(c *gin.Context, err error, attributes ...slog.Attr) {
+	switch {
+	case errors.Is(err, domain.ErrReportNotFound):
+		h.notFound(c, "REPORT_NOT_FOUND", err.Error())
+	case errors.Is(err, domain.ErrReportResolved):
+		h.conflict(c, "REPORT_RESOLVED", err.Error())
+	case errors.Is(err, domain.ErrInvalidReportResolution):
+		h.badRequest(c, "INVALID_REPORT_RESOLUTION", err.Error())
+	case errors.Is(err, domain.ErrReportCommentTooLong):
+		h.badRequest(c, "REPORT_NOTE_TOO_LONG", err.Error())
+	default:
+		h.internalServerError(c, err, attributes...)
+	}
+}
+
+func (h *GinHandler) suspendUser(c *gin.Context) {
+	adminID := c.GetString("userID")
+	userID := c.Param("id")
+
+	if err := h.deps.ModerationSvc.SuspendUser(c.Request.Context(), adminID, userID); err != nil {
 		h.internalServerError(c, err, slog.String("userID", userID))
 		return
 	}
 
-	c.JSON(http.StatusCreated, tweet)
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
 }
 
-func (h *GinHandler) followUser(c *gin.Context) {
-	currentUserID := c.GetString("userID")
-	userToFollowID := c.Param("id")
+func (h *GinHandler) unsuspendUser(c *gin.Context) {
+	adminID := c.GetString("userID")
+	userID := c.Param("id")
 
-	if currentUserID == userToFollowID {
-		h.badRequest(c, "INVALID_OPERATION", "A user cannot follow themselves.")
+	if err := h.deps.ModerationSvc.UnsuspendUser(c.Request.Context(), adminID, userID); err != nil {
+		if errors.Is(err, domain.ErrAccountNotSuspended) {
+			h.conflict(c, "ACCOUNT_NOT_SUSPENDED", err.Error())
+			return
+		}
+		h.internalServerError(c, err, slog.String("userID", userID))
 		return
 	}
 
-	if err := h.deps.FollowSvc.FollowUser(c.Request.Context(), currentUserID, userToFollowID); err != nil {
-		if errors.Is(err, services.ErrSelfFollow) {
-			h.badRequest(c, "INVALID_OPERATION", err.Error())
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) removeTweet(c *gin.Context) {
+	adminID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	if err := h.deps.ModerationSvc.RemoveTweet(c.Request.Context(), adminID, tweetID); err != nil {
+		if errors.Is(err, domain.ErrTweetNotFound) {
+			h.notFound(c, "TWEET_NOT_FOUND", err.Error())
 			return
 		}
-		h.internalServerError(c, err, slog.String("follower", currentUserID), slog.String("followee", userToFollowID))
+		h.internalServerError(c, err, slog.String("tweetID", tweetID))
 		return
 	}
 
 	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
 }
 
-func (h *GinHandler) getTimeline(c *gin.Context) {
+func (h *GinHandler) pollError(c *gin.Context, err error, attributes ...slog.Attr) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPollOption):
+		h.badRequest(c, "INVALID_POLL_OPTION", err.Error())
+	case errors.Is(err, domain.ErrPollNotFound):
+		h.notFound(c, "POLL_NOT_FOUND", err.Error())
+	case errors.Is(err, domain.ErrPollClosed):
+		h.conflict(c, "POLL_CLOSED", err.Error())
+	case errors.Is(err, domain.ErrAlreadyVoted):
+		h.conflict(c, "ALREADY_VOTED", err.Error())
+	default:
+		h.internalServerError(c, err, attributes...)
+	}
+}
+
+func (h *GinHandler) getPollResults(c *gin.Context) {
+	userID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	results, err := h.deps.PollSvc.GetPollResults(c.Request.Context(), userID, tweetID)
+	if err != nil {
+		h.pollError(c, err, slog.String("userID", userID), slog.String("tweetID", tweetID))
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+func (h *GinHandler) votePoll(c *gin.Context) {
+	userID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	var req VoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	results, err := h.deps.PollSvc.Vote(c.Request.Context(), userID, tweetID, *req.Option)
+	if err != nil {
+		h.pollError(c, err, slog.String("userID", userID), slog.String("tweetID", tweetID))
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// uploadMedia accepts an image as the "file" field of a multipart form.
+func (h *GinHandler) uploadMedia(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	// Leave room for the multipart framing around the file.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, domain.MaxMediaSize+64<<10)
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.payloadTooLarge(c, "MEDIA_TOO_LARGE", domain.ErrMediaTooLarge.Error())
+			return
+		}
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+	if file.Size > domain.MaxMediaSize {
+		h.payloadTooLarge(c, "MEDIA_TOO_LARGE", domain.ErrMediaTooLarge.Error())
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	media, err := h.deps.MediaSvc.UploadMedia(c.Request.Context(), userID, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrMediaTooLarge):
+			h.payloadTooLarge(c, "MEDIA_TOO_LARGE", err.Error())
+		case errors.Is(err, domain.ErrUnsupportedMediaType):
+			h.badRequest(c, "UNSUPPORTED_MEDIA_TYPE", err.Error())
+		default:
+			h.internalServerError(c, err, slog.String("userID", userID))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, media)
+}
+
+func (h *GinHandler) getMedia(c *gin.Context) {
+	h.serveMedia(c, false)
+}
+
+func (h *GinHandler) getMediaThumbnail(c *gin.Context) {
+	h.serveMedia(c, true)
+}
+
+// serveMedia sends the blob as is. Media never change once uploaded, so
+// clients may cache them for good; only the viewer's own cache may keep the
+// media of protected or inactive accounts.
+func (h *GinHandler) serveMedia(c *gin.Context, thumbnail bool) {
+	userID := c.GetString("userID")
+	mediaID := c.Param("id")
+
+	blob, err := h.deps.MediaSvc.GetMediaBlob(c.Request.Context(), userID, mediaID, thumbnail)
+	if err != nil {
+		if errors.Is(err, domain.ErrMediaNotFound) || errors.Is(err, domain.ErrBlobNotFound) {
+			h.notFound(c, "MEDIA_NOT_FOUND", domain.ErrMediaNotFound.Error())
+			return
+		}
+		h.internalServerError(c, err, slog.String("mediaID", mediaID))
+		return
+	}
+
+	if blob.Private {
+		c.Header("Cache-Control", "private, max-age=31536000, immutable")
+	} else {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	}
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, blob.ContentType, blob.Data)
+}
+
+func (h *GinHandler) draftError(c *gin.Context, err error, attributes ...slog.Attr) {
+	switch {
+	case errors.Is(err, domain.ErrDraftTooLong):
+		h.badRequest(c, "DRAFT_TOO_LONG", err.Error())
+	case errors.Is(err, domain.ErrTweetTooLong):
+		h.badRequest(c, "TWEET_TOO_LONG", err.Error())
+	case errors.Is(err, services.ErrTooManyDrafts):
+		h.badRequest(c, "TOO_MANY_DRAFTS", err.Error())
+	case errors.Is(err, domain.ErrDraftNotFound):
+		h.notFound(c, "DRAFT_NOT_FOUND", err.Error())
+	default:
+		h.internalServerError(c, err, attributes...)
+	}
+}
+
+func (h *GinHandler) getDrafts(c *gin.Context) {
 	userID := c.GetString("userID")
 
-	timeline, err := h.deps.TimelineSvc.GetUserTimeline(c.Request.Context(), userID)
+	drafts, err := h.deps.DraftSvc.GetDrafts(c.Request.Context(), userID)
 	if err != nil {
 		h.internalServerError(c, err, slog.String("userID", userID))
 		return
 	}
 
-	c.JSON(http.StatusOK, timeline)
+	c.JSON(http.StatusOK, drafts)
+}
+
+func (h *GinHandler) createDraft(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req DraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	draft, err := h.deps.DraftSvc.CreateDraft(c.Request.Context(), userID, req.Text)
+	if err != nil {
+		h.draftError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusCreated, draft)
+}
+
+func (h *GinHandler) updateDraft(c *gin.Context) {
+	userID := c.GetString("userID")
+	draftID := c.Param("id")
+
+	var req DraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	draft, err := h.deps.DraftSvc.UpdateDraft(c.Request.Context(), userID, draftID, req.Text)
+	if err != nil {
+		h.draftError(c, err, slog.String("userID", userID), slog.String("draftID", draftID))
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+func (h *GinHandler) deleteDraft(c *gin.Context) {
+	userID := c.GetString("userID")
+	draftID := c.Param("id")
+
+	if err := h.deps.DraftSvc.DeleteDraft(c.Request.Context(), userID, draftID); err != nil {
+		h.draftError(c, err, slog.String("userID", userID), slog.String("draftID", draftID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) publishDraft(c *gin.Context) {
+	userID := c.GetString("userID")
+	draftID := c.Param("id")
+
+	tweet, err := h.deps.DraftSvc.PublishDraft(c.Request.Context(), userID, draftID)
+	if err != nil {
+		if h.moderationOutcome(c, err) {
+			return
+		}
+		h.draftError(c, err, slog.String("userID", userID), slog.String("draftID", draftID))
+		return
+	}
+
+	c.JSON(http.StatusCreated, tweet)
+}
+
+func (h *GinHandler) scheduleError(c *gin.Context, err error, attributes ...slog.Attr) {
+	switch {
+	case errors.Is(err, domain.ErrTweetTooLong):
+		h.badRequest(c, "TWEET_TOO_LONG", err.Error())
+	case errors.Is(err, domain.ErrPublishAtInPast):
+		h.badRequest(c, "INVALID_PUBLISH_AT", err.Error())
+	case errors.Is(err, domain.ErrScheduledTweetNotFound):
+		h.notFound(c, "SCHEDULED_TWEET_NOT_FOUND", err.Error())
+	case errors.Is(err, domain.ErrScheduledTweetPublished):
+		h.conflict(c, "SCHEDULED_TWEET_PUBLISHED", err.Error())
+	default:
+		h.internalServerError(c, err, attributes...)
+	}
+}
+
+func (h *GinHandler) getScheduledTweets(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	tweets, err := h.deps.ScheduleSvc.GetScheduledTweets(c.Request.Context(), userID)
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, tweets)
+}
+
+func (h *GinHandler) updateScheduledTweet(c *gin.Context) {
+	userID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	var req UpdateScheduledTweetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	scheduled, err := h.deps.ScheduleSvc.UpdateScheduledTweet(c.Request.Context(), userID, tweetID, req.Text, req.PublishAt)
+	if err != nil {
+		h.scheduleError(c, err, slog.String("userID", userID), slog.String("scheduledTweetID", tweetID))
+		return
+	}
+
+	c.JSON(http.StatusOK, scheduled)
+}
+
+func (h *GinHandler) cancelScheduledTweet(c *gin.Context) {
+	userID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	if err := h.deps.ScheduleSvc.CancelScheduledTweet(c.Request.Context(), userID, tweetID); err != nil {
+		h.scheduleError(c, err, slog.String("userID", userID), slog.String("scheduledTweetID", tweetID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) deleteTweet(c *gin.Context) {
+	userID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	if err := h.deps.TweetSvc.DeleteTweet(c.Request.Context(), userID, tweetID); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTweetNotFound):
+			h.notFound(c, "TWEET_NOT_FOUND", err.Error())
+		case errors.Is(err, domain.ErrNotTweetAuthor):
+			h.forbidden(c, "NOT_TWEET_AUTHOR", err.Error())
+		default:
+			h.internalServerError(c, err, slog.String("userID", userID), slog.String("tweetID", tweetID))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) editTweet(c *gin.Context) {
+	userID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	var req EditTweetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	tweet, err := h.deps.TweetSvc.EditTweet(c.Request.Context(), userID, tweetID, req.Text)
+	if err != nil {
+		if h.moderationOutcome(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrTweetTooLong):
+			h.badRequest(c, "TWEET_TOO_LONG", err.Error())
+		case errors.Is(err, domain.ErrTweetTextUnchanged):
+			h.badRequest(c, "TWEET_UNCHANGED", err.Error())
+		case errors.Is(err, domain.ErrTweetNotFound):
+			h.notFound(c, "TWEET_NOT_FOUND", err.Error())
+		case errors.Is(err, domain.ErrNotTweetAuthor):
+			h.forbidden(c, "NOT_TWEET_AUTHOR", err.Error())
+		case errors.Is(err, domain.ErrEditWindowExpired):
+			h.forbidden(c, "EDIT_WINDOW_EXPIRED", err.Error())
+		case errors.Is(err, domain.ErrTooManyTweetEdits):
+			h.forbidden(c, "TOO_MANY_EDITS", err.Error())
+		default:
+			h.internalServerError(c, err, slog.String("userID", userID), slog.String("tweetID", tweetID))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tweet)
+}
+
+func (h *GinHandler) getTweetHistory(c *gin.Context) {
+	userID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	history, err := h.deps.TweetSvc.GetTweetHistory(c.Request.Context(), userID, tweetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTweetNotFound) {
+			h.notFound(c, "TWEET_NOT_FOUND", err.Error())
+			return
+		}
+		h.internalServerError(c, err, slog.String("userID", userID), slog.String("tweetID", tweetID))
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+func (h *GinHandler) bookmarkTweet(c *gin.Context) {
+	userID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	if err := h.deps.BookmarkSvc.BookmarkTweet(c.Request.Context(), userID, tweetID); err != nil {
+		if errors.Is(err, domain.ErrTweetNotFound) {
+			h.notFound(c, "TWEET_NOT_FOUND", err.Error())
+			return
+		}
+		h.internalServerError(c, err, slog.String("userID", userID), slog.String("tweetID", tweetID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) removeBookmark(c *gin.Context) {
+	userID := c.GetString("userID")
+	tweetID := c.Param("id")
+
+	if err := h.deps.BookmarkSvc.RemoveBookmark(c.Request.Context(), userID, tweetID); err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID), slog.String("tweetID", tweetID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) getBookmarks(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	page, err := h.deps.BookmarkSvc.GetBookmarks(c.Request.Context(), userID, c.Query("cursor"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			h.badRequest(c, "INVALID_CURSOR", err.Error())
+			return
+		}
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, newTweetPageResponse(page))
+}
+
+func (h *GinHandler) followUser(c *gin.Context) {
+	currentUserID := c.GetString("userID")
+	userToFollowID := c.Param("id")
+
+	if currentUserID == userToFollowID {
+		h.badRequest(c, "INVALID_OPERATION", "A user cannot follow themselves.")
+		return
+	}
+
+	status, err := h.deps.FollowSvc.FollowUser(c.Request.Context(), currentUserID, userToFollowID)
+	if err != nil {
+		if errors.Is(err, services.ErrSelfFollow) {
+			h.badRequest(c, "INVALID_OPERATION", err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrUserBlocked) {
+			h.forbidden(c, "USER_BLOCKED", err.Error())
+			return
+		}
+		if h.accountStatusError(c, err) {
+			return
+		}
+		h.internalServerError(c, err, slog.String("follower", currentUserID), slog.String("followee", userToFollowID))
+		return
+	}
+
+	if status == domain.FollowStatusPending {
+		c.JSON(http.StatusAccepted, StatusResponse{Status: string(status)})
+		return
+	}
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) getSuggestions(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	suggestions, err := h.deps.SuggestionSvc.GetSuggestions(c.Request.Context(), userID)
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+func (h *GinHandler) updateAccount(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req UpdateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	if err := h.deps.AccountSvc.SetProtected(c.Request.Context(), userID, *req.Protected); err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) deactivateAccount(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	if err := h.deps.AccountSvc.Deactivate(c.Request.Context(), userID); err != nil {
+		if h.accountStatusError(c, err) {
+			return
+		}
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) reactivateAccount(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	if err := h.deps.AccountSvc.Reactivate(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, domain.ErrAccountErasurePending) {
+			h.conflict(c, "ACCOUNT_ERASURE_PENDING", err.Error())
+			return
+		}
+		if h.accountStatusError(c, err) {
+			return
+		}
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// exportData sends the user's data as a zip archive. It is built in memory
+// first, so a failure is still reported as an error response.
+func (h *GinHandler) exportData(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	export, err := h.deps.PrivacySvc.ExportData(c.Request.Context(), userID)
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	var archive bytes.Buffer
+	if err := writeDataExport(&archive, export); err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	filename := fmt.Sprintf("data-export-%s.zip", export.ExportedAt.UTC().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", archive.Bytes())
+}
+
+// deleteAccount queues the account for erasure. It is hidden right away and
+// its data is erased by a background job, hence 202.
+func (h *GinHandler) deleteAccount(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	if err := h.deps.PrivacySvc.RequestErasure(c.Request.Context(), userID); err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, StatusResponse{Status: "pending"})
+}
+
+// accountStatusError answers for users whose account is not active. It
+// reports whether err was one of those errors.
+func (h *GinHandler) accountStatusError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrAccountSuspended):
+		h.forbidden(c, "ACCOUNT_SUSPENDED", err.Error())
+	case errors.Is(err, domain.ErrAccountDeactivated):
+		h.forbidden(c, "ACCOUNT_DEACTIVATED", err.Error())
+	default:
+		return false
+	}
+	return true
+}
+
+func (h *GinHandler) getFollowRequests(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	requests, err := h.deps.FollowSvc.GetFollowRequests(c.Request.Context(), userID)
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+func (h *GinHandler) approveFollowRequest(c *gin.Context) {
+	h.answerFollowRequest(c, h.deps.FollowSvc.ApproveFollowRequest)
+}
+
+func (h *GinHandler) rejectFollowRequest(c *gin.Context) {
+	h.answerFollowRequest(c, h.deps.FollowSvc.RejectFollowRequest)
+}
+
+func (h *GinHandler) answerFollowRequest(c *gin.Context, answer func(ctx context.Context, userID, requesterID string) error) {
+	userID := c.GetString("userID")
+	requesterID := c.Param("id")
+
+	if err := answer(c.Request.Context(), userID, requesterID); err != nil {
+		if errors.Is(err, domain.ErrFollowRequestNotFound) {
+			h.notFound(c, "FOLLOW_REQUEST_NOT_FOUND", err.Error())
+			return
+		}
+		h.internalServerError(c, err, slog.String("userID", userID), slog.String("requesterID", requesterID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) blockUser(c *gin.Context) {
+	h.changeRelationship(c, h.deps.RelationshipSvc.BlockUser)
+}
+
+func (h *GinHandler) unblockUser(c *gin.Context) {
+	h.changeRelationship(c, h.deps.RelationshipSvc.UnblockUser)
+}
+
+func (h *GinHandler) muteUser(c *gin.Context) {
+	h.changeRelationship(c, h.deps.RelationshipSvc.MuteUser)
+}
+
+func (h *GinHandler) unmuteUser(c *gin.Context) {
+	h.changeRelationship(c, h.deps.RelationshipSvc.UnmuteUser)
+}
+
+func (h *GinHandler) changeRelationship(c *gin.Context, change func(ctx context.Context, currentUserID, otherUserID string) error) {
+	currentUserID := c.GetString("userID")
+	otherUserID := c.Param("id")
+
+	if err := change(c.Request.Context(), currentUserID, otherUserID); err != nil {
+		if errors.Is(err, services.ErrSelfBlock) || errors.Is(err, services.ErrSelfMute) {
+			h.badRequest(c, "INVALID_OPERATION", err.Error())
+			return
+		}
+		h.internalServerError(c, err, slog.String("userID", currentUserID), slog.String("otherUserID", otherUserID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) getTimeline(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	page, err := h.deps.TimelineSvc.GetUserTimeline(c.Request.Context(), userID, c.Query("cursor"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			h.badRequest(c, "INVALID_CURSOR", err.Error())
+			return
+		}
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	// The body stays a plain array for existing clients; the cursor of the
+	// next page travels in a header.
+	if page.NextCursor != "" {
+		c.Header("X-Next-Cursor", page.NextCursor)
+	}
+	c.JSON(http.StatusOK, newTweetPageResponse(page).Tweets)
+}
+
+func (h *GinHandler) getMentions(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	mentions, err := h.deps.MentionSvc.GetUserMentions(c.Request.Context(), userID)
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, mentions)
+}
+
+func (h *GinHandler) getHashtagTweets(c *gin.Context) {
+	userID := c.GetString("userID")
+	tag := c.Param("tag")
+
+	tweets, err := h.deps.HashtagSvc.GetHashtagTweets(c.Request.Context(), userID, tag)
+	if err != nil {
+		h.internalServerError(c, err, slog.String("tag", tag))
+		return
+	}
+
+	c.JSON(http.StatusOK, tweets)
+}
+
+func (h *GinHandler) getTrends(c *gin.Context) {
+	trends, err := h.deps.HashtagSvc.GetTrends(c.Request.Context())
+	if err != nil {
+		h.internalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trends)
+}
+
+func (h *GinHandler) searchTweets(c *gin.Context) {
+	query := c.Query("q")
+
+	page, err := h.deps.SearchSvc.Search(c.Request.Context(), c.GetString("userID"), query, c.Query("cursor"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmptySearchQuery), errors.Is(err, domain.ErrInvalidSearchQuery):
+			h.badRequest(c, "INVALID_SEARCH_QUERY", err.Error())
+		case errors.Is(err, domain.ErrInvalidCursor):
+			h.badRequest(c, "INVALID_CURSOR", err.Error())
+		default:
+			h.internalServerError(c, err, slog.String("query", query))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, newTweetPageResponse(page))
+}
+
+func (h *GinHandler) getNotifications(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	feed, err := h.deps.NotificationSvc.GetNotifications(c.Request.Context(), userID)
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+func (h *GinHandler) markNotificationsRead(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req MarkNotificationsReadRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	if err := h.deps.NotificationSvc.MarkAsRead(c.Request.Context(), userID, req.NotificationIDs); err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) streamTimeline(c *gin.Context) {
+	userID := c.GetString("userID")
+	ctx := c.Request.Context()
+
+	events, err := h.deps.StreamSvc.SubscribeTimeline(ctx, userID, c.GetHeader("Last-Event-ID"))
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	heartbeat := h.deps.StreamHeartbeat
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event.Payload())
+			if err != nil {
+				h.logger.Error("Failed to encode stream event", "error", err, "eventID", event.ID)
+				continue
+			}
+			if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// messagingError maps the errors shared by the direct message endpoints.
+func (h *GinHandler) messagingError(c *gin.Context, err error, attributes ...slog.Attr) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidParticipants):
+		h.badRequest(c, "INVALID_PARTICIPANTS", err.Error())
+	case errors.Is(err, domain.ErrMessageEmpty), errors.Is(err, domain.ErrMessageTooLong):
+		h.badRequest(c, "INVALID_MESSAGE", err.Error())
+	case errors.Is(err, domain.ErrInvalidCursor):
+		h.badRequest(c, "INVALID_CURSOR", err.Error())
+	case errors.Is(err, domain.ErrUserBlocked):
+		h.forbidden(c, "USER_BLOCKED", err.Error())
+	case errors.Is(err, domain.ErrConversationNotFound):
+		h.notFound(c, "CONVERSATION_NOT_FOUND", err.Error())
+	default:
+		h.internalServerError(c, err, attributes...)
+	}
+}
+
+func (h *GinHandler) getConversations(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	page, err := h.deps.MessageSvc.GetConversations(c.Request.Context(), userID, c.Query("cursor"))
+	if err != nil {
+		h.messagingError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, newConversationPageResponse(page))
+}
+
+func (h *GinHandler) startConversation(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req StartConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	conversation, err := h.deps.MessageSvc.StartConversation(c.Request.Context(), userID, req.ParticipantIDs)
+	if err != nil {
+		h.messagingError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusCreated, conversation)
+}
+
+func (h *GinHandler) getMessages(c *gin.Context) {
+	userID := c.GetString("userID")
+	conversationID := c.Param("id")
+
+	page, err := h.deps.MessageSvc.GetMessages(c.Request.Context(), userID, conversationID, c.Query("cursor"))
+	if err != nil {
+		h.messagingError(c, err, slog.String("userID", userID), slog.String("conversationID", conversationID))
+		return
+	}
+
+	c.JSON(http.StatusOK, newMessagePageResponse(page))
+}
+
+func (h *GinHandler) sendMessage(c *gin.Context) {
+	userID := c.GetString("userID")
+	conversationID := c.Param("id")
+
+	var req SendMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	message, err := h.deps.MessageSvc.SendMessage(c.Request.Context(), userID, conversationID, req.Text)
+	if err != nil {
+		h.messagingError(c, err, slog.String("userID", userID), slog.String("conversationID", conversationID))
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+func (h *GinHandler) markConversationRead(c *gin.Context) {
+	userID := c.GetString("userID")
+	conversationID := c.Param("id")
+
+	if err := h.deps.MessageSvc.MarkConversationRead(c.Request.Context(), userID, conversationID); err != nil {
+		h.messagingError(c, err, slog.String("userID", userID), slog.String("conversationID", conversationID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) listError(c *gin.Context, err error, attributes ...slog.Attr) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidListName), errors.Is(err, domain.ErrListDescriptionTooLong):
+		h.badRequest(c, "INVALID_LIST", err.Error())
+	case errors.Is(err, services.ErrTooManyLists):
+		h.badRequest(c, "TOO_MANY_LISTS", err.Error())
+	case errors.Is(err, services.ErrListFull):
+		h.badRequest(c, "LIST_FULL", err.Error())
+	case errors.Is(err, domain.ErrInvalidCursor):
+		h.badRequest(c, "INVALID_CURSOR", err.Error())
+	case errors.Is(err, domain.ErrNotListOwner):
+		h.forbidden(c, "NOT_LIST_OWNER", err.Error())
+	case errors.Is(err, domain.ErrUserBlocked):
+		h.forbidden(c, "USER_BLOCKED", err.Error())
+	case errors.Is(err, domain.ErrListNotFound):
+		h.notFound(c, "LIST_NOT_FOUND", err.Error())
+	default:
+		h.internalServerError(c, err, attributes...)
+	}
+}
+
+func (h *GinHandler) getLists(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	lists, err := h.deps.ListSvc.GetLists(c.Request.Context(), userID)
+	if err != nil {
+		h.listError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, lists)
+}
+
+func (h *GinHandler) createList(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req ListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	list, err := h.deps.ListSvc.CreateList(c.Request.Context(), userID, req.Name, req.Description, req.Private)
+	if err != nil {
+		h.listError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+func (h *GinHandler) getList(c *gin.Context) {
+	userID := c.GetString("userID")
+	listID := c.Param("id")
+
+	list, err := h.deps.ListSvc.GetList(c.Request.Context(), userID, listID)
+	if err != nil {
+		h.listError(c, err, slog.String("userID", userID), slog.String("listID", listID))
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *GinHandler) updateList(c *gin.Context) {
+	userID := c.GetString("userID")
+	listID := c.Param("id")
+
+	var req ListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	list, err := h.deps.ListSvc.UpdateList(c.Request.Context(), userID, listID, req.Name, req.Description, req.Private)
+	if err != nil {
+		h.listError(c, err, slog.String("userID", userID), slog.String("listID", listID))
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func (h *GinHandler) deleteList(c *gin.Context) {
+	userID := c.GetString("userID")
+	listID := c.Param("id")
+
+	if err := h.deps.ListSvc.DeleteList(c.Request.Context(), userID, listID); err != nil {
+		h.listError(c, err, slog.String("userID", userID), slog.String("listID", listID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) getListMembers(c *gin.Context) {
+	userID := c.GetString("userID")
+	listID := c.Param("id")
+
+	members, err := h.deps.ListSvc.GetMembers(c.Request.Context(), userID, listID)
+	if err != nil {
+		h.listError(c, err, slog.String("userID", userID), slog.String("listID", listID))
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+func (h *GinHandler) addListMember(c *gin.Context) {
+	h.changeListMembership(c, h.deps.ListSvc.AddMember)
+}
+
+func (h *GinHandler) removeListMember(c *gin.Context) {
+	h.changeListMembership(c, h.deps.ListSvc.RemoveMember)
+}
+
+func (h *GinHandler) changeListMembership(c *gin.Context, change func(ctx context.Context, userID, listID, memberID string) error) {
+	userID := c.GetString("userID")
+	listID := c.Param("id")
+	memberID := c.Param("user_id")
+
+	if err := change(c.Request.Context(), userID, listID, memberID); err != nil {
+		h.listError(c, err, slog.String("userID", userID), slog.String("listID", listID), slog.String("memberID", memberID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+func (h *GinHandler) getListTimeline(c *gin.Context) {
+	userID := c.GetString("userID")
+	listID := c.Param("id")
+
+	page, err := h.deps.ListSvc.GetListTimeline(c.Request.Context(), userID, listID, c.Query("cursor"))
+	if err != nil {
+		h.listError(c, err, slog.String("userID", userID), slog.String("listID", listID))
+		return
+	}
+
+	c.JSON(http.StatusOK, newTweetPageResponse(page))
+}
+
+func (h *GinHandler) getProfileTweets(c *gin.Context) {
+	viewerID := c.GetString("userID")
+	userID := c.Param("id")
+
+	page, err := h.deps.ProfileSvc.GetProfileTweets(c.Request.Context(), viewerID, userID, c.Query("cursor"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCursor):
+			h.badRequest(c, "INVALID_CURSOR", err.Error())
+		case errors.Is(err, domain.ErrUserBlocked):
+			h.forbidden(c, "USER_BLOCKED", err.Error())
+		default:
+			h.internalServerError(c, err, slog.String("viewerID", viewerID), slog.String("userID", userID))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, newTweetPageResponse(page))
+}
+
+func (h *GinHandler) getFilters(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	filters, err := h.deps.FilterSvc.GetFilters(c.Request.Context(), userID)
+	if err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID))
+		return
+	}
+
+	c.JSON(http.StatusOK, filters)
+}
+
+func (h *GinHandler) addFilter(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	var req AddFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.badRequest(c, "INVALID_REQUEST_BODY", err.Error())
+		return
+	}
+
+	filter, err := h.deps.FilterSvc.AddFilter(c.Request.Context(), userID, req.Value, req.ExpiresAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidFilter), errors.Is(err, domain.ErrFilterExpiryInPast):
+			h.badRequest(c, "INVALID_FILTER", err.Error())
+		case errors.Is(err, services.ErrTooManyFilters):
+			h.badRequest(c, "TOO_MANY_FILTERS", err.Error())
+		default:
+			h.internalServerError(c, err, slog.String("userID", userID))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, filter)
+}
+
+func (h *GinHandler) removeFilter(c *gin.Context) {
+	userID := c.GetString("userID")
+	filterID := c.Param("id")
+
+	if err := h.deps.FilterSvc.RemoveFilter(c.Request.Context(), userID, filterID); err != nil {
+		h.internalServerError(c, err, slog.String("userID", userID), slog.String("filterID", filterID))
+		return
+	}
+
+	c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
 }
@@ -1,10 +1,12 @@
 package http
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/json"
 	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -53,7 +55,7 @@ func TestGinHandler_publishTweet(t *testing.T) {
 			CreatedAt: time.Now(),
 		}
 
-		mockTweetSvc.On("PublishTweet", mock.Anything, userID, tweetText).Return(expectedTweet, nil)
+		mockTweetSvc.On("PublishTweet", mock.Anything, userID, domain.TweetContent{Text: tweetText}).Return(expectedTweet, nil)
 
 		body, _ := json.Marshal(PublishTweetRequest{Text: tweetText})
 		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tweets", bytes.NewBuffer(body))
@@ -91,7 +93,7 @@ func TestGinHandler_publishTweet(t *testing.T) {
 		userID := "user-1"
 		longTweetText := strings.Repeat("a", 281)
 
-		mockTweetSvc.On("PublishTweet", mock.Anything, userID, longTweetText).Return(nil, domain.ErrTweetTooLong)
+		mockTweetSvc.On("PublishTweet", mock.Anything, userID, domain.TweetContent{Text: longTweetText}).Return(nil, domain.ErrTweetTooLong)
 
 		body, _ := json.Marshal(PublishTweetRequest{Text: longTweetText})
 		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tweets", bytes.NewBuffer(body))
@@ -105,3 +107,991 @@ func TestGinHandler_publishTweet(t *testing.T) {
 		mockTweetSvc.AssertExpectations(t)
 	})
 }
+
+func TestGinHandler_getMentions(t *testing.T) {
+	t.Run("Success: should return the tweets that mention the current user", func(t *testing.T) {
+		mockMentionSvc := new(mocks.MentionService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			MentionSvc: mockMentionSvc,
+			Logger:     discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		userID := "user-2"
+		expectedTweets := []domain.Tweet{{
+			ID:        uuid.NewString(),
+			UserID:    "user-1",
+			Text:      "Hola @user-2",
+			CreatedAt: time.Now(),
+			Mentions: []domain.Mention{{
+				UserID: userID,
+				Handle: userID,
+				Span:   domain.Span{ByteStart: 5, ByteEnd: 12, CharStart: 5, CharEnd: 12},
+			}},
+		}}
+
+		mockMentionSvc.On("GetUserMentions", mock.Anything, userID).Return(expectedTweets, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/mentions", nil)
+		req.Header.Set("X-User-ID", userID)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var responseTweets []domain.Tweet
+		err := json.Unmarshal(w.Body.Bytes(), &responseTweets)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedTweets[0].Mentions, responseTweets[0].Mentions)
+
+		mockMentionSvc.AssertExpectations(t)
+	})
+}
+
+func TestGinHandler_streamTimeline(t *testing.T) {
+	t.Run("Success: should write tweets as server-sent events", func(t *testing.T) {
+		mockStreamSvc := new(mocks.StreamService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			StreamSvc:       mockStreamSvc,
+			StreamHeartbeat: time.Hour,
+			Logger:          discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		userID := "user-1"
+		events := make(chan domain.Event, 1)
+		events <- domain.Event{ID: "7", Type: domain.EventTweet, Tweet: &domain.Tweet{ID: "tweet-1", UserID: "user-2", Text: "Hola"}}
+		close(events)
+
+		mockStreamSvc.On("SubscribeTimeline", mock.Anything, userID, "6").Return((<-chan domain.Event)(events), nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/timeline/stream", nil)
+		req.Header.Set("X-User-ID", userID)
+		req.Header.Set("Last-Event-ID", "6")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "id: 7\nevent: tweet\ndata: {\"ID\":\"tweet-1\"")
+
+		mockStreamSvc.AssertExpectations(t)
+	})
+}
+
+func TestGinHandler_followUser(t *testing.T) {
+	t.Run("Failure: should return 403 Forbidden when a block exists", func(t *testing.T) {
+		mockFollowSvc := new(mocks.FollowService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			FollowSvc: mockFollowSvc,
+			Logger:    discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockFollowSvc.On("FollowUser", mock.Anything, "user-1", "user-2").Return(domain.FollowStatus(""), domain.ErrUserBlocked)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/user-2/follow", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "USER_BLOCKED")
+		mockFollowSvc.AssertExpectations(t)
+	})
+
+	t.Run("Success: should return 202 Accepted when a follow request is pending", func(t *testing.T) {
+		mockFollowSvc := new(mocks.FollowService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			FollowSvc: mockFollowSvc,
+			Logger:    discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockFollowSvc.On("FollowUser", mock.Anything, "user-1", "user-2").Return(domain.FollowStatusPending, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/user-2/follow", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.JSONEq(t, `{"status":"pending"}`, w.Body.String())
+		mockFollowSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 403 Forbidden for deactivated accounts", func(t *testing.T) {
+		mockFollowSvc := new(mocks.FollowService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			FollowSvc: mockFollowSvc,
+			Logger:    discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockFollowSvc.On("FollowUser", mock.Anything, "user-1", "user-2").Return(domain.FollowStatus(""), domain.ErrAccountDeactivated)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/users/user-2/follow", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "ACCOUNT_DEACTIVATED")
+	})
+}
+
+func TestGinHandler_uploadMedia(t *testing.T) {
+	newUpload := func(t *testing.T, data []byte) (*bytes.Buffer, string) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("file", "foto.jpg")
+		assert.NoError(t, err)
+		part.Write(data)
+		assert.NoError(t, writer.Close())
+		return &body, writer.FormDataContentType()
+	}
+
+	t.Run("Success: should return 201 Created with the uploaded media", func(t *testing.T) {
+		mockMediaSvc := new(mocks.MediaService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			MediaSvc: mockMediaSvc,
+			Logger:   discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		media := &domain.Media{ID: "media-1", UserID: "user-1", ContentType: "image/jpeg"}
+		mockMediaSvc.On("UploadMedia", mock.Anything, "user-1", []byte("jpeg")).Return(media, nil)
+
+		body, contentType := newUpload(t, []byte("jpeg"))
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/media", body)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), "media-1")
+		mockMediaSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 413 when the file is over the size limit", func(t *testing.T) {
+		mockMediaSvc := new(mocks.MediaService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			MediaSvc: mockMediaSvc,
+			Logger:   discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		body, contentType := newUpload(t, make([]byte, domain.MaxMediaSize+1))
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/media", body)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "MEDIA_TOO_LARGE")
+		mockMediaSvc.AssertNotCalled(t, "UploadMedia", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGinHandler_deleteTweet(t *testing.T) {
+	t.Run("Failure: should return 403 Forbidden when the user is not the author", func(t *testing.T) {
+		mockTweetSvc := new(mocks.TweetService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			TweetSvc: mockTweetSvc,
+			Logger:   discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockTweetSvc.On("DeleteTweet", mock.Anything, "user-2", "tweet-1").Return(domain.ErrNotTweetAuthor)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/v1/tweets/tweet-1", nil)
+		req.Header.Set("X-User-ID", "user-2")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "NOT_TWEET_AUTHOR")
+		mockTweetSvc.AssertExpectations(t)
+	})
+}
+
+func TestGinHandler_editTweet(t *testing.T) {
+	t.Run("Success: should return the edited tweet marked as edited", func(t *testing.T) {
+		mockTweetSvc := new(mocks.TweetService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			TweetSvc: mockTweetSvc,
+			Logger:   discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		editedAt := time.Date(2025, 1, 1, 12, 5, 0, 0, time.UTC)
+		edited := &domain.Tweet{ID: "tweet-1", UserID: "user-1", Text: "Hola mundo", EditedAt: &editedAt}
+		mockTweetSvc.On("EditTweet", mock.Anything, "user-1", "tweet-1", "Hola mundo").Return(edited, nil)
+
+		body, _ := json.Marshal(EditTweetRequest{Text: "Hola mundo"})
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/tweets/tweet-1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"EditedAt":"2025-01-01T12:05:00Z"`)
+		mockTweetSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 403 Forbidden once the edit window is over", func(t *testing.T) {
+		mockTweetSvc := new(mocks.TweetService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			TweetSvc: mockTweetSvc,
+			Logger:   discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockTweetSvc.On("EditTweet", mock.Anything, "user-1", "tweet-1", "Hola mundo").Return(nil, domain.ErrEditWindowExpired)
+
+		body, _ := json.Marshal(EditTweetRequest{Text: "Hola mundo"})
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/tweets/tweet-1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "EDIT_WINDOW_EXPIRED")
+		mockTweetSvc.AssertExpectations(t)
+	})
+}
+
+func TestGinHandler_getTweetHistory(t *testing.T) {
+	t.Run("Success: should return every version of the tweet", func(t *testing.T) {
+		mockTweetSvc := new(mocks.TweetService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			TweetSvc: mockTweetSvc,
+			Logger:   discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		history := []domain.TweetRevision{{Text: "Hola mudno"}, {Text: "Hola mundo"}}
+		mockTweetSvc.On("GetTweetHistory", mock.Anything, "user-2", "tweet-1").Return(history, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/tweets/tweet-1/history", nil)
+		req.Header.Set("X-User-ID", "user-2")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Hola mudno")
+		mockTweetSvc.AssertExpectations(t)
+	})
+}
+
+func TestGinHandler_scheduleTweet(t *testing.T) {
+	t.Run("Success: should schedule the tweet when publish_at is set", func(t *testing.T) {
+		mockTweetSvc := new(mocks.TweetService)
+		mockScheduleSvc := new(mocks.ScheduleService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			TweetSvc:    mockTweetSvc,
+			ScheduleSvc: mockScheduleSvc,
+			Logger:      discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		publishAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+		scheduled := &domain.ScheduledTweet{ID: "scheduled-1", UserID: "user-1", Text: "Lanzamiento", PublishAt: publishAt}
+		mockScheduleSvc.On("ScheduleTweet", mock.Anything, "user-1", "Lanzamiento", publishAt).Return(scheduled, nil)
+
+		body, _ := json.Marshal(PublishTweetRequest{Text: "Lanzamiento", PublishAt: &publishAt})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tweets", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), "scheduled-1")
+		mockScheduleSvc.AssertExpectations(t)
+		mockTweetSvc.AssertNotCalled(t, "PublishTweet", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGinHandler_votePoll(t *testing.T) {
+	t.Run("Success: should return the results after voting", func(t *testing.T) {
+		mockPollSvc := new(mocks.PollService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			PollSvc: mockPollSvc,
+			Logger:  discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		option, total := 0, 3
+		results := &domain.PollResults{Options: []string{"Mate", "Café"}, VotedOption: &option, Votes: []int{2, 1}, TotalVotes: &total}
+		mockPollSvc.On("Vote", mock.Anything, "user-1", "tweet-1", 0).Return(results, nil)
+
+		body, _ := json.Marshal(map[string]int{"option": 0})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tweets/tweet-1/poll/votes", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"Votes":[2,1]`)
+		mockPollSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 409 Conflict when the user already voted", func(t *testing.T) {
+		mockPollSvc := new(mocks.PollService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			PollSvc: mockPollSvc,
+			Logger:  discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockPollSvc.On("Vote", mock.Anything, "user-1", "tweet-1", 1).Return(nil, domain.ErrAlreadyVoted)
+
+		body, _ := json.Marshal(map[string]int{"option": 1})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tweets/tweet-1/poll/votes", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "ALREADY_VOTED")
+	})
+}
+
+func TestGinHandler_publishTweetModeration(t *testing.T) {
+	t.Run("Failure: should return 422 with the reason code of a rejection", func(t *testing.T) {
+		mockTweetSvc := new(mocks.TweetService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			TweetSvc: mockTweetSvc,
+			Logger:   discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		content := domain.TweetContent{Text: "Compra seguidores"}
+		mockTweetSvc.On("PublishTweet", mock.Anything, "user-1", content).Return(nil, &domain.RejectionError{Reason: "SPAM"})
+
+		body, _ := json.Marshal(PublishTweetRequest{Text: "Compra seguidores"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tweets", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), `"error_code":"SPAM"`)
+	})
+
+	t.Run("Success: should return 202 Accepted for tweets held for review", func(t *testing.T) {
+		mockTweetSvc := new(mocks.TweetService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			TweetSvc: mockTweetSvc,
+			Logger:   discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		content := domain.TweetContent{Text: "Oferta imperdible"}
+		mockTweetSvc.On("PublishTweet", mock.Anything, "user-1", content).Return(nil, domain.ErrTweetHeldForReview)
+
+		body, _ := json.Marshal(PublishTweetRequest{Text: "Oferta imperdible"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/tweets", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"held"`)
+	})
+}
+
+func TestGinHandler_approveHeldTweet(t *testing.T) {
+	t.Run("Success: should publish the held tweet for admins", func(t *testing.T) {
+		mockModerationSvc := new(mocks.ModerationService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ModerationSvc: mockModerationSvc,
+			AdminUserIDs:  []string{"admin-1"},
+			AdminToken:    "admin-token",
+			Logger:        discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		tweet := &domain.Tweet{ID: "tweet-1", UserID: "user-1", Text: "Oferta imperdible"}
+		mockModerationSvc.On("ApproveHeldTweet", mock.Anything, "held-1").Return(tweet, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/held-tweets/held-1/approve", nil)
+		req.Header.Set("X-User-ID", "admin-1")
+		req.Header.Set("Authorization", "Bearer admin-token")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockModerationSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 403 Forbidden for users who are not admins", func(t *testing.T) {
+		mockModerationSvc := new(mocks.ModerationService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ModerationSvc: mockModerationSvc,
+			AdminUserIDs:  []string{"admin-1"},
+			AdminToken:    "admin-token",
+			Logger:        discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/held-tweets/held-1/approve", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "ADMIN_REQUIRED")
+		mockModerationSvc.AssertNotCalled(t, "ApproveHeldTweet", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should return 403 Forbidden for a spoofed admin X-User-ID without the admin token", func(t *testing.T) {
+		mockModerationSvc := new(mocks.ModerationService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ModerationSvc: mockModerationSvc,
+			AdminUserIDs:  []string{"admin-1"},
+			AdminToken:    "admin-token",
+			Logger:        discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		for _, authorization := range []string{"", "Bearer wrong-token", "admin-token"} {
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/held-tweets/held-1/approve", nil)
+			req.Header.Set("X-User-ID", "admin-1")
+			req.Header.Set("Authorization", authorization)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code, authorization)
+		}
+		mockModerationSvc.AssertNotCalled(t, "ApproveHeldTweet", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should return 403 Forbidden when no admin token is configured", func(t *testing.T) {
+		mockModerationSvc := new(mocks.ModerationService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ModerationSvc: mockModerationSvc,
+			AdminUserIDs:  []string{"admin-1"},
+			Logger:        discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/held-tweets/held-1/approve", nil)
+		req.Header.Set("X-User-ID", "admin-1")
+		req.Header.Set("Authorization", "Bearer ")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockModerationSvc.AssertNotCalled(t, "ApproveHeldTweet", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGinHandler_createReport(t *testing.T) {
+	t.Run("Success: should return 201 Created with the report", func(t *testing.T) {
+		mockReportSvc := new(mocks.ReportService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ReportSvc: mockReportSvc,
+			Logger:    discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		input := domain.ReportInput{TargetType: domain.ReportTargetTweet, TargetID: "tweet-1", Reason: domain.ReportReasonSpam, Comment: "Spam"}
+		report := &domain.Report{ID: "report-1", ReporterID: "user-1", TargetType: input.TargetType, TargetID: input.TargetID, Status: domain.ReportOpen}
+		mockReportSvc.On("CreateReport", mock.Anything, "user-1", input).Return(report, nil)
+
+		body := []byte(`{"target_type":"tweet","target_id":"tweet-1","reason":"spam","comment":"Spam"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/reports", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"report-1"`)
+		mockReportSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 409 Conflict for a repeated report", func(t *testing.T) {
+		mockReportSvc := new(mocks.ReportService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ReportSvc: mockReportSvc,
+			Logger:    discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockReportSvc.On("CreateReport", mock.Anything, "user-1", mock.Anything).Return(nil, domain.ErrAlreadyReported)
+
+		body := []byte(`{"target_type":"user","target_id":"user-2","reason":"harassment"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/reports", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "ALREADY_REPORTED")
+	})
+}
+
+func TestGinHandler_getReports(t *testing.T) {
+	t.Run("Success: should list the open reports by default", func(t *testing.T) {
+		mockReportSvc := new(mocks.ReportService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ReportSvc:    mockReportSvc,
+			AdminUserIDs: []string{"admin-1"},
+			AdminToken:   "admin-token",
+			Logger:       discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockReportSvc.On("GetReports", mock.Anything, domain.ReportOpen).Return([]domain.Report{{ID: "report-1"}}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/reports", nil)
+		req.Header.Set("X-User-ID", "admin-1")
+		req.Header.Set("Authorization", "Bearer admin-token")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockReportSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 400 Bad Request for an unknown status", func(t *testing.T) {
+		mockReportSvc := new(mocks.ReportService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ReportSvc:    mockReportSvc,
+			AdminUserIDs: []string{"admin-1"},
+			AdminToken:   "admin-token",
+			Logger:       discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/admin/reports?status=archived", nil)
+		req.Header.Set("X-User-ID", "admin-1")
+		req.Header.Set("Authorization", "Bearer admin-token")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "INVALID_REPORT_STATUS")
+	})
+}
+
+func TestGinHandler_resolveReport(t *testing.T) {
+	t.Run("Failure: should return 409 Conflict for a resolved report", func(t *testing.T) {
+		mockReportSvc := new(mocks.ReportService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ReportSvc:    mockReportSvc,
+			AdminUserIDs: []string{"admin-1"},
+			AdminToken:   "admin-token",
+			Logger:       discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockReportSvc.On("ResolveReport", mock.Anything, "admin-1", "report-1", domain.ReportActioned, "").Return(nil, domain.ErrReportResolved)
+
+		body := []byte(`{"resolution":"actioned"}`)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/reports/report-1/resolve", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-ID", "admin-1")
+		req.Header.Set("Authorization", "Bearer admin-token")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "REPORT_RESOLVED")
+	})
+}
+
+func TestGinHandler_removeTweet(t *testing.T) {
+	t.Run("Success: should remove the tweet for admins", func(t *testing.T) {
+		mockModerationSvc := new(mocks.ModerationService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ModerationSvc: mockModerationSvc,
+			AdminUserIDs:  []string{"admin-1"},
+			AdminToken:    "admin-token",
+			Logger:        discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockModerationSvc.On("RemoveTweet", mock.Anything, "admin-1", "tweet-1").Return(nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/v1/admin/tweets/tweet-1", nil)
+		req.Header.Set("X-User-ID", "admin-1")
+		req.Header.Set("Authorization", "Bearer admin-token")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockModerationSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 403 Forbidden for users who are not admins", func(t *testing.T) {
+		mockModerationSvc := new(mocks.ModerationService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ModerationSvc: mockModerationSvc,
+			AdminUserIDs:  []string{"admin-1"},
+			AdminToken:    "admin-token",
+			Logger:        discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/v1/admin/tweets/tweet-1", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockModerationSvc.AssertNotCalled(t, "RemoveTweet", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGinHandler_reactivateAccount(t *testing.T) {
+	t.Run("Success: should reactivate the current user's account", func(t *testing.T) {
+		mockAccountSvc := new(mocks.AccountService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			AccountSvc: mockAccountSvc,
+			Logger:     discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockAccountSvc.On("Reactivate", mock.Anything, "user-1").Return(nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/me/reactivate", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockAccountSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 403 Forbidden for suspended accounts", func(t *testing.T) {
+		mockAccountSvc := new(mocks.AccountService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			AccountSvc: mockAccountSvc,
+			Logger:     discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockAccountSvc.On("Reactivate", mock.Anything, "user-1").Return(domain.ErrAccountSuspended)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/me/reactivate", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "ACCOUNT_SUSPENDED")
+	})
+
+	t.Run("Failure: should return 409 Conflict for accounts waiting to be erased", func(t *testing.T) {
+		mockAccountSvc := new(mocks.AccountService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			AccountSvc: mockAccountSvc,
+			Logger:     discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockAccountSvc.On("Reactivate", mock.Anything, "user-1").Return(domain.ErrAccountErasurePending)
+
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/me/reactivate", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "ACCOUNT_ERASURE_PENDING")
+	})
+}
+
+func TestGinHandler_exportData(t *testing.T) {
+	t.Run("Success: should download a zip with the user's data", func(t *testing.T) {
+		mockPrivacySvc := new(mocks.PrivacyService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			PrivacySvc: mockPrivacySvc,
+			Logger:     discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		exportedAt := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+		export := &domain.DataExport{
+			User:       domain.User{ID: "user-1", Status: domain.UserActive},
+			Tweets:     []domain.Tweet{{ID: "t1", UserID: "user-1", Text: "hola, mundo", CreatedAt: exportedAt}},
+			Following:  []string{"user-2"},
+			ExportedAt: exportedAt,
+		}
+		mockPrivacySvc.On("ExportData", mock.Anything, "user-1").Return(export, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/me/export", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="data-export-20250304.zip"`, w.Header().Get("Content-Disposition"))
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		assert.NoError(t, err)
+		files := make(map[string]string)
+		for _, f := range archive.File {
+			rc, err := f.Open()
+			assert.NoError(t, err)
+			data, _ := io.ReadAll(rc)
+			rc.Close()
+			files[f.Name] = string(data)
+		}
+
+		assert.Len(t, files, 5)
+		assert.Contains(t, files["profile.json"], `"Following": [`)
+		assert.Contains(t, files["profile.json"], `"Followers": []`)
+		assert.Contains(t, files["tweets.json"], `"Text": "hola, mundo"`)
+		assert.Equal(t, "id,created_at,edited_at,text,media_ids\nt1,2025-03-04T10:00:00Z,,\"hola, mundo\",\n", files["tweets.csv"])
+		assert.Equal(t, "user_id\nuser-2\n", files["following.csv"])
+		assert.Equal(t, "user_id\n", files["followers.csv"])
+	})
+}
+
+func TestGinHandler_deleteAccount(t *testing.T) {
+	t.Run("Success: should return 202 Accepted once the erasure is queued", func(t *testing.T) {
+		mockPrivacySvc := new(mocks.PrivacyService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			PrivacySvc: mockPrivacySvc,
+			Logger:     discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockPrivacySvc.On("RequestErasure", mock.Anything, "user-1").Return(nil)
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/v1/me", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.JSONEq(t, `{"status":"pending"}`, w.Body.String())
+		mockPrivacySvc.AssertExpectations(t)
+	})
+}
+
+func TestGinHandler_getProfileTweets(t *testing.T) {
+	t.Run("Success: should return 200 OK with the page of the user's tweets", func(t *testing.T) {
+		mockProfileSvc := new(mocks.ProfileService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ProfileSvc: mockProfileSvc,
+			Logger:     discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		page := domain.TweetPage{Tweets: []domain.Tweet{{ID: "t1", UserID: "user-2"}}, NextCursor: "next"}
+		mockProfileSvc.On("GetProfileTweets", mock.Anything, "user-1", "user-2", "abc").Return(page, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/user-2/tweets?cursor=abc", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"next_cursor":"next"`)
+		mockProfileSvc.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return 403 Forbidden when there is a block", func(t *testing.T) {
+		mockProfileSvc := new(mocks.ProfileService)
+		discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		deps := HandlerDependencies{
+			ProfileSvc: mockProfileSvc,
+			Logger:     discardLogger,
+		}
+
+		handler := NewGinHandler(deps)
+		router := setupRouter(handler)
+
+		mockProfileSvc.On("GetProfileTweets", mock.Anything, "user-1", "user-2", "").Return(domain.TweetPage{}, domain.ErrUserBlocked)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/users/user-2/tweets", nil)
+		req.Header.Set("X-User-ID", "user-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "USER_BLOCKED")
+	})
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/stretchr/testify/mock"
@@ -11,31 +12,612 @@ type TweetService struct {
 	mock.Mock
 }
 
-func (m *TweetService) PublishTweet(ctx context.Context, userID, text string) (*domain.Tweet, error) {
-	args := m.Called(ctx, userID, text)
+func (m *TweetService) PublishTweet(ctx context.Context, userID string, content domain.TweetContent) (*domain.Tweet, error) {
+	args := m.Called(ctx, userID, content)
+	if tweet, ok := args.Get(0).(*domain.Tweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TweetService) PublishHeldTweet(ctx context.Context, held *domain.HeldTweet) (*domain.Tweet, error) {
+	args := m.Called(ctx, held)
+	if tweet, ok := args.Get(0).(*domain.Tweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TweetService) PublishScheduledTweet(ctx context.Context, scheduled *domain.ScheduledTweet) (*domain.Tweet, error) {
+	args := m.Called(ctx, scheduled)
+	if tweet, ok := args.Get(0).(*domain.Tweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TweetService) PublishDraft(ctx context.Context, draft *domain.Draft) (*domain.Tweet, error) {
+	args := m.Called(ctx, draft)
+	if tweet, ok := args.Get(0).(*domain.Tweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TweetService) DeleteTweet(ctx context.Context, userID, tweetID string) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *TweetService) EditTweet(ctx context.Context, userID, tweetID, text string) (*domain.Tweet, error) {
+	args := m.Called(ctx, userID, tweetID, text)
 	if tweet, ok := args.Get(0).(*domain.Tweet); ok {
 		return tweet, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
+func (m *TweetService) GetTweetHistory(ctx context.Context, viewerID, tweetID string) ([]domain.TweetRevision, error) {
+	args := m.Called(ctx, viewerID, tweetID)
+	if revisions, ok := args.Get(0).([]domain.TweetRevision); ok {
+		return revisions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type ModerationService struct {
+	mock.Mock
+}
+
+func (m *ModerationService) GetHeldTweets(ctx context.Context) ([]domain.HeldTweet, error) {
+	args := m.Called(ctx)
+	if tweets, ok := args.Get(0).([]domain.HeldTweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ModerationService) ApproveHeldTweet(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	args := m.Called(ctx, tweetID)
+	if tweet, ok := args.Get(0).(*domain.Tweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ModerationService) RejectHeldTweet(ctx context.Context, tweetID string) error {
+	args := m.Called(ctx, tweetID)
+	return args.Error(0)
+}
+
+func (m *ModerationService) SuspendUser(ctx context.Context, adminID, userID string) error {
+	args := m.Called(ctx, adminID, userID)
+	return args.Error(0)
+}
+
+func (m *ModerationService) UnsuspendUser(ctx context.Context, adminID, userID string) error {
+	args := m.Called(ctx, adminID, userID)
+	return args.Error(0)
+}
+
+func (m *ModerationService) RemoveTweet(ctx context.Context, adminID, tweetID string) error {
+	args := m.Called(ctx, adminID, tweetID)
+	return args.Error(0)
+}
+
+type ReportService struct {
+	mock.Mock
+}
+
+func (m *ReportService) CreateReport(ctx context.Context, reporterID string, input domain.ReportInput) (*domain.Report, error) {
+	args := m.Called(ctx, reporterID, input)
+	if report, ok := args.Get(0).(*domain.Report); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ReportService) GetReports(ctx context.Context, status domain.ReportStatus) ([]domain.Report, error) {
+	args := m.Called(ctx, status)
+	if reports, ok := args.Get(0).([]domain.Report); ok {
+		return reports, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ReportService) TriageReport(ctx context.Context, adminID, reportID string) (*domain.Report, error) {
+	args := m.Called(ctx, adminID, reportID)
+	if report, ok := args.Get(0).(*domain.Report); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ReportService) ResolveReport(ctx context.Context, adminID, reportID string, resolution domain.ReportResolution, note string) (*domain.Report, error) {
+	args := m.Called(ctx, adminID, reportID, resolution, note)
+	if report, ok := args.Get(0).(*domain.Report); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type PollService struct {
+	mock.Mock
+}
+
+func (m *PollService) Vote(ctx context.Context, userID, tweetID string, option int) (*domain.PollResults, error) {
+	args := m.Called(ctx, userID, tweetID, option)
+	if results, ok := args.Get(0).(*domain.PollResults); ok {
+		return results, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PollService) GetPollResults(ctx context.Context, viewerID, tweetID string) (*domain.PollResults, error) {
+	args := m.Called(ctx, viewerID, tweetID)
+	if results, ok := args.Get(0).(*domain.PollResults); ok {
+		return results, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MediaService struct {
+	mock.Mock
+}
+
+func (m *MediaService) UploadMedia(ctx context.Context, userID string, data []byte) (*domain.Media, error) {
+	args := m.Called(ctx, userID, data)
+	if media, ok := args.Get(0).(*domain.Media); ok {
+		return media, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MediaService) GetMediaBlob(ctx context.Context, viewerID, mediaID string, thumbnail bool) (*domain.MediaBlob, error) {
+	args := m.Called(ctx, viewerID, mediaID, thumbnail)
+	if blob, ok := args.Get(0).(*domain.MediaBlob); ok {
+		return blob, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 type FollowService struct {
 	mock.Mock
 }
 
-func (m *FollowService) FollowUser(ctx context.Context, currentUserID, userToFollowID string) error {
+func (m *FollowService) FollowUser(ctx context.Context, currentUserID, userToFollowID string) (domain.FollowStatus, error) {
 	args := m.Called(ctx, currentUserID, userToFollowID)
+	return args.Get(0).(domain.FollowStatus), args.Error(1)
+}
+
+func (m *FollowService) GetFollowRequests(ctx context.Context, userID string) ([]domain.FollowRequest, error) {
+	args := m.Called(ctx, userID)
+	if requests, ok := args.Get(0).([]domain.FollowRequest); ok {
+		return requests, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *FollowService) ApproveFollowRequest(ctx context.Context, userID, requesterID string) error {
+	args := m.Called(ctx, userID, requesterID)
+	return args.Error(0)
+}
+
+func (m *FollowService) RejectFollowRequest(ctx context.Context, userID, requesterID string) error {
+	args := m.Called(ctx, userID, requesterID)
+	return args.Error(0)
+}
+
+type AccountService struct {
+	mock.Mock
+}
+
+func (m *AccountService) SetProtected(ctx context.Context, userID string, protected bool) error {
+	args := m.Called(ctx, userID, protected)
+	return args.Error(0)
+}
+
+func (m *AccountService) Deactivate(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *AccountService) Reactivate(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
 
+type PrivacyService struct {
+	mock.Mock
+}
+
+func (m *PrivacyService) ExportData(ctx context.Context, userID string) (*domain.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if export, ok := args.Get(0).(*domain.DataExport); ok {
+		return export, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *PrivacyService) RequestErasure(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *PrivacyService) EraseAccounts(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type ProfileService struct {
+	mock.Mock
+}
+
+func (m *ProfileService) GetProfileTweets(ctx context.Context, viewerID, userID, cursor string) (domain.TweetPage, error) {
+	args := m.Called(ctx, viewerID, userID, cursor)
+	return args.Get(0).(domain.TweetPage), args.Error(1)
+}
+
 type TimelineService struct {
 	mock.Mock
 }
 
-func (m *TimelineService) GetUserTimeline(ctx context.Context, userID string) ([]domain.Tweet, error) {
+func (m *TimelineService) GetUserTimeline(ctx context.Context, userID, cursor string) (domain.TweetPage, error) {
+	args := m.Called(ctx, userID, cursor)
+	return args.Get(0).(domain.TweetPage), args.Error(1)
+}
+
+type MentionService struct {
+	mock.Mock
+}
+
+func (m *MentionService) GetUserMentions(ctx context.Context, userID string) ([]domain.Tweet, error) {
 	args := m.Called(ctx, userID)
-	if timeline, ok := args.Get(0).([]domain.Tweet); ok {
-		return timeline, args.Error(1)
+	if tweets, ok := args.Get(0).([]domain.Tweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type HashtagService struct {
+	mock.Mock
+}
+
+func (m *HashtagService) GetHashtagTweets(ctx context.Context, userID, tag string) ([]domain.Tweet, error) {
+	args := m.Called(ctx, userID, tag)
+	if tweets, ok := args.Get(0).([]domain.Tweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *HashtagService) GetTrends(ctx context.Context) ([]domain.Trend, error) {
+	args := m.Called(ctx)
+	if trends, ok := args.Get(0).([]domain.Trend); ok {
+		return trends, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *HashtagService) RefreshTrends(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type SearchService struct {
+	mock.Mock
+}
+
+func (m *SearchService) Search(ctx context.Context, userID, rawQuery, cursor string) (domain.TweetPage, error) {
+	args := m.Called(ctx, userID, rawQuery, cursor)
+	return args.Get(0).(domain.TweetPage), args.Error(1)
+}
+
+type NotificationService struct {
+	mock.Mock
+}
+
+func (m *NotificationService) Notify(ctx context.Context, notifications ...domain.Notification) {
+	m.Called(ctx, notifications)
+}
+
+func (m *NotificationService) GetNotifications(ctx context.Context, userID string) (domain.NotificationFeed, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(domain.NotificationFeed), args.Error(1)
+}
+
+func (m *NotificationService) MarkAsRead(ctx context.Context, userID string, notificationIDs []string) error {
+	args := m.Called(ctx, userID, notificationIDs)
+	return args.Error(0)
+}
+
+type StreamService struct {
+	mock.Mock
+}
+
+func (m *StreamService) SubscribeTimeline(ctx context.Context, userID, lastEventID string) (<-chan domain.Event, error) {
+	args := m.Called(ctx, userID, lastEventID)
+	if events, ok := args.Get(0).(<-chan domain.Event); ok {
+		return events, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *StreamService) SubscribeNotifications(ctx context.Context, userID, lastEventID string) (<-chan domain.Event, error) {
+	args := m.Called(ctx, userID, lastEventID)
+	if events, ok := args.Get(0).(<-chan domain.Event); ok {
+		return events, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *StreamService) SubscribeThread(ctx context.Context, userID, tweetID, lastEventID string) (<-chan domain.Event, error) {
+	args := m.Called(ctx, userID, tweetID, lastEventID)
+	if events, ok := args.Get(0).(<-chan domain.Event); ok {
+		return events, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type RelationshipService struct {
+	mock.Mock
+}
+
+func (m *RelationshipService) BlockUser(ctx context.Context, currentUserID, userToBlockID string) error {
+	args := m.Called(ctx, currentUserID, userToBlockID)
+	return args.Error(0)
+}
+
+func (m *RelationshipService) UnblockUser(ctx context.Context, currentUserID, userToUnblockID string) error {
+	args := m.Called(ctx, currentUserID, userToUnblockID)
+	return args.Error(0)
+}
+
+func (m *RelationshipService) MuteUser(ctx context.Context, currentUserID, userToMuteID string) error {
+	args := m.Called(ctx, currentUserID, userToMuteID)
+	return args.Error(0)
+}
+
+func (m *RelationshipService) UnmuteUser(ctx context.Context, currentUserID, userToUnmuteID string) error {
+	args := m.Called(ctx, currentUserID, userToUnmuteID)
+	return args.Error(0)
+}
+
+type FilterService struct {
+	mock.Mock
+}
+
+func (m *FilterService) AddFilter(ctx context.Context, userID, value string, expiresAt *time.Time) (*domain.ContentFilter, error) {
+	args := m.Called(ctx, userID, value, expiresAt)
+	if filter, ok := args.Get(0).(*domain.ContentFilter); ok {
+		return filter, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *FilterService) RemoveFilter(ctx context.Context, userID, filterID string) error {
+	args := m.Called(ctx, userID, filterID)
+	return args.Error(0)
+}
+
+func (m *FilterService) GetFilters(ctx context.Context, userID string) ([]domain.ContentFilter, error) {
+	args := m.Called(ctx, userID)
+	if filters, ok := args.Get(0).([]domain.ContentFilter); ok {
+		return filters, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+type MessageService struct {
+	mock.Mock
+}
+
+func (m *MessageService) StartConversation(ctx context.Context, userID string, participantIDs []string) (*domain.Conversation, error) {
+	args := m.Called(ctx, userID, participantIDs)
+	if conversation, ok := args.Get(0).(*domain.Conversation); ok {
+		return conversation, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MessageService) GetConversations(ctx context.Context, userID, cursor string) (domain.ConversationPage, error) {
+	args := m.Called(ctx, userID, cursor)
+	return args.Get(0).(domain.ConversationPage), args.Error(1)
+}
+
+func (m *MessageService) SendMessage(ctx context.Context, userID, conversationID, text string) (*domain.Message, error) {
+	args := m.Called(ctx, userID, conversationID, text)
+	if message, ok := args.Get(0).(*domain.Message); ok {
+		return message, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MessageService) GetMessages(ctx context.Context, userID, conversationID, cursor string) (domain.MessagePage, error) {
+	args := m.Called(ctx, userID, conversationID, cursor)
+	return args.Get(0).(domain.MessagePage), args.Error(1)
+}
+
+func (m *MessageService) MarkConversationRead(ctx context.Context, userID, conversationID string) error {
+	args := m.Called(ctx, userID, conversationID)
+	return args.Error(0)
+}
+
+type BookmarkService struct {
+	mock.Mock
+}
+
+func (m *BookmarkService) BookmarkTweet(ctx context.Context, userID, tweetID string) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *BookmarkService) RemoveBookmark(ctx context.Context, userID, tweetID string) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *BookmarkService) GetBookmarks(ctx context.Context, userID, cursor string) (domain.TweetPage, error) {
+	args := m.Called(ctx, userID, cursor)
+	return args.Get(0).(domain.TweetPage), args.Error(1)
+}
+
+type ListService struct {
+	mock.Mock
+}
+
+func (m *ListService) CreateList(ctx context.Context, userID, name, description string, private bool) (*domain.List, error) {
+	args := m.Called(ctx, userID, name, description, private)
+	if list, ok := args.Get(0).(*domain.List); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ListService) GetLists(ctx context.Context, userID string) ([]domain.List, error) {
+	args := m.Called(ctx, userID)
+	if lists, ok := args.Get(0).([]domain.List); ok {
+		return lists, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ListService) GetList(ctx context.Context, userID, listID string) (*domain.List, error) {
+	args := m.Called(ctx, userID, listID)
+	if list, ok := args.Get(0).(*domain.List); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ListService) UpdateList(ctx context.Context, userID, listID, name, description string, private bool) (*domain.List, error) {
+	args := m.Called(ctx, userID, listID, name, description, private)
+	if list, ok := args.Get(0).(*domain.List); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ListService) DeleteList(ctx context.Context, userID, listID string) error {
+	args := m.Called(ctx, userID, listID)
+	return args.Error(0)
+}
+
+func (m *ListService) AddMember(ctx context.Context, userID, listID, memberID string) error {
+	args := m.Called(ctx, userID, listID, memberID)
+	return args.Error(0)
+}
+
+func (m *ListService) RemoveMember(ctx context.Context, userID, listID, memberID string) error {
+	args := m.Called(ctx, userID, listID, memberID)
+	return args.Error(0)
+}
+
+func (m *ListService) GetMembers(ctx context.Context, userID, listID string) ([]string, error) {
+	args := m.Called(ctx, userID, listID)
+	if members, ok := args.Get(0).([]string); ok {
+		return members, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ListService) GetListTimeline(ctx context.Context, userID, listID, cursor string) (domain.TweetPage, error) {
+	args := m.Called(ctx, userID, listID, cursor)
+	return args.Get(0).(domain.TweetPage), args.Error(1)
+}
+
+type SuggestionService struct {
+	mock.Mock
+}
+
+func (m *SuggestionService) GetSuggestions(ctx context.Context, userID string) ([]domain.Suggestion, error) {
+	args := m.Called(ctx, userID)
+	if suggestions, ok := args.Get(0).([]domain.Suggestion); ok {
+		return suggestions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *SuggestionService) RefreshSuggestions(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type ScheduleService struct {
+	mock.Mock
+}
+
+func (m *ScheduleService) ScheduleTweet(ctx context.Context, userID, text string, publishAt time.Time) (*domain.ScheduledTweet, error) {
+	args := m.Called(ctx, userID, text, publishAt)
+	if tweet, ok := args.Get(0).(*domain.ScheduledTweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ScheduleService) GetScheduledTweets(ctx context.Context, userID string) ([]domain.ScheduledTweet, error) {
+	args := m.Called(ctx, userID)
+	if tweets, ok := args.Get(0).([]domain.ScheduledTweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ScheduleService) UpdateScheduledTweet(ctx context.Context, userID, tweetID, text string, publishAt time.Time) (*domain.ScheduledTweet, error) {
+	args := m.Called(ctx, userID, tweetID, text, publishAt)
+	if tweet, ok := args.Get(0).(*domain.ScheduledTweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ScheduleService) CancelScheduledTweet(ctx context.Context, userID, tweetID string) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *ScheduleService) PublishDueTweets(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type DraftService struct {
+	mock.Mock
+}
+
+func (m *DraftService) CreateDraft(ctx context.Context, userID, text string) (*domain.Draft, error) {
+	args := m.Called(ctx, userID, text)
+	if draft, ok := args.Get(0).(*domain.Draft); ok {
+		return draft, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *DraftService) GetDrafts(ctx context.Context, userID string) ([]domain.Draft, error) {
+	args := m.Called(ctx, userID)
+	if drafts, ok := args.Get(0).([]domain.Draft); ok {
+		return drafts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *DraftService) UpdateDraft(ctx context.Context, userID, draftID, text string) (*domain.Draft, error) {
+	args := m.Called(ctx, userID, draftID, text)
+	if draft, ok := args.Get(0).(*domain.Draft); ok {
+		return draft, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *DraftService) DeleteDraft(ctx context.Context, userID, draftID string) error {
+	args := m.Called(ctx, userID, draftID)
+	return args.Error(0)
+}
+
+func (m *DraftService) PublishDraft(ctx context.Context, userID, draftID string) (*domain.Tweet, error) {
+	args := m.Called(ctx, userID, draftID)
+	if tweet, ok := args.Get(0).(*domain.Tweet); ok {
+		return tweet, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
@@ -2,14 +2,128 @@ package http
 
 import (
 	"log/slog"
+	"time"
 
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/EstefiS/uala-challenge/internal/core/ports"
 )
 
+// PublishTweetRequest publishes the tweet right away, or schedules it when
+// PublishAt is set. Scheduled tweets cannot carry media or polls yet.
 type PublishTweetRequest struct {
+	Text      string       `json:"text" binding:"required"`
+	MediaIDs  []string     `json:"media_ids"`
+	Poll      *PollRequest `json:"poll"`
+	PublishAt *time.Time   `json:"publish_at"`
+}
+
+type PollRequest struct {
+	Options         []string `json:"options"`
+	DurationMinutes int      `json:"duration_minutes"`
+}
+
+// VoteRequest takes the zero-based index of the chosen option.
+type VoteRequest struct {
+	Option *int `json:"option" binding:"required"`
+}
+
+type EditTweetRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+type DraftRequest struct {
+	Text string `json:"text"`
+}
+
+type UpdateScheduledTweetRequest struct {
+	Text      string    `json:"text" binding:"required"`
+	PublishAt time.Time `json:"publish_at" binding:"required"`
+}
+
+type MarkNotificationsReadRequest struct {
+	NotificationIDs []string `json:"notification_ids"`
+}
+
+type UpdateAccountRequest struct {
+	Protected *bool `json:"protected" binding:"required"`
+}
+
+type StartConversationRequest struct {
+	ParticipantIDs []string `json:"participant_ids" binding:"required"`
+}
+
+type SendMessageRequest struct {
 	Text string `json:"text" binding:"required"`
 }
 
+type ConversationPageResponse struct {
+	Conversations []domain.Conversation `json:"conversations"`
+	NextCursor    string                `json:"next_cursor,omitempty"`
+}
+
+func newConversationPageResponse(page domain.ConversationPage) ConversationPageResponse {
+	conversations := page.Conversations
+	if conversations == nil {
+		conversations = []domain.Conversation{}
+	}
+	return ConversationPageResponse{Conversations: conversations, NextCursor: page.NextCursor}
+}
+
+type MessagePageResponse struct {
+	Messages   []domain.Message `json:"messages"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+func newMessagePageResponse(page domain.MessagePage) MessagePageResponse {
+	messages := page.Messages
+	if messages == nil {
+		messages = []domain.Message{}
+	}
+	return MessagePageResponse{Messages: messages, NextCursor: page.NextCursor}
+}
+
+type AddFilterRequest struct {
+	Value     string     `json:"value" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ListRequest creates a list or replaces its editable fields.
+type ListRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+}
+
+type WSClientMessage struct {
+	Type        string `json:"type"`
+	Channel     string `json:"channel,omitempty"`
+	ID          string `json:"id,omitempty"`
+	LastEventID string `json:"last_event_id,omitempty"`
+}
+
+type WSServerMessage struct {
+	Type      string `json:"type"`
+	Channel   string `json:"channel,omitempty"`
+	ID        string `json:"id,omitempty"`
+	EventID   string `json:"event_id,omitempty"`
+	Event     string `json:"event,omitempty"`
+	Data      any    `json:"data,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+type CreateReportRequest struct {
+	TargetType string `json:"target_type" binding:"required"`
+	TargetID   string `json:"target_id" binding:"required"`
+	Reason     string `json:"reason" binding:"required"`
+	Comment    string `json:"comment"`
+}
+
+type ResolveReportRequest struct {
+	Resolution string `json:"resolution" binding:"required"`
+	Note       string `json:"note"`
+}
+
 type ErrorResponse struct {
 	ErrorCode string `json:"error_code"`
 	Message   string `json:"message"`
@@ -19,9 +133,45 @@ type StatusResponse struct {
 	Status string `json:"status" example:"ok"`
 }
 
+type TweetPageResponse struct {
+	Tweets     []domain.Tweet `json:"tweets"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+func newTweetPageResponse(page domain.TweetPage) TweetPageResponse {
+	tweets := page.Tweets
+	if tweets == nil {
+		tweets = []domain.Tweet{}
+	}
+	return TweetPageResponse{Tweets: tweets, NextCursor: page.NextCursor}
+}
+
 type HandlerDependencies struct {
-	TweetSvc    ports.TweetService
-	FollowSvc   ports.FollowService
-	TimelineSvc ports.TimelineService
-	Logger      *slog.Logger
+	TweetSvc        ports.TweetService
+	ScheduleSvc     ports.ScheduleService
+	DraftSvc        ports.DraftService
+	MediaSvc        ports.MediaService
+	PollSvc         ports.PollService
+	ModerationSvc   ports.ModerationService
+	ReportSvc       ports.ReportService
+	FollowSvc       ports.FollowService
+	AccountSvc      ports.AccountService
+	PrivacySvc      ports.PrivacyService
+	RelationshipSvc ports.RelationshipService
+	TimelineSvc     ports.TimelineService
+	ProfileSvc      ports.ProfileService
+	MentionSvc      ports.MentionService
+	HashtagSvc      ports.HashtagService
+	SearchSvc       ports.SearchService
+	NotificationSvc ports.NotificationService
+	FilterSvc       ports.FilterService
+	MessageSvc      ports.MessageService
+	BookmarkSvc     ports.BookmarkService
+	ListSvc         ports.ListService
+	SuggestionSvc   ports.SuggestionService
+	StreamSvc       ports.StreamService
+	StreamHeartbeat time.Duration
+	AdminUserIDs    []string
+	AdminToken      string
+	Logger          *slog.Logger
 }
@@ -0,0 +1,240 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	wsOutboundBuffer = 64
+
+	wsChannelTimeline      = "timeline"
+	wsChannelNotifications = "notifications"
+	wsChannelThread        = "thread"
+)
+
+// serveWebSocket upgrades the request to a WebSocket speaking a small JSON
+// protocol: clients send subscribe/unsubscribe/ping messages and receive the
+// events of the channels they subscribed to. The server pings every
+// StreamHeartbeat and drops connections that stay silent for two intervals.
+func (h *GinHandler) serveWebSocket(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	keepalive := h.deps.StreamHeartbeat
+	if keepalive <= 0 {
+		keepalive = 15 * time.Second
+	}
+
+	server := websocket.Server{
+		// Clients authenticate with X-User-ID like the REST API, so the
+		// origin check of the default handshake does not add anything.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			ctx, cancel := context.WithCancel(c.Request.Context())
+			session := &wsSession{
+				conn:      conn,
+				userID:    userID,
+				streams:   h.deps.StreamSvc,
+				keepalive: keepalive,
+				logger:    h.logger.With("component", "WebSocket", "userID", userID),
+				out:       make(chan WSServerMessage, wsOutboundBuffer),
+				subs:      make(map[string]context.CancelFunc),
+				ctx:       ctx,
+				cancel:    cancel,
+			}
+			session.run()
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+type wsSession struct {
+	conn      *websocket.Conn
+	userID    string
+	streams   ports.StreamService
+	keepalive time.Duration
+	logger    *slog.Logger
+	out       chan WSServerMessage
+
+	mu   sync.Mutex
+	subs map[string]context.CancelFunc
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (s *wsSession) run() {
+	defer s.cancel()
+	go s.writeLoop()
+
+	for {
+		if err := s.conn.SetReadDeadline(time.Now().Add(2 * s.keepalive)); err != nil {
+			return
+		}
+
+		var msg WSClientMessage
+		if err := websocket.JSON.Receive(s.conn, &msg); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				s.send(WSServerMessage{Type: "error", ErrorCode: "INVALID_MESSAGE", Message: err.Error()})
+				continue
+			}
+			return
+		}
+		s.handle(msg)
+	}
+}
+
+func (s *wsSession) writeLoop() {
+	defer s.conn.Close()
+
+	ticker := time.NewTicker(s.keepalive)
+	defer ticker.Stop()
+
+	for {
+		var msg WSServerMessage
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			msg = WSServerMessage{Type: "ping"}
+		case msg = <-s.out:
+		}
+
+		if err := s.conn.SetWriteDeadline(time.Now().Add(s.keepalive)); err != nil {
+			s.cancel()
+			return
+		}
+		if err := websocket.JSON.Send(s.conn, msg); err != nil {
+			s.cancel()
+			return
+		}
+	}
+}
+
+// send queues a message for the client. A client that does not keep up with
+// its outbound buffer is disconnected.
+func (s *wsSession) send(msg WSServerMessage) {
+	select {
+	case s.out <- msg:
+	case <-s.ctx.Done():
+	default:
+		s.logger.Warn("Closing WebSocket of a client that is not keeping up")
+		s.cancel()
+	}
+}
+
+func (s *wsSession) handle(msg WSClientMessage) {
+	switch msg.Type {
+	case "subscribe":
+		s.subscribe(msg)
+	case "unsubscribe":
+		s.unsubscribe(msg)
+	case "ping":
+		s.send(WSServerMessage{Type: "pong"})
+	case "pong":
+	default:
+		s.send(WSServerMessage{Type: "error", ErrorCode: "UNKNOWN_MESSAGE_TYPE", Message: "unknown message type: " + msg.Type})
+	}
+}
+
+func subscriptionKey(msg WSClientMessage) string {
+	if msg.Channel == wsChannelThread {
+		return msg.Channel + ":" + msg.ID
+	}
+	return msg.Channel
+}
+
+func (s *wsSession) subscribe(msg WSClientMessage) {
+	key := subscriptionKey(msg)
+	ack := WSServerMessage{Type: "subscribed", Channel: msg.Channel, ID: msg.ID}
+
+	s.mu.Lock()
+	_, exists := s.subs[key]
+	s.mu.Unlock()
+	if exists {
+		s.send(ack)
+		return
+	}
+
+	subCtx, cancel := context.WithCancel(s.ctx)
+	var events <-chan domain.Event
+	var err error
+	switch msg.Channel {
+	case wsChannelTimeline:
+		events, err = s.streams.SubscribeTimeline(subCtx, s.userID, msg.LastEventID)
+	case wsChannelNotifications:
+		events, err = s.streams.SubscribeNotifications(subCtx, s.userID, msg.LastEventID)
+	case wsChannelThread:
+		if msg.ID == "" {
+			cancel()
+			s.send(WSServerMessage{Type: "error", Channel: msg.Channel, ErrorCode: "INVALID_SUBSCRIPTION", Message: "thread subscriptions require an id"})
+			return
+		}
+		events, err = s.streams.SubscribeThread(subCtx, s.userID, msg.ID, msg.LastEventID)
+	default:
+		cancel()
+		s.send(WSServerMessage{Type: "error", Channel: msg.Channel, ErrorCode: "UNKNOWN_CHANNEL", Message: "unknown channel: " + msg.Channel})
+		return
+	}
+	if err != nil {
+		cancel()
+		s.logger.Error("Failed to open subscription", "error", err, "channel", key)
+		s.send(WSServerMessage{Type: "error", Channel: msg.Channel, ID: msg.ID, ErrorCode: "INTERNAL_SERVER_ERROR", Message: "could not subscribe"})
+		return
+	}
+
+	s.mu.Lock()
+	s.subs[key] = cancel
+	s.mu.Unlock()
+	s.send(ack)
+
+	go s.forward(subCtx, key, msg, events)
+}
+
+func (s *wsSession) forward(ctx context.Context, key string, msg WSClientMessage, events <-chan domain.Event) {
+	for e := range events {
+		s.send(WSServerMessage{
+			Type:    "event",
+			Channel: msg.Channel,
+			ID:      msg.ID,
+			EventID: e.ID,
+			Event:   string(e.Type),
+			Data:    e.Payload(),
+		})
+	}
+
+	if ctx.Err() == nil {
+		// The broker dropped a lagging subscription; let the client resume it
+		// from the last event it received.
+		s.mu.Lock()
+		delete(s.subs, key)
+		s.mu.Unlock()
+		s.send(WSServerMessage{Type: "error", Channel: msg.Channel, ID: msg.ID, ErrorCode: "SUBSCRIPTION_DROPPED", Message: "subscription fell behind, resubscribe with last_event_id"})
+	}
+}
+
+func (s *wsSession) unsubscribe(msg WSClientMessage) {
+	key := subscriptionKey(msg)
+
+	s.mu.Lock()
+	cancel, ok := s.subs[key]
+	delete(s.subs, key)
+	s.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+	s.send(WSServerMessage{Type: "unsubscribed", Channel: msg.Channel, ID: msg.ID})
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/adapters/events"
+	"github.com/EstefiS/uala-challenge/internal/adapters/repository"
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func setupWebSocketServer(t *testing.T) (*httptest.Server, *events.MemoryBroker) {
+	broker := events.NewMemoryBroker(10)
+	repo := repository.NewMockRepository()
+	deps := HandlerDependencies{
+		StreamSvc:       services.NewStreamService(broker, repo, repo, repo, 8, time.Now),
+		StreamHeartbeat: time.Minute,
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	server := httptest.NewServer(setupRouter(NewGinHandler(deps)))
+	t.Cleanup(server.Close)
+	return server, broker
+}
+
+func dialWebSocket(t *testing.T, server *httptest.Server, userID string) (*websocket.Conn, error) {
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/ws"
+	cfg, err := websocket.NewConfig(wsURL, server.URL)
+	require.NoError(t, err)
+	if userID != "" {
+		cfg.Header.Set("X-User-ID", userID)
+	}
+	return websocket.DialConfig(cfg)
+}
+
+func receive(t *testing.T, conn *websocket.Conn) WSServerMessage {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var raw json.RawMessage
+	require.NoError(t, websocket.JSON.Receive(conn, &raw))
+	var msg WSServerMessage
+	require.NoError(t, json.Unmarshal(raw, &msg))
+	return msg
+}
+
+func TestWebSocketGateway(t *testing.T) {
+	t.Run("Success: should push events of subscribed channels", func(t *testing.T) {
+		server, broker := setupWebSocketServer(t)
+		conn, err := dialWebSocket(t, server, "user-1")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, websocket.JSON.Send(conn, WSClientMessage{Type: "subscribe", Channel: "timeline"}))
+		assert.Equal(t, WSServerMessage{Type: "subscribed", Channel: "timeline"}, receive(t, conn))
+
+		tweet := &domain.Tweet{ID: "tweet-1", UserID: "user-2", Text: "Hola"}
+		require.NoError(t, broker.Publish(context.Background(),
+			domain.Event{Topic: domain.TimelineTopic("user-3"), Type: domain.EventTweet, Tweet: tweet},
+			domain.Event{Topic: domain.TimelineTopic("user-1"), Type: domain.EventTweet, Tweet: tweet},
+		))
+
+		msg := receive(t, conn)
+		assert.Equal(t, "event", msg.Type)
+		assert.Equal(t, "timeline", msg.Channel)
+		assert.Equal(t, "2", msg.EventID)
+		assert.Equal(t, "tweet", msg.Event)
+		assert.Equal(t, "tweet-1", msg.Data.(map[string]any)["ID"])
+	})
+
+	t.Run("Success: should answer pings and report protocol errors", func(t *testing.T) {
+		server, _ := setupWebSocketServer(t)
+		conn, err := dialWebSocket(t, server, "user-1")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, websocket.JSON.Send(conn, WSClientMessage{Type: "ping"}))
+		assert.Equal(t, "pong", receive(t, conn).Type)
+
+		require.NoError(t, websocket.JSON.Send(conn, WSClientMessage{Type: "subscribe", Channel: "thread"}))
+		assert.Equal(t, "INVALID_SUBSCRIPTION", receive(t, conn).ErrorCode)
+
+		require.NoError(t, websocket.Message.Send(conn, "{not json"))
+		assert.Equal(t, "INVALID_MESSAGE", receive(t, conn).ErrorCode)
+	})
+
+	t.Run("Failure: should reject connections without X-User-ID", func(t *testing.T) {
+		server, _ := setupWebSocketServer(t)
+
+		_, err := dialWebSocket(t, server, "")
+
+		var dialErr *websocket.DialError
+		require.ErrorAs(t, err, &dialErr)
+		assert.ErrorIs(t, dialErr.Err, websocket.ErrBadStatus)
+
+		resp, err := http.Get(server.URL + "/api/v1/ws")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Start runs every job once right away and then on its interval, each in its
+// own goroutine, until ctx is cancelled. Failures are logged and the job is
+// retried on the next tick.
+func Start(ctx context.Context, logger *slog.Logger, jobs ...Job) {
+	for _, job := range jobs {
+		go run(ctx, logger.With("component", "jobs", "job", job.Name), job)
+	}
+}
+
+func run(ctx context.Context, logger *slog.Logger, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		start := time.Now()
+		if err := job.Run(ctx); err != nil {
+			logger.Error("Background job failed", "error", err)
+		} else {
+			logger.Debug("Background job finished", "duration", time.Since(start))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
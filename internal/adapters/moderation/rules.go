@@ -0,0 +1,99 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+)
+
+// Rule matches tweets containing Keyword, as a whole word or phrase in any
+// case, or matching the Regex; exactly one of the two must be set. Action
+// is "reject" or "hold" and Reason the code reported for the decision.
+type Rule struct {
+	Keyword string                  `json:"keyword,omitempty"`
+	Regex   string                  `json:"regex,omitempty"`
+	Action  domain.ModerationAction `json:"action"`
+	Reason  string                  `json:"reason"`
+}
+
+type compiledRule struct {
+	pattern *regexp.Regexp
+	action  domain.ModerationAction
+	reason  string
+}
+
+// RuleEngine is a ModerationPolicy that checks the text and poll options of
+// a tweet against a list of rules. Rejections win over holds; among rules
+// with the same action the first one listed gives the reason. Tweets
+// matching no rule are allowed.
+type RuleEngine struct {
+	rules []compiledRule
+}
+
+func NewRuleEngine(rules []Rule) (*RuleEngine, error) {
+	engine := &RuleEngine{}
+	for i, rule := range rules {
+		if rule.Action != domain.ModerationReject && rule.Action != domain.ModerationHold {
+			return nil, fmt.Errorf("rule %d: action must be %q or %q", i, domain.ModerationReject, domain.ModerationHold)
+		}
+		if rule.Reason == "" {
+			return nil, fmt.Errorf("rule %d: reason is required", i)
+		}
+
+		var expr string
+		switch {
+		case rule.Keyword != "" && rule.Regex == "":
+			// RE2's \b only knows ASCII, so words are delimited by hand.
+			expr = `(?i)(?:^|[^\pL\pN_])` + regexp.QuoteMeta(strings.TrimSpace(rule.Keyword)) + `(?:$|[^\pL\pN_])`
+		case rule.Regex != "" && rule.Keyword == "":
+			expr = rule.Regex
+		default:
+			return nil, fmt.Errorf("rule %d: set either keyword or regex", i)
+		}
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		engine.rules = append(engine.rules, compiledRule{pattern: pattern, action: rule.Action, reason: rule.Reason})
+	}
+	return engine, nil
+}
+
+// LoadRules reads the rules from a JSON file holding an array of rules.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading moderation rules: %w", err)
+	}
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("error parsing moderation rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (e *RuleEngine) Review(_ context.Context, _ string, content domain.TweetContent) (domain.ModerationDecision, error) {
+	texts := []string{content.Text}
+	if content.Poll != nil {
+		texts = append(texts, content.Poll.Options...)
+	}
+
+	decision := domain.ModerationDecision{Action: domain.ModerationAllow}
+	for _, rule := range e.rules {
+		if decision.Action == domain.ModerationReject || (decision.Action == domain.ModerationHold && rule.action == domain.ModerationHold) {
+			continue
+		}
+		for _, text := range texts {
+			if rule.pattern.MatchString(text) {
+				decision = domain.ModerationDecision{Action: rule.action, Reason: rule.reason}
+				break
+			}
+		}
+	}
+	return decision, nil
+}
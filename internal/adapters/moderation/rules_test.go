@@ -0,0 +1,82 @@
+package moderation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleEngine_Review(t *testing.T) {
+	ctx := context.Background()
+	engine, err := NewRuleEngine([]Rule{
+		{Keyword: "oferta", Action: domain.ModerationHold, Reason: "PROMOTION"},
+		{Keyword: "compra seguidores", Action: domain.ModerationReject, Reason: "SPAM"},
+		{Regex: `(?i)bit\.ly/\w+`, Action: domain.ModerationHold, Reason: "SHORTENED_LINK"},
+	})
+	require.NoError(t, err)
+
+	review := func(content domain.TweetContent) domain.ModerationDecision {
+		decision, err := engine.Review(ctx, "user-1", content)
+		require.NoError(t, err)
+		return decision
+	}
+
+	t.Run("Success: should allow tweets matching no rule", func(t *testing.T) {
+		decision := review(domain.TweetContent{Text: "Las ofertas de trabajo de hoy"})
+
+		assert.Equal(t, domain.ModerationAllow, decision.Action)
+	})
+
+	t.Run("Success: should match keywords as whole words in any case", func(t *testing.T) {
+		decision := review(domain.TweetContent{Text: "¡OFERTA imperdible!"})
+
+		assert.Equal(t, domain.ModerationDecision{Action: domain.ModerationHold, Reason: "PROMOTION"}, decision)
+	})
+
+	t.Run("Success: should let rejections win over holds", func(t *testing.T) {
+		decision := review(domain.TweetContent{Text: "Oferta: compra seguidores en bit.ly/abc"})
+
+		assert.Equal(t, domain.ModerationDecision{Action: domain.ModerationReject, Reason: "SPAM"}, decision)
+	})
+
+	t.Run("Success: should check the poll options too", func(t *testing.T) {
+		decision := review(domain.TweetContent{
+			Text: "¿Qué preferís?",
+			Poll: &domain.PollSpec{Options: []string{"Nada", "bit.ly/premio"}, Duration: time.Hour},
+		})
+
+		assert.Equal(t, domain.ModerationDecision{Action: domain.ModerationHold, Reason: "SHORTENED_LINK"}, decision)
+	})
+}
+
+func TestNewRuleEngine(t *testing.T) {
+	t.Run("Failure: should reject invalid rules", func(t *testing.T) {
+		for _, rule := range []Rule{
+			{Keyword: "spam", Action: domain.ModerationAllow, Reason: "SPAM"},
+			{Keyword: "spam", Action: domain.ModerationReject},
+			{Keyword: "spam", Regex: "spam", Action: domain.ModerationReject, Reason: "SPAM"},
+			{Regex: "(", Action: domain.ModerationReject, Reason: "SPAM"},
+		} {
+			_, err := NewRuleEngine([]Rule{rule})
+			assert.Error(t, err, rule)
+		}
+	})
+}
+
+func TestLoadRules(t *testing.T) {
+	t.Run("Success: should read the rules from a JSON file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rules.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[{"keyword": "spam", "action": "reject", "reason": "SPAM"}]`), 0o644))
+
+		rules, err := LoadRules(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Rule{{Keyword: "spam", Action: domain.ModerationReject, Reason: "SPAM"}}, rules)
+	})
+}
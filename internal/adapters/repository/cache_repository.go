@@ -16,6 +16,8 @@ type CachingRepository struct {
 	nextUserRepo     ports.UserRepository
 	nextTweetRepo    ports.TweetRepository
 	nextTimelineRepo ports.TimelineRepository
+	nextRelationRepo ports.RelationshipRepository
+	nextRequestRepo  ports.FollowRequestRepository
 	logger           *slog.Logger
 	ttl              time.Duration
 }
@@ -25,6 +27,8 @@ func NewCachingRepository(
 	userRepo ports.UserRepository,
 	tweetRepo ports.TweetRepository,
 	timelineRepo ports.TimelineRepository,
+	relationshipRepo ports.RelationshipRepository,
+	followRequestRepo ports.FollowRequestRepository,
 	logger *slog.Logger,
 ) *CachingRepository {
 	return &CachingRepository{
@@ -32,6 +36,8 @@ func NewCachingRepository(
 		nextUserRepo:     userRepo,
 		nextTweetRepo:    tweetRepo,
 		nextTimelineRepo: timelineRepo,
+		nextRelationRepo: relationshipRepo,
+		nextRequestRepo:  followRequestRepo,
 		logger:           logger.With("component", "CachingRepository"),
 		ttl:              2 * time.Minute,
 	}
@@ -41,7 +47,13 @@ func timelineCacheKey(userID string) string {
 	return "timeline:" + userID
 }
 
-func (r *CachingRepository) Get(ctx context.Context, userID string, limit int) ([]domain.Tweet, error) {
+// Get serves the first page of a timeline from the cache; later pages are
+// always read from the next repository.
+func (r *CachingRepository) Get(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	if cursor != nil {
+		return r.nextTimelineRepo.Get(ctx, userID, cursor, limit)
+	}
+
 	cacheKey := timelineCacheKey(userID)
 
 	val, err := r.redisClient.Get(ctx, cacheKey).Result()
@@ -49,7 +61,7 @@ func (r *CachingRepository) Get(ctx context.Context, userID string, limit int) (
 		r.logger.Debug("Cache HIT for user's timeline", "userID", userID)
 		var timeline []domain.Tweet
 		if json.Unmarshal([]byte(val), &timeline) == nil {
-			return timeline, nil
+			return timeline[:min(limit, len(timeline))], nil
 		}
 	}
 
@@ -58,7 +70,7 @@ func (r *CachingRepository) Get(ctx context.Context, userID string, limit int) (
 	}
 
 	r.logger.Debug("Cache MISS for user's timeline", "userID", userID)
-	timeline, err := r.nextTimelineRepo.Get(ctx, userID, limit)
+	timeline, err := r.nextTimelineRepo.Get(ctx, userID, nil, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -88,14 +100,58 @@ func (r *CachingRepository) PublishTx(ctx context.Context, tweet *domain.Tweet)
 		return err
 	}
 
-	followers, err := r.nextUserRepo.GetFollowers(ctx, tweet.UserID)
+	r.invalidateFollowerTimelines(ctx, tweet.UserID)
+	return nil
+}
+
+func (r *CachingRepository) PublishDraftTx(ctx context.Context, draft *domain.Draft, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.PublishDraftTx(ctx, draft, tweet); err != nil {
+		return err
+	}
+
+	r.invalidateFollowerTimelines(ctx, tweet.UserID)
+	return nil
+}
+
+func (r *CachingRepository) GetTweet(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	return r.nextTweetRepo.GetTweet(ctx, tweetID)
+}
+
+// DeleteTx drops the cached timelines of the author's followers so the
+// deleted tweet is not served from the cache until it expires.
+func (r *CachingRepository) DeleteTx(ctx context.Context, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.DeleteTx(ctx, tweet); err != nil {
+		return err
+	}
+
+	r.invalidateFollowerTimelines(ctx, tweet.UserID)
+	return nil
+}
+
+// EditTx drops the cached timelines of the author's followers, which hold a
+// JSON copy of the tweet, so they are rebuilt with the new text.
+func (r *CachingRepository) EditTx(ctx context.Context, before, after *domain.Tweet) error {
+	if err := r.nextTweetRepo.EditTx(ctx, before, after); err != nil {
+		return err
+	}
+
+	r.invalidateFollowerTimelines(ctx, after.UserID)
+	return nil
+}
+
+func (r *CachingRepository) GetTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	return r.nextTweetRepo.GetTweetRevisions(ctx, tweetID)
+}
+
+func (r *CachingRepository) invalidateFollowerTimelines(ctx context.Context, authorID string) {
+	followers, err := r.nextUserRepo.GetFollowers(ctx, authorID)
 	if err != nil {
-		r.logger.Error("Failed to get followers for cache invalidation", "error", err, "userID", tweet.UserID)
-		return nil
+		r.logger.Error("Failed to get followers for cache invalidation", "error", err, "userID", authorID)
+		return
 	}
 
 	if len(followers) == 0 {
-		return nil
+		return
 	}
 
 	pipe := r.redisClient.Pipeline()
@@ -108,21 +164,111 @@ func (r *CachingRepository) PublishTx(ctx context.Context, tweet *domain.Tweet)
 	}
 
 	r.logger.Info("Cache invalidated for follower timelines", "count", len(followers))
-
-	return nil
 }
 
-func (r *CachingRepository) FollowTx(ctx context.Context, userID, userToFollowID string) error {
-	err := r.nextUserRepo.FollowTx(ctx, userID, userToFollowID)
+func (r *CachingRepository) FollowTx(ctx context.Context, userID, userToFollowID string) (bool, error) {
+	followed, err := r.nextUserRepo.FollowTx(ctx, userID, userToFollowID)
 	if err == nil {
 		r.logger.Info("Invalidating timeline cache for new follower", "userID", userID)
 		if err := r.redisClient.Del(ctx, timelineCacheKey(userID)).Err(); err != nil {
 			r.logger.Warn("Failed to invalidate cache on follow", "error", err, "userID", userID)
 		}
 	}
-	return err
+	return followed, err
 }
 
 func (r *CachingRepository) GetFollowers(ctx context.Context, userID string) ([]string, error) {
 	return r.nextUserRepo.GetFollowers(ctx, userID)
 }
+
+func (r *CachingRepository) GetFollowedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error) {
+	return r.nextUserRepo.GetFollowedUsers(ctx, userID, candidateIDs)
+}
+
+func (r *CachingRepository) GetUsers(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	return r.nextUserRepo.GetUsers(ctx, userIDs)
+}
+
+func (r *CachingRepository) SetProtected(ctx context.Context, userID string, protected bool) error {
+	return r.nextUserRepo.SetProtected(ctx, userID, protected)
+}
+
+func (r *CachingRepository) SetStatus(ctx context.Context, userID string, status domain.UserStatus) error {
+	return r.nextUserRepo.SetStatus(ctx, userID, status)
+}
+
+func (r *CachingRepository) SetStatusUnlessSuspended(ctx context.Context, userID string, status domain.UserStatus) error {
+	return r.nextUserRepo.SetStatusUnlessSuspended(ctx, userID, status)
+}
+
+func (r *CachingRepository) SetStatusIfSuspended(ctx context.Context, userID string, status domain.UserStatus) error {
+	return r.nextUserRepo.SetStatusIfSuspended(ctx, userID, status)
+}
+
+func (r *CachingRepository) AddFollowRequest(ctx context.Context, request domain.FollowRequest) (bool, error) {
+	return r.nextRequestRepo.AddFollowRequest(ctx, request)
+}
+
+func (r *CachingRepository) GetFollowRequests(ctx context.Context, targetID string, limit int) ([]domain.FollowRequest, error) {
+	return r.nextRequestRepo.GetFollowRequests(ctx, targetID, limit)
+}
+
+// ApproveFollowRequestTx backfills the requester's timeline, so its cached
+// copy is dropped just like after FollowTx.
+func (r *CachingRepository) ApproveFollowRequestTx(ctx context.Context, targetID, requesterID string) error {
+	err := r.nextRequestRepo.ApproveFollowRequestTx(ctx, targetID, requesterID)
+	if err == nil {
+		r.invalidateTimelines(ctx, requesterID)
+	}
+	return err
+}
+
+func (r *CachingRepository) DeleteFollowRequest(ctx context.Context, targetID, requesterID string) error {
+	return r.nextRequestRepo.DeleteFollowRequest(ctx, targetID, requesterID)
+}
+
+func (r *CachingRepository) BlockTx(ctx context.Context, userID, blockedUserID string) error {
+	err := r.nextRelationRepo.BlockTx(ctx, userID, blockedUserID)
+	if err == nil {
+		r.invalidateTimelines(ctx, userID, blockedUserID)
+	}
+	return err
+}
+
+func (r *CachingRepository) Unblock(ctx context.Context, userID, blockedUserID string) error {
+	return r.nextRelationRepo.Unblock(ctx, userID, blockedUserID)
+}
+
+func (r *CachingRepository) Mute(ctx context.Context, userID, mutedUserID string) error {
+	err := r.nextRelationRepo.Mute(ctx, userID, mutedUserID)
+	if err == nil {
+		r.invalidateTimelines(ctx, userID)
+	}
+	return err
+}
+
+func (r *CachingRepository) Unmute(ctx context.Context, userID, mutedUserID string) error {
+	err := r.nextRelationRepo.Unmute(ctx, userID, mutedUserID)
+	if err == nil {
+		r.invalidateTimelines(ctx, userID)
+	}
+	return err
+}
+
+func (r *CachingRepository) GetBlockedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error) {
+	return r.nextRelationRepo.GetBlockedUsers(ctx, userID, candidateIDs)
+}
+
+func (r *CachingRepository) GetMutedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error) {
+	return r.nextRelationRepo.GetMutedUsers(ctx, userID, candidateIDs)
+}
+
+func (r *CachingRepository) invalidateTimelines(ctx context.Context, userIDs ...string) {
+	keys := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		keys[i] = timelineCacheKey(userID)
+	}
+	if err := r.redisClient.Del(ctx, keys...).Err(); err != nil {
+		r.logger.Warn("Failed to invalidate timeline cache", "error", err, "userIDs", userIDs)
+	}
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErasureCachingRepository drops every cached copy of an erased user's data:
+// their timeline and suggestions, the timelines of their followers, the
+// suggestions that may recommend them and the list timelines they appear in
+// or own. The keys are collected before the user is erased, since the follow
+// graph and the lists are gone afterwards.
+type ErasureCachingRepository struct {
+	redisClient     *redis.Client
+	nextAccountRepo ports.AccountDataRepository
+	userRepo        ports.UserRepository
+	listRepo        ports.ListRepository
+	logger          *slog.Logger
+}
+
+func NewErasureCachingRepository(
+	client *redis.Client,
+	accountRepo ports.AccountDataRepository,
+	userRepo ports.UserRepository,
+	listRepo ports.ListRepository,
+	logger *slog.Logger,
+) *ErasureCachingRepository {
+	return &ErasureCachingRepository{
+		redisClient:     client,
+		nextAccountRepo: accountRepo,
+		userRepo:        userRepo,
+		listRepo:        listRepo,
+		logger:          logger.With("component", "ErasureCachingRepository"),
+	}
+}
+
+func (r *ErasureCachingRepository) GetUserTweets(ctx context.Context, userID string) ([]domain.Tweet, error) {
+	return r.nextAccountRepo.GetUserTweets(ctx, userID)
+}
+
+func (r *ErasureCachingRepository) GetFollowing(ctx context.Context, userID string) ([]string, error) {
+	return r.nextAccountRepo.GetFollowing(ctx, userID)
+}
+
+func (r *ErasureCachingRepository) GetNotifiedUsers(ctx context.Context, userID string) ([]string, error) {
+	return r.nextAccountRepo.GetNotifiedUsers(ctx, userID)
+}
+
+func (r *ErasureCachingRepository) GetFollowersOfFollowers(ctx context.Context, userID string) ([]string, error) {
+	return r.nextAccountRepo.GetFollowersOfFollowers(ctx, userID)
+}
+
+func (r *ErasureCachingRepository) GetUserMedia(ctx context.Context, userID string) ([]domain.Media, error) {
+	return r.nextAccountRepo.GetUserMedia(ctx, userID)
+}
+
+func (r *ErasureCachingRepository) RequestErasure(ctx context.Context, userID string, requestedAt time.Time) error {
+	return r.nextAccountRepo.RequestErasure(ctx, userID, requestedAt)
+}
+
+func (r *ErasureCachingRepository) GetErasureRequests(ctx context.Context, limit int) ([]string, error) {
+	return r.nextAccountRepo.GetErasureRequests(ctx, limit)
+}
+
+// erasureDeleteBatch bounds the keys deleted per command, since the
+// suggestions of a popular user's second-degree audience can add up to many.
+const erasureDeleteBatch = 1000
+
+// EraseUserTx fails if the keys cannot be collected, so the erasure is
+// retried instead of leaving cached data behind with nothing pointing at it.
+// Once the user is erased the request is gone too; keys that could not be
+// deleted then are left to expire.
+func (r *ErasureCachingRepository) EraseUserTx(ctx context.Context, userID string) error {
+	keys, err := r.cacheKeys(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.nextAccountRepo.EraseUserTx(ctx, userID); err != nil {
+		return err
+	}
+
+	for chunk := range slices.Chunk(keys, erasureDeleteBatch) {
+		if err := r.redisClient.Del(ctx, chunk...).Err(); err != nil {
+			r.logger.Warn("Failed to purge cache for erased user", "error", err, "userID", userID)
+			return nil
+		}
+	}
+	r.logger.Info("Cache purged for erased user", "userID", userID, "keys", len(keys))
+	return nil
+}
+
+func (r *ErasureCachingRepository) cacheKeys(ctx context.Context, userID string) ([]string, error) {
+	keys := []string{timelineCacheKey(userID), suggestionsCacheKey(userID)}
+
+	followers, err := r.userRepo.GetFollowers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, followerID := range followers {
+		keys = append(keys, timelineCacheKey(followerID))
+	}
+
+	// Suggestions are only recomputed by the refresh job, so those that
+	// recommend the user are dropped to be computed again on read.
+	secondDegree, err := r.nextAccountRepo.GetFollowersOfFollowers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range secondDegree {
+		keys = append(keys, suggestionsCacheKey(id))
+	}
+
+	memberLists, err := r.listRepo.GetMemberLists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, listID := range memberLists {
+		keys = append(keys, listTimelineCacheKey(listID))
+	}
+
+	ownedLists, err := r.listRepo.GetLists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range ownedLists {
+		keys = append(keys, listTimelineCacheKey(list.ID))
+	}
+	return keys, nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+	"github.com/redis/go-redis/v9"
+)
+
+// ListCachingRepository caches the first page of list timelines. Since they
+// are computed on read, the cached copies are dropped whenever a member
+// publishes or deletes a tweet and whenever the membership changes.
+type ListCachingRepository struct {
+	redisClient   *redis.Client
+	nextListRepo  ports.ListRepository
+	nextTweetRepo ports.TweetRepository
+	logger        *slog.Logger
+	ttl           time.Duration
+}
+
+func NewListCachingRepository(client *redis.Client, listRepo ports.ListRepository, tweetRepo ports.TweetRepository, logger *slog.Logger) *ListCachingRepository {
+	return &ListCachingRepository{
+		redisClient:   client,
+		nextListRepo:  listRepo,
+		nextTweetRepo: tweetRepo,
+		logger:        logger.With("component", "ListCachingRepository"),
+		ttl:           2 * time.Minute,
+	}
+}
+
+func listTimelineCacheKey(listID string) string {
+	return "list_timeline:" + listID
+}
+
+func (r *ListCachingRepository) GetListTimeline(ctx context.Context, listID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	if cursor != nil {
+		return r.nextListRepo.GetListTimeline(ctx, listID, cursor, limit)
+	}
+
+	cacheKey := listTimelineCacheKey(listID)
+
+	val, err := r.redisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		r.logger.Debug("Cache HIT for list timeline", "listID", listID)
+		var timeline []domain.Tweet
+		if json.Unmarshal([]byte(val), &timeline) == nil {
+			return timeline[:min(limit, len(timeline))], nil
+		}
+	}
+
+	if err != redis.Nil {
+		r.logger.Warn("Redis error on GET (not a cache miss)", "error", err, "key", cacheKey)
+	}
+
+	r.logger.Debug("Cache MISS for list timeline", "listID", listID)
+	timeline, err := r.nextListRepo.GetListTimeline(ctx, listID, nil, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(timeline) > 0 {
+		go func() {
+			bgCtx := context.Background()
+
+			data, marshalErr := json.Marshal(timeline)
+			if marshalErr != nil {
+				r.logger.Error("Background cache population: failed to marshal list timeline", "error", marshalErr, "listID", listID)
+				return
+			}
+
+			if err := r.redisClient.Set(bgCtx, cacheKey, data, r.ttl).Err(); err != nil {
+				r.logger.Error("Background cache population: failed to set cache", "error", err, "listID", listID)
+			}
+		}()
+	}
+
+	return timeline, nil
+}
+
+func (r *ListCachingRepository) PublishTx(ctx context.Context, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.PublishTx(ctx, tweet); err != nil {
+		return err
+	}
+
+	r.invalidateMemberLists(ctx, tweet.UserID)
+	return nil
+}
+
+func (r *ListCachingRepository) PublishDraftTx(ctx context.Context, draft *domain.Draft, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.PublishDraftTx(ctx, draft, tweet); err != nil {
+		return err
+	}
+
+	r.invalidateMemberLists(ctx, tweet.UserID)
+	return nil
+}
+
+func (r *ListCachingRepository) GetTweet(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	return r.nextTweetRepo.GetTweet(ctx, tweetID)
+}
+
+func (r *ListCachingRepository) DeleteTx(ctx context.Context, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.DeleteTx(ctx, tweet); err != nil {
+		return err
+	}
+
+	r.invalidateMemberLists(ctx, tweet.UserID)
+	return nil
+}
+
+func (r *ListCachingRepository) EditTx(ctx context.Context, before, after *domain.Tweet) error {
+	if err := r.nextTweetRepo.EditTx(ctx, before, after); err != nil {
+		return err
+	}
+
+	r.invalidateMemberLists(ctx, after.UserID)
+	return nil
+}
+
+func (r *ListCachingRepository) GetTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	return r.nextTweetRepo.GetTweetRevisions(ctx, tweetID)
+}
+
+func (r *ListCachingRepository) invalidateMemberLists(ctx context.Context, authorID string) {
+	listIDs, err := r.nextListRepo.GetMemberLists(ctx, authorID)
+	if err != nil {
+		r.logger.Error("Failed to get member lists for cache invalidation", "error", err, "userID", authorID)
+		return
+	}
+
+	if len(listIDs) == 0 {
+		return
+	}
+
+	r.invalidateListTimelines(ctx, listIDs...)
+}
+
+func (r *ListCachingRepository) CreateList(ctx context.Context, list *domain.List) error {
+	return r.nextListRepo.CreateList(ctx, list)
+}
+
+func (r *ListCachingRepository) GetList(ctx context.Context, listID string) (*domain.List, error) {
+	return r.nextListRepo.GetList(ctx, listID)
+}
+
+func (r *ListCachingRepository) GetLists(ctx context.Context, ownerID string) ([]domain.List, error) {
+	return r.nextListRepo.GetLists(ctx, ownerID)
+}
+
+func (r *ListCachingRepository) UpdateList(ctx context.Context, list *domain.List) error {
+	return r.nextListRepo.UpdateList(ctx, list)
+}
+
+func (r *ListCachingRepository) DeleteList(ctx context.Context, listID string) error {
+	err := r.nextListRepo.DeleteList(ctx, listID)
+	if err == nil {
+		r.invalidateListTimelines(ctx, listID)
+	}
+	return err
+}
+
+func (r *ListCachingRepository) AddListMember(ctx context.Context, listID, userID string) error {
+	err := r.nextListRepo.AddListMember(ctx, listID, userID)
+	if err == nil {
+		r.invalidateListTimelines(ctx, listID)
+	}
+	return err
+}
+
+func (r *ListCachingRepository) RemoveListMember(ctx context.Context, listID, userID string) error {
+	err := r.nextListRepo.RemoveListMember(ctx, listID, userID)
+	if err == nil {
+		r.invalidateListTimelines(ctx, listID)
+	}
+	return err
+}
+
+func (r *ListCachingRepository) GetListMembers(ctx context.Context, listID string) ([]string, error) {
+	return r.nextListRepo.GetListMembers(ctx, listID)
+}
+
+func (r *ListCachingRepository) GetMemberLists(ctx context.Context, userID string) ([]string, error) {
+	return r.nextListRepo.GetMemberLists(ctx, userID)
+}
+
+func (r *ListCachingRepository) invalidateListTimelines(ctx context.Context, listIDs ...string) {
+	keys := make([]string, len(listIDs))
+	for i, listID := range listIDs {
+		keys[i] = listTimelineCacheKey(listID)
+	}
+	if err := r.redisClient.Del(ctx, keys...).Err(); err != nil {
+		r.logger.Warn("Failed to invalidate list timeline cache", "error", err, "listIDs", listIDs)
+	}
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/EstefiS/uala-challenge/internal/core/domain"
 )
@@ -11,17 +14,111 @@ import (
 type MockRepository struct {
 	mu        sync.RWMutex
 	users     map[string]bool
+	protected map[string]bool
+	statuses  map[string]domain.UserStatus
 	followers map[string]map[string]bool
 	tweets    map[string]*domain.Tweet
 	timelines map[string][]*domain.Tweet
+	mentions  map[string][]*domain.Tweet
+	hashtags  map[string][]*domain.Tweet
+	trends    []domain.Trend
+	index     *searchIndex
+
+	notifications map[string][]*domain.Notification
+	blocks        map[string]map[string]bool
+	mutes         map[string]map[string]bool
+	filters       map[string][]domain.ContentFilter
+
+	followRequests map[string][]domain.FollowRequest
+
+	conversations       map[string]*domain.Conversation
+	directConversations map[string]string
+	messages            map[string][]domain.Message
+
+	bookmarks map[string]map[string]time.Time
+
+	lists       map[string]*domain.List
+	listMembers map[string][]string
+
+	suggestions     map[string][]domain.Suggestion
+	suggestionLease time.Time
+
+	scheduled map[string]*scheduledEntry
+	drafts    map[string]domain.Draft
+
+	revisions map[string][]domain.TweetRevision
+	media     map[string]domain.Media
+	pollVotes map[string]map[string]int
+	previews  map[string]domain.LinkPreview
+	unfurls   map[string]unfurlEntry
+	held      map[string]domain.HeldTweet
+	reports   map[string]domain.Report
+	erasures  map[string]time.Time
+	archived  map[string]*domain.Tweet
+	grown     map[string]bool
+
+	partitions map[string]domain.Partition
+}
+
+// unfurlEntry is how many times a link has been fetched and when it may be
+// claimed again.
+type unfurlEntry struct {
+	attempts      int
+	nextAttemptAt time.Time
+}
+
+// scheduledEntry is a scheduled tweet and the lease of the replica that
+// claimed it, if any.
+type scheduledEntry struct {
+	tweet        domain.ScheduledTweet
+	claimedUntil *time.Time
 }
 
 func NewMockRepository() *MockRepository {
 	return &MockRepository{
 		users:     make(map[string]bool),
+		protected: make(map[string]bool),
+		statuses:  make(map[string]domain.UserStatus),
 		followers: make(map[string]map[string]bool),
 		tweets:    make(map[string]*domain.Tweet),
 		timelines: make(map[string][]*domain.Tweet),
+		mentions:  make(map[string][]*domain.Tweet),
+		hashtags:  make(map[string][]*domain.Tweet),
+		index:     newSearchIndex(),
+
+		notifications: make(map[string][]*domain.Notification),
+		blocks:        make(map[string]map[string]bool),
+		mutes:         make(map[string]map[string]bool),
+		filters:       make(map[string][]domain.ContentFilter),
+
+		followRequests: make(map[string][]domain.FollowRequest),
+
+		conversations:       make(map[string]*domain.Conversation),
+		directConversations: make(map[string]string),
+		messages:            make(map[string][]domain.Message),
+
+		bookmarks: make(map[string]map[string]time.Time),
+
+		lists:       make(map[string]*domain.List),
+		listMembers: make(map[string][]string),
+
+		suggestions: make(map[string][]domain.Suggestion),
+
+		scheduled: make(map[string]*scheduledEntry),
+		drafts:    make(map[string]domain.Draft),
+
+		revisions: make(map[string][]domain.TweetRevision),
+		media:     make(map[string]domain.Media),
+		pollVotes: make(map[string]map[string]int),
+		previews:  make(map[string]domain.LinkPreview),
+		unfurls:   make(map[string]unfurlEntry),
+		held:      make(map[string]domain.HeldTweet),
+		reports:   make(map[string]domain.Report),
+		erasures:  make(map[string]time.Time),
+		archived:  make(map[string]*domain.Tweet),
+		grown:     make(map[string]bool),
+
+		partitions: make(map[string]domain.Partition),
 	}
 }
 
@@ -33,10 +130,20 @@ func (r *MockRepository) ensureUserExists(userID string) {
 }
 
 // --- UserRepository ---
-func (r *MockRepository) FollowTx(_ context.Context, userID, userToFollowID string) error {
+func (r *MockRepository) FollowTx(_ context.Context, userID, userToFollowID string) (bool, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if r.blocks[userID][userToFollowID] || r.blocks[userToFollowID][userID] {
+		return false, domain.ErrUserBlocked
+	}
+
+	followed := !r.followers[userToFollowID][userID]
+	r.follow(userID, userToFollowID)
+	return followed, nil
+}
+
+func (r *MockRepository) follow(userID, userToFollowID string) {
 	r.ensureUserExists(userID)
 	r.ensureUserExists(userToFollowID)
 
@@ -58,8 +165,7 @@ func (r *MockRepository) FollowTx(_ context.Context, userID, userToFollowID stri
 		limit = len(followeeTweets)
 	}
 	r.timelines[userID] = append(r.timelines[userID], followeeTweets[:limit]...)
-
-	return nil
+	r.grown[userID] = true
 }
 
 func (r *MockRepository) GetFollowers(_ context.Context, userID string) ([]string, error) {
@@ -75,45 +181,1773 @@ func (r *MockRepository) GetFollowers(_ context.Context, userID string) ([]strin
 	return followers, nil
 }
 
+func (r *MockRepository) GetUsers(_ context.Context, userIDs []string) ([]domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var users []domain.User
+	for _, id := range userIDs {
+		if r.users[id] {
+			users = append(users, domain.User{ID: id, Protected: r.protected[id], Status: r.userStatus(id)})
+		}
+	}
+	return users, nil
+}
+
+func (r *MockRepository) GetFollowedUsers(_ context.Context, userID string, candidateIDs []string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var followed []string
+	for _, id := range candidateIDs {
+		if r.followers[id][userID] {
+			followed = append(followed, id)
+		}
+	}
+	return followed, nil
+}
+
+func (r *MockRepository) SetProtected(_ context.Context, userID string, protected bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(userID)
+	r.protected[userID] = protected
+	return nil
+}
+
+func (r *MockRepository) SetStatus(_ context.Context, userID string, status domain.UserStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(userID)
+	r.statuses[userID] = status
+	return nil
+}
+
+func (r *MockRepository) SetStatusUnlessSuspended(_ context.Context, userID string, status domain.UserStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.userStatus(userID) == domain.UserSuspended {
+		return domain.ErrAccountSuspended
+	}
+	if _, ok := r.erasures[userID]; ok && status == domain.UserActive {
+		return domain.ErrAccountErasurePending
+	}
+	r.ensureUserExists(userID)
+	r.statuses[userID] = status
+	return nil
+}
+
+func (r *MockRepository) SetStatusIfSuspended(_ context.Context, userID string, status domain.UserStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.userStatus(userID) != domain.UserSuspended {
+		return domain.ErrAccountNotSuspended
+	}
+	r.statuses[userID] = status
+	return nil
+}
+
+func (r *MockRepository) userStatus(userID string) domain.UserStatus {
+	if status, ok := r.statuses[userID]; ok {
+		return status
+	}
+	return domain.UserActive
+}
+
+// --- FollowRequestRepository ---
+func (r *MockRepository) AddFollowRequest(_ context.Context, request domain.FollowRequest) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.blocks[request.RequesterID][request.TargetID] || r.blocks[request.TargetID][request.RequesterID] {
+		return false, domain.ErrUserBlocked
+	}
+	if r.hasFollowRequest(request.TargetID, request.RequesterID) {
+		return false, nil
+	}
+
+	r.ensureUserExists(request.RequesterID)
+	r.ensureUserExists(request.TargetID)
+	r.followRequests[request.TargetID] = append(r.followRequests[request.TargetID], request)
+	return true, nil
+}
+
+func (r *MockRepository) GetFollowRequests(_ context.Context, targetID string, limit int) ([]domain.FollowRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.followRequests[targetID]
+	result := make([]domain.FollowRequest, 0, min(limit, len(stored)))
+	for i := len(stored) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, stored[i])
+	}
+	return result, nil
+}
+
+func (r *MockRepository) ApproveFollowRequestTx(_ context.Context, targetID, requesterID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.removeFollowRequest(targetID, requesterID) {
+		return domain.ErrFollowRequestNotFound
+	}
+	r.follow(requesterID, targetID)
+	return nil
+}
+
+func (r *MockRepository) DeleteFollowRequest(_ context.Context, targetID, requesterID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.removeFollowRequest(targetID, requesterID) {
+		return domain.ErrFollowRequestNotFound
+	}
+	return nil
+}
+
+func (r *MockRepository) hasFollowRequest(targetID, requesterID string) bool {
+	return slices.ContainsFunc(r.followRequests[targetID], func(fr domain.FollowRequest) bool {
+		return fr.RequesterID == requesterID
+	})
+}
+
+func (r *MockRepository) removeFollowRequest(targetID, requesterID string) bool {
+	if !r.hasFollowRequest(targetID, requesterID) {
+		return false
+	}
+	r.followRequests[targetID] = slices.DeleteFunc(r.followRequests[targetID], func(fr domain.FollowRequest) bool {
+		return fr.RequesterID == requesterID
+	})
+	return true
+}
+
+// --- SuggestionRepository ---
+func (r *MockRepository) GetUserIDs(_ context.Context, afterID string, limit int) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var userIDs []string
+	for userID := range r.users {
+		if userID > afterID {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	slices.Sort(userIDs)
+
+	if len(userIDs) > limit {
+		userIDs = userIDs[:limit]
+	}
+	return userIDs, nil
+}
+
+func (r *MockRepository) GetSuggestionCandidates(_ context.Context, userID string, limit int) ([]domain.Suggestion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mutuals := make(map[string]int)
+	for followedID, followers := range r.followers {
+		if !followers[userID] {
+			continue
+		}
+		for candidateID, candidateFollowers := range r.followers {
+			if candidateFollowers[followedID] {
+				mutuals[candidateID]++
+			}
+		}
+	}
+
+	var suggestions []domain.Suggestion
+	for candidateID, count := range mutuals {
+		if candidateID == userID || r.followers[candidateID][userID] || r.hasFollowRequest(candidateID, userID) ||
+			r.blocks[userID][candidateID] || r.blocks[candidateID][userID] {
+			continue
+		}
+		suggestion := domain.Suggestion{UserID: candidateID, MutualCount: count}
+		for _, tweet := range r.tweets {
+			if tweet.UserID == candidateID && (suggestion.LastTweetAt == nil || tweet.CreatedAt.After(*suggestion.LastTweetAt)) {
+				createdAt := tweet.CreatedAt
+				suggestion.LastTweetAt = &createdAt
+			}
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].MutualCount != suggestions[j].MutualCount {
+			return suggestions[i].MutualCount > suggestions[j].MutualCount
+		}
+		return suggestions[i].UserID < suggestions[j].UserID
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+func (r *MockRepository) SaveSuggestions(_ context.Context, userID string, suggestions []domain.Suggestion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.suggestions[userID] = slices.Clone(suggestions)
+	return nil
+}
+
+func (r *MockRepository) GetSuggestions(_ context.Context, userID string) ([]domain.Suggestion, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	suggestions, ok := r.suggestions[userID]
+	return slices.Clone(suggestions), ok, nil
+}
+
+func (r *MockRepository) AcquireRefreshLease(_ context.Context, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(r.suggestionLease) {
+		return false, nil
+	}
+	r.suggestionLease = now.Add(ttl)
+	return true, nil
+}
+
+// --- RelationshipRepository ---
+func (r *MockRepository) BlockTx(_ context.Context, userID, blockedUserID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(userID)
+	r.ensureUserExists(blockedUserID)
+	setRelation(r.blocks, userID, blockedUserID, true)
+
+	delete(r.followers[userID], blockedUserID)
+	delete(r.followers[blockedUserID], userID)
+	r.removeFollowRequest(userID, blockedUserID)
+	r.removeFollowRequest(blockedUserID, userID)
+	r.stripTimeline(userID, blockedUserID)
+	r.stripTimeline(blockedUserID, userID)
+
+	return nil
+}
+
+func (r *MockRepository) Unblock(_ context.Context, userID, blockedUserID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	setRelation(r.blocks, userID, blockedUserID, false)
+	return nil
+}
+
+func (r *MockRepository) Mute(_ context.Context, userID, mutedUserID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(userID)
+	r.ensureUserExists(mutedUserID)
+	setRelation(r.mutes, userID, mutedUserID, true)
+	return nil
+}
+
+func (r *MockRepository) GetBlockedUsers(_ context.Context, userID string, candidateIDs []string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var blocked []string
+	for _, id := range candidateIDs {
+		if r.blocks[userID][id] || r.blocks[id][userID] {
+			blocked = append(blocked, id)
+		}
+	}
+	return blocked, nil
+}
+
+func (r *MockRepository) GetMutedUsers(_ context.Context, userID string, candidateIDs []string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var muted []string
+	for _, id := range candidateIDs {
+		if r.mutes[userID][id] {
+			muted = append(muted, id)
+		}
+	}
+	return muted, nil
+}
+
+func (r *MockRepository) Unmute(_ context.Context, userID, mutedUserID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	setRelation(r.mutes, userID, mutedUserID, false)
+	return nil
+}
+
+func setRelation(relations map[string]map[string]bool, userID, otherUserID string, on bool) {
+	if !on {
+		delete(relations[userID], otherUserID)
+		return
+	}
+	if relations[userID] == nil {
+		relations[userID] = make(map[string]bool)
+	}
+	relations[userID][otherUserID] = true
+}
+
+// stripTimeline removes every tweet by authorID from userID's timeline.
+func (r *MockRepository) stripTimeline(userID, authorID string) {
+	kept := r.timelines[userID][:0]
+	for _, tweet := range r.timelines[userID] {
+		if tweet.UserID != authorID {
+			kept = append(kept, tweet)
+		}
+	}
+	r.timelines[userID] = kept
+}
+
 // --- TweetRepository ---
 func (r *MockRepository) PublishTx(_ context.Context, tweet *domain.Tweet) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	return r.publishTweet(tweet)
+}
+
+func (r *MockRepository) PublishDraftTx(_ context.Context, draft *domain.Draft, tweet *domain.Tweet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.drafts[draft.ID]
+	if !ok || stored.UserID != draft.UserID {
+		return domain.ErrDraftNotFound
+	}
+	if err := r.publishTweet(tweet); err != nil {
+		return err
+	}
+	delete(r.drafts, draft.ID)
+	return nil
+}
+
+// publishTweet must be called with r.mu held.
+func (r *MockRepository) publishTweet(tweet *domain.Tweet) error {
+	if _, ok := r.storedTweet(tweet.ID); ok {
+		return domain.ErrTweetAlreadyPublished
+	}
 	r.ensureUserExists(tweet.UserID)
+	r.attachPreviews(tweet)
 	r.tweets[tweet.ID] = tweet
 
 	if followers, ok := r.followers[tweet.UserID]; ok {
 		for followerID := range followers {
 			r.timelines[followerID] = append(r.timelines[followerID], tweet)
+			r.grown[followerID] = true
+		}
+	}
+
+	mentioned := make(map[string]bool)
+	for _, m := range tweet.Mentions {
+		if !mentioned[m.UserID] {
+			mentioned[m.UserID] = true
+			r.mentions[m.UserID] = append(r.mentions[m.UserID], tweet)
 		}
 	}
 
+	for _, tag := range tweet.HashtagTags() {
+		r.hashtags[tag] = append(r.hashtags[tag], tweet)
+	}
+
+	r.index.add(tweet)
+
 	return nil
 }
 
-// --- TimelineRepository ---
-func (r *MockRepository) Get(_ context.Context, userID string, limit int) ([]domain.Tweet, error) {
+func (r *MockRepository) GetTweet(_ context.Context, tweetID string) (*domain.Tweet, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	timelinePointers, ok := r.timelines[userID]
+	tweet, ok := r.storedTweet(tweetID)
 	if !ok {
-		return []domain.Tweet{}, nil
+		return nil, domain.ErrTweetNotFound
 	}
+	clone := *tweet
+	return &clone, nil
+}
 
-	sort.SliceStable(timelinePointers, func(i, j int) bool {
-		return timelinePointers[i].CreatedAt.After(timelinePointers[j].CreatedAt)
-	})
+// storedTweet returns the tweet, live or archived.
+func (r *MockRepository) storedTweet(tweetID string) (*domain.Tweet, bool) {
+	if tweet, ok := r.tweets[tweetID]; ok {
+		return tweet, true
+	}
+	tweet, ok := r.archived[tweetID]
+	return tweet, ok
+}
+
+func (r *MockRepository) DeleteTx(_ context.Context, tweet *domain.Tweet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.archived[tweet.ID]; ok {
+		delete(r.archived, tweet.ID)
+		r.deleteReferences(tweet.ID)
+		return nil
+	}
+	stored, ok := r.tweets[tweet.ID]
+	if !ok {
+		return domain.ErrTweetNotFound
+	}
+	r.unlistTweet(stored)
+	r.deleteReferences(stored.ID)
+	return nil
+}
+
+// unlistTweet removes the tweet from the live tweets, their search index,
+// timelines and entities, which is all that archiving it does.
+func (r *MockRepository) unlistTweet(tweet *domain.Tweet) {
+	delete(r.tweets, tweet.ID)
+	r.index.remove(tweet)
+
+	isDeleted := func(t *domain.Tweet) bool { return t.ID == tweet.ID }
+	for userID := range r.timelines {
+		r.timelines[userID] = slices.DeleteFunc(r.timelines[userID], isDeleted)
+	}
+	for userID := range r.mentions {
+		r.mentions[userID] = slices.DeleteFunc(r.mentions[userID], isDeleted)
+	}
+	for tag := range r.hashtags {
+		r.hashtags[tag] = slices.DeleteFunc(r.hashtags[tag], isDeleted)
+	}
+}
+
+// deleteReferences removes the notifications, bookmarks, revisions and poll
+// votes of a deleted tweet.
+func (r *MockRepository) deleteReferences(tweetID string) {
+	for userID := range r.notifications {
+		r.notifications[userID] = slices.DeleteFunc(r.notifications[userID], func(n *domain.Notification) bool {
+			return n.TweetID == tweetID
+		})
+	}
+	for _, saved := range r.bookmarks {
+		delete(saved, tweetID)
+	}
+	delete(r.revisions, tweetID)
+	delete(r.pollVotes, tweetID)
+}
+
+// EditTx updates the stored tweet in place, so the timelines holding it see
+// the new text, and re-indexes its entities.
+func (r *MockRepository) EditTx(_ context.Context, before, after *domain.Tweet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	if len(timelinePointers) > limit {
-		timelinePointers = timelinePointers[:limit]
+	stored, ok := r.tweets[after.ID]
+	if !ok {
+		return domain.ErrTweetNotFound
 	}
+	r.index.remove(stored)
 
-	resultTimeline := make([]domain.Tweet, len(timelinePointers))
-	for i, tweetPtr := range timelinePointers {
-		resultTimeline[i] = *tweetPtr
+	isEdited := func(t *domain.Tweet) bool { return t.ID == after.ID }
+	for userID := range r.mentions {
+		r.mentions[userID] = slices.DeleteFunc(r.mentions[userID], isEdited)
+	}
+	for tag := range r.hashtags {
+		r.hashtags[tag] = slices.DeleteFunc(r.hashtags[tag], isEdited)
 	}
 
-	return resultTimeline, nil
+	r.revisions[after.ID] = append(r.revisions[after.ID], before.Revision())
+	*stored = *after
+	r.attachPreviews(stored)
+
+	mentioned := make(map[string]bool)
+	for _, m := range stored.Mentions {
+		if !mentioned[m.UserID] {
+			mentioned[m.UserID] = true
+			r.mentions[m.UserID] = append(r.mentions[m.UserID], stored)
+		}
+	}
+	for _, tag := range stored.HashtagTags() {
+		r.hashtags[tag] = append(r.hashtags[tag], stored)
+	}
+	r.index.add(stored)
+	return nil
+}
+
+func (r *MockRepository) GetTweetRevisions(_ context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.revisions[tweetID]), nil
+}
+
+// --- PollRepository ---
+func (r *MockRepository) AddPollVote(_ context.Context, tweetID, userID string, option int, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tweet, ok := r.tweets[tweetID]
+	if !ok || tweet.Poll == nil {
+		return domain.ErrPollNotFound
+	}
+	if tweet.Poll.Closed(now) {
+		return domain.ErrPollClosed
+	}
+	votes, ok := r.pollVotes[tweetID]
+	if !ok {
+		votes = make(map[string]int)
+		r.pollVotes[tweetID] = votes
+	}
+	if _, voted := votes[userID]; voted {
+		return domain.ErrAlreadyVoted
+	}
+
+	r.ensureUserExists(userID)
+	votes[userID] = option
+	return nil
+}
+
+func (r *MockRepository) GetPollTallies(_ context.Context, tweetID string, options int) ([]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tallies := make([]int, options)
+	for _, option := range r.pollVotes[tweetID] {
+		if option >= 0 && option < options {
+			tallies[option]++
+		}
+	}
+	return tallies, nil
+}
+
+func (r *MockRepository) GetPollVote(_ context.Context, tweetID, userID string) (*int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	option, ok := r.pollVotes[tweetID][userID]
+	if !ok {
+		return nil, nil
+	}
+	return &option, nil
+}
+
+// --- LinkRepository ---
+// The links of the stored tweets without a preview are pending; unfurls only
+// keeps the claims and retries of those that have been fetched.
+func (r *MockRepository) ClaimPendingLinks(_ context.Context, now time.Time, lease time.Duration, limit int) ([]domain.PendingLink, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tweets := make([]*domain.Tweet, 0, len(r.tweets))
+	for _, tweet := range r.tweets {
+		tweets = append(tweets, tweet)
+	}
+	sort.Slice(tweets, func(i, j int) bool { return tweets[i].CreatedAt.Before(tweets[j].CreatedAt) })
+
+	var claimed []domain.PendingLink
+	for _, tweet := range tweets {
+		for _, link := range tweet.Links {
+			if len(claimed) == limit {
+				return claimed, nil
+			}
+			if _, ok := r.previews[link.URL]; ok {
+				continue
+			}
+			entry := r.unfurls[link.URL]
+			if entry.nextAttemptAt.After(now) {
+				continue
+			}
+			entry.attempts++
+			entry.nextAttemptAt = now.Add(lease)
+			r.unfurls[link.URL] = entry
+			claimed = append(claimed, domain.PendingLink{URL: link.URL, Attempts: entry.attempts})
+		}
+	}
+	return claimed, nil
+}
+
+func (r *MockRepository) SaveLinkPreview(_ context.Context, preview *domain.LinkPreview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.previews[preview.URL] = *preview
+	delete(r.unfurls, preview.URL)
+	for _, tweet := range r.tweets {
+		r.attachPreviews(tweet)
+	}
+	return nil
+}
+
+func (r *MockRepository) RetryLink(_ context.Context, url string, retryAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.unfurls[url]; ok {
+		entry.nextAttemptAt = retryAt
+		r.unfurls[url] = entry
+	}
+	return nil
+}
+
+// attachPreviews sets the cards known for the tweet's links. The links are
+// copied rather than updated in place, since readers may hold the previous
+// slice.
+func (r *MockRepository) attachPreviews(tweet *domain.Tweet) {
+	var links []domain.Link
+	for i, link := range tweet.Links {
+		preview, ok := r.previews[link.URL]
+		if !ok || !preview.Unfurled() || link.Preview != nil {
+			continue
+		}
+		if links == nil {
+			links = slices.Clone(tweet.Links)
+		}
+		links[i].Preview = &preview
+	}
+	if links != nil {
+		tweet.Links = links
+	}
+}
+
+// --- MediaRepository ---
+func (r *MockRepository) AddMedia(_ context.Context, media *domain.Media) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(media.UserID)
+	r.media[media.ID] = *media
+	return nil
+}
+
+func (r *MockRepository) GetMedia(_ context.Context, mediaID string) (*domain.Media, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	media, ok := r.media[mediaID]
+	if !ok {
+		return nil, domain.ErrMediaNotFound
+	}
+	return &media, nil
+}
+
+// --- ScheduledTweetRepository ---
+func (r *MockRepository) AddScheduledTweet(_ context.Context, tweet *domain.ScheduledTweet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(tweet.UserID)
+	r.scheduled[tweet.ID] = &scheduledEntry{tweet: *tweet}
+	return nil
+}
+
+func (r *MockRepository) GetScheduledTweet(_ context.Context, tweetID string) (*domain.ScheduledTweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.scheduled[tweetID]
+	if !ok {
+		return nil, domain.ErrScheduledTweetNotFound
+	}
+	tweet := entry.tweet
+	return &tweet, nil
+}
+
+func (r *MockRepository) GetScheduledTweets(_ context.Context, userID string) ([]domain.ScheduledTweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tweets []domain.ScheduledTweet
+	for _, entry := range r.scheduled {
+		if entry.tweet.UserID == userID {
+			tweets = append(tweets, entry.tweet)
+		}
+	}
+	sortScheduled(tweets)
+	return tweets, nil
+}
+
+func (r *MockRepository) UpdateScheduledTweet(_ context.Context, tweet *domain.ScheduledTweet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, err := r.unclaimedScheduled(tweet.ID)
+	if err != nil {
+		return err
+	}
+	entry.tweet = *tweet
+	return nil
+}
+
+func (r *MockRepository) DeleteScheduledTweet(_ context.Context, tweetID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.unclaimedScheduled(tweetID); err != nil {
+		return err
+	}
+	delete(r.scheduled, tweetID)
+	return nil
+}
+
+func (r *MockRepository) unclaimedScheduled(tweetID string) (*scheduledEntry, error) {
+	entry, ok := r.scheduled[tweetID]
+	if !ok {
+		return nil, domain.ErrScheduledTweetNotFound
+	}
+	if entry.claimedUntil != nil {
+		return nil, domain.ErrScheduledTweetPublished
+	}
+	return entry, nil
+}
+
+func (r *MockRepository) ClaimDueTweets(_ context.Context, now time.Time, lease time.Duration, limit int) ([]domain.ScheduledTweet, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []domain.ScheduledTweet
+	for _, entry := range r.scheduled {
+		if entry.tweet.PublishAt.After(now) || (entry.claimedUntil != nil && entry.claimedUntil.After(now)) {
+			continue
+		}
+		due = append(due, entry.tweet)
+	}
+	sortScheduled(due)
+
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	claimedUntil := now.Add(lease)
+	for _, tweet := range due {
+		r.scheduled[tweet.ID].claimedUntil = &claimedUntil
+	}
+	return due, nil
+}
+
+func (r *MockRepository) ReleaseScheduledTweet(_ context.Context, tweetID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.scheduled[tweetID]; ok {
+		entry.claimedUntil = nil
+	}
+	return nil
+}
+
+func (r *MockRepository) CompleteScheduledTweet(_ context.Context, tweetID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.scheduled, tweetID)
+	return nil
+}
+
+func sortScheduled(tweets []domain.ScheduledTweet) {
+	sort.Slice(tweets, func(i, j int) bool {
+		if tweets[i].PublishAt.Equal(tweets[j].PublishAt) {
+			return tweets[i].ID < tweets[j].ID
+		}
+		return tweets[i].PublishAt.Before(tweets[j].PublishAt)
+	})
+}
+
+// --- DraftRepository ---
+func (r *MockRepository) AddDraft(_ context.Context, draft *domain.Draft) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(draft.UserID)
+	r.drafts[draft.ID] = *draft
+	return nil
+}
+
+func (r *MockRepository) GetDraft(_ context.Context, draftID string) (*domain.Draft, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	draft, ok := r.drafts[draftID]
+	if !ok {
+		return nil, domain.ErrDraftNotFound
+	}
+	return &draft, nil
+}
+
+func (r *MockRepository) GetDrafts(_ context.Context, userID string) ([]domain.Draft, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var drafts []domain.Draft
+	for _, draft := range r.drafts {
+		if draft.UserID == userID {
+			drafts = append(drafts, draft)
+		}
+	}
+	sort.Slice(drafts, func(i, j int) bool {
+		if drafts[i].UpdatedAt.Equal(drafts[j].UpdatedAt) {
+			return drafts[i].ID > drafts[j].ID
+		}
+		return drafts[i].UpdatedAt.After(drafts[j].UpdatedAt)
+	})
+	return drafts, nil
+}
+
+func (r *MockRepository) UpdateDraft(_ context.Context, draft *domain.Draft) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.drafts[draft.ID]; !ok || stored.UserID != draft.UserID {
+		return domain.ErrDraftNotFound
+	}
+	r.drafts[draft.ID] = *draft
+	return nil
+}
+
+func (r *MockRepository) DeleteDraft(_ context.Context, userID, draftID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if draft, ok := r.drafts[draftID]; ok && draft.UserID == userID {
+		delete(r.drafts, draftID)
+	}
+	return nil
+}
+
+// --- HeldTweetRepository ---
+func (r *MockRepository) AddHeldTweet(_ context.Context, tweet *domain.HeldTweet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(tweet.UserID)
+	r.held[tweet.ID] = *tweet
+	return nil
+}
+
+func (r *MockRepository) GetHeldTweets(_ context.Context, limit int) ([]domain.HeldTweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tweets := make([]domain.HeldTweet, 0, len(r.held))
+	for _, tweet := range r.held {
+		tweets = append(tweets, tweet)
+	}
+	sort.Slice(tweets, func(i, j int) bool {
+		if tweets[i].CreatedAt.Equal(tweets[j].CreatedAt) {
+			return tweets[i].ID < tweets[j].ID
+		}
+		return tweets[i].CreatedAt.Before(tweets[j].CreatedAt)
+	})
+	return tweets[:min(limit, len(tweets))], nil
+}
+
+func (r *MockRepository) TakeHeldTweet(_ context.Context, tweetID string) (*domain.HeldTweet, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tweet, ok := r.held[tweetID]
+	if !ok {
+		return nil, domain.ErrHeldTweetNotFound
+	}
+	delete(r.held, tweetID)
+	return &tweet, nil
+}
+
+// --- ReportRepository ---
+func (r *MockRepository) AddReport(_ context.Context, report *domain.Report) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.reports {
+		if existing.ReporterID == report.ReporterID && existing.TargetType == report.TargetType &&
+			existing.TargetID == report.TargetID && existing.Status != domain.ReportResolved {
+			return domain.ErrAlreadyReported
+		}
+	}
+	r.ensureUserExists(report.ReporterID)
+	r.ensureUserExists(report.ReportedUserID)
+	r.reports[report.ID] = *report
+	return nil
+}
+
+func (r *MockRepository) GetReport(_ context.Context, reportID string) (*domain.Report, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report, ok := r.reports[reportID]
+	if !ok {
+		return nil, domain.ErrReportNotFound
+	}
+	return &report, nil
+}
+
+func (r *MockRepository) GetReports(_ context.Context, status domain.ReportStatus, limit int) ([]domain.Report, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reports := []domain.Report{}
+	for _, report := range r.reports {
+		if report.Status == status {
+			reports = append(reports, report)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].CreatedAt.Equal(reports[j].CreatedAt) {
+			return reports[i].ID < reports[j].ID
+		}
+		return reports[i].CreatedAt.Before(reports[j].CreatedAt)
+	})
+	return reports[:min(limit, len(reports))], nil
+}
+
+func (r *MockRepository) UpdateReport(_ context.Context, report *domain.Report) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.reports[report.ID]
+	if !ok || existing.Status == domain.ReportResolved {
+		return domain.ErrReportResolved
+	}
+	r.reports[report.ID] = *report
+	return nil
+}
+
+// --- AccountDataRepository ---
+func (r *MockRepository) GetUserTweets(_ context.Context, userID string) ([]domain.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tweets := r.userTweets(userID, nil)
+	return newestFirst(tweets, len(tweets)), nil
+}
+
+// userTweets returns the live and archived tweets of the user after cursor.
+func (r *MockRepository) userTweets(userID string, cursor *domain.Cursor) []*domain.Tweet {
+	var tweets []*domain.Tweet
+	for _, all := range []map[string]*domain.Tweet{r.tweets, r.archived} {
+		for _, tweet := range all {
+			if tweet.UserID == userID && cursor.Before(*tweet) {
+				tweets = append(tweets, tweet)
+			}
+		}
+	}
+	return tweets
+}
+
+func (r *MockRepository) GetFollowing(_ context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var following []string
+	for id, followers := range r.followers {
+		if followers[userID] {
+			following = append(following, id)
+		}
+	}
+	return following, nil
+}
+
+func (r *MockRepository) GetNotifiedUsers(_ context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var notified []string
+	for recipientID, notifications := range r.notifications {
+		if slices.ContainsFunc(notifications, func(n *domain.Notification) bool { return n.ActorID == userID }) {
+			notified = append(notified, recipientID)
+		}
+	}
+	return notified, nil
+}
+
+func (r *MockRepository) GetFollowersOfFollowers(_ context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var secondDegree []string
+	for followerID := range r.followers[userID] {
+		for id := range r.followers[followerID] {
+			if id != userID && !seen[id] {
+				seen[id] = true
+				secondDegree = append(secondDegree, id)
+			}
+		}
+	}
+	return secondDegree, nil
+}
+
+func (r *MockRepository) GetUserMedia(_ context.Context, userID string) ([]domain.Media, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var media []domain.Media
+	for _, m := range r.media {
+		if m.UserID == userID {
+			media = append(media, m)
+		}
+	}
+	return media, nil
+}
+
+func (r *MockRepository) RequestErasure(_ context.Context, userID string, requestedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(userID)
+	if _, ok := r.erasures[userID]; !ok {
+		r.erasures[userID] = requestedAt
+	}
+	return nil
+}
+
+func (r *MockRepository) GetErasureRequests(_ context.Context, limit int) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userIDs := make([]string, 0, len(r.erasures))
+	for userID := range r.erasures {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool {
+		a, b := r.erasures[userIDs[i]], r.erasures[userIDs[j]]
+		if a.Equal(b) {
+			return userIDs[i] < userIDs[j]
+		}
+		return a.Before(b)
+	})
+	return userIDs[:min(limit, len(userIDs))], nil
+}
+
+// EraseUserTx removes the user from every map, mirroring the ON DELETE
+// CASCADE foreign keys of the Postgres schema.
+func (r *MockRepository) EraseUserTx(_ context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, tweet := range r.tweets {
+		if tweet.UserID == userID {
+			r.unlistTweet(tweet)
+			r.deleteReferences(tweet.ID)
+		}
+	}
+
+	delete(r.users, userID)
+	delete(r.protected, userID)
+	delete(r.statuses, userID)
+	delete(r.followers, userID)
+	for _, followers := range r.followers {
+		delete(followers, userID)
+	}
+	delete(r.timelines, userID)
+	delete(r.mentions, userID)
+	delete(r.notifications, userID)
+	for recipientID := range r.notifications {
+		r.notifications[recipientID] = slices.DeleteFunc(r.notifications[recipientID], func(n *domain.Notification) bool {
+			return n.ActorID == userID
+		})
+	}
+
+	delete(r.blocks, userID)
+	for _, blocked := range r.blocks {
+		delete(blocked, userID)
+	}
+	delete(r.mutes, userID)
+	for _, muted := range r.mutes {
+		delete(muted, userID)
+	}
+	delete(r.filters, userID)
+	delete(r.followRequests, userID)
+	for targetID := range r.followRequests {
+		r.followRequests[targetID] = slices.DeleteFunc(r.followRequests[targetID], func(fr domain.FollowRequest) bool {
+			return fr.RequesterID == userID
+		})
+	}
+
+	for id, conversation := range r.conversations {
+		conversation.Participants = slices.DeleteFunc(conversation.Participants, func(p domain.Participant) bool {
+			return p.UserID == userID
+		})
+		r.messages[id] = slices.DeleteFunc(r.messages[id], func(m domain.Message) bool { return m.SenderID == userID })
+		if len(conversation.Participants) == 0 {
+			delete(r.conversations, id)
+			delete(r.messages, id)
+		}
+	}
+	for key, id := range r.directConversations {
+		if _, ok := r.conversations[id]; !ok {
+			delete(r.directConversations, key)
+		}
+	}
+
+	delete(r.bookmarks, userID)
+	for id, list := range r.lists {
+		if list.OwnerID == userID {
+			delete(r.lists, id)
+			delete(r.listMembers, id)
+		}
+	}
+	for id := range r.listMembers {
+		r.listMembers[id] = slices.DeleteFunc(r.listMembers[id], func(memberID string) bool { return memberID == userID })
+	}
+	delete(r.suggestions, userID)
+
+	for id, entry := range r.scheduled {
+		if entry.tweet.UserID == userID {
+			delete(r.scheduled, id)
+		}
+	}
+	for id, draft := range r.drafts {
+		if draft.UserID == userID {
+			delete(r.drafts, id)
+		}
+	}
+	for id, media := range r.media {
+		if media.UserID == userID {
+			delete(r.media, id)
+		}
+	}
+	for _, votes := range r.pollVotes {
+		delete(votes, userID)
+	}
+	for id, held := range r.held {
+		if held.UserID == userID {
+			delete(r.held, id)
+		}
+	}
+	for id, report := range r.reports {
+		if report.ReporterID == userID || report.ReportedUserID == userID {
+			delete(r.reports, id)
+		}
+	}
+	for id, tweet := range r.archived {
+		if tweet.UserID == userID {
+			delete(r.archived, id)
+			r.deleteReferences(id)
+		}
+	}
+	delete(r.erasures, userID)
+	return nil
+}
+
+// --- ProfileRepository ---
+func (r *MockRepository) GetProfileTweets(_ context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return newestFirst(r.userTweets(userID, cursor), limit), nil
+}
+
+// --- RetentionRepository ---
+func (r *MockRepository) TrimTimelinesBefore(_ context.Context, cutoff time.Time, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	trimmed := 0
+	for userID, timeline := range r.timelines {
+		r.timelines[userID] = slices.DeleteFunc(timeline, func(t *domain.Tweet) bool {
+			if trimmed < limit && t.CreatedAt.Before(cutoff) {
+				trimmed++
+				return true
+			}
+			return false
+		})
+	}
+	return trimmed, nil
+}
+
+func (r *MockRepository) GetGrownTimelines(_ context.Context, limit int) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	userIDs := slices.Sorted(maps.Keys(r.grown))
+	return userIDs[:min(limit, len(userIDs))], nil
+}
+
+func (r *MockRepository) TrimTimelines(_ context.Context, userIDs []string, keep int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	trimmed := 0
+	for _, userID := range userIDs {
+		delete(r.grown, userID)
+		timeline := r.timelines[userID]
+		if len(timeline) <= keep {
+			continue
+		}
+		kept := newestFirst(timeline, keep)
+		r.timelines[userID] = slices.DeleteFunc(timeline, func(t *domain.Tweet) bool {
+			return !slices.ContainsFunc(kept, func(k domain.Tweet) bool { return k.ID == t.ID })
+		})
+		trimmed += len(timeline) - keep
+	}
+	return trimmed, nil
+}
+
+func (r *MockRepository) ArchiveTweets(_ context.Context, cutoff time.Time, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var old []*domain.Tweet
+	for _, tweet := range r.tweets {
+		if tweet.CreatedAt.Before(cutoff) {
+			old = append(old, tweet)
+		}
+	}
+	oldest := newestFirst(old, len(old))
+	slices.Reverse(oldest)
+	oldest = oldest[:min(limit, len(oldest))]
+
+	for _, tweet := range oldest {
+		stored := r.tweets[tweet.ID]
+		r.unlistTweet(stored)
+		r.archived[tweet.ID] = stored
+	}
+	return len(oldest), nil
+}
+
+// --- PartitionRepository ---
+// The in-memory tables are not partitioned; the mock keeps track of the
+// partitions and drops the rows of a detached month like PostgreSQL does.
+func (r *MockRepository) GetPartitions(_ context.Context, table string) ([]domain.Partition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var partitions []domain.Partition
+	for _, p := range r.partitions {
+		if p.Table == table {
+			partitions = append(partitions, p)
+		}
+	}
+	slices.SortFunc(partitions, func(a, b domain.Partition) int { return a.From.Compare(b.From) })
+	return partitions, nil
+}
+
+func (r *MockRepository) CreatePartition(_ context.Context, partition domain.Partition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.partitions[partition.Name()] = partition
+	return nil
+}
+
+func (r *MockRepository) DetachPartition(_ context.Context, partition domain.Partition, onlyIfEmpty bool) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inMonth := func(t *domain.Tweet) bool {
+		return !t.CreatedAt.Before(partition.From) && t.CreatedAt.Before(partition.To)
+	}
+	switch partition.Table {
+	case domain.TweetsTable:
+		for _, tweet := range r.tweets {
+			if inMonth(tweet) {
+				return false, nil
+			}
+		}
+	case domain.TimelinesTable:
+		for _, timeline := range r.timelines {
+			if onlyIfEmpty && slices.ContainsFunc(timeline, inMonth) {
+				return false, nil
+			}
+		}
+		for userID, timeline := range r.timelines {
+			r.timelines[userID] = slices.DeleteFunc(timeline, inMonth)
+		}
+	}
+	delete(r.partitions, partition.Name())
+	return true, nil
+}
+
+// DropPartition has nothing to do: the rows of a detached month are already
+// gone from the in-memory tables.
+func (r *MockRepository) DropPartition(_ context.Context, _ domain.Partition) error {
+	return nil
+}
+
+// --- BookmarkRepository ---
+func (r *MockRepository) AddBookmark(_ context.Context, userID, tweetID string, bookmarkedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.storedTweet(tweetID); !ok {
+		return domain.ErrTweetNotFound
+	}
+	if r.bookmarks[userID] == nil {
+		r.bookmarks[userID] = make(map[string]time.Time)
+	}
+	if _, ok := r.bookmarks[userID][tweetID]; !ok {
+		r.bookmarks[userID][tweetID] = bookmarkedAt
+	}
+	return nil
+}
+
+func (r *MockRepository) RemoveBookmark(_ context.Context, userID, tweetID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.bookmarks[userID], tweetID)
+	return nil
+}
+
+func (r *MockRepository) GetBookmarks(_ context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Bookmark, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []domain.Bookmark
+	for tweetID, bookmarkedAt := range r.bookmarks[userID] {
+		tweet, ok := r.storedTweet(tweetID)
+		if ok && cursor.BeforeItem(bookmarkedAt, tweetID) {
+			result = append(result, domain.Bookmark{Tweet: *tweet, BookmarkedAt: bookmarkedAt})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].BookmarkedAt.Equal(result[j].BookmarkedAt) {
+			return result[i].Tweet.ID > result[j].Tweet.ID
+		}
+		return result[i].BookmarkedAt.After(result[j].BookmarkedAt)
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// --- ListRepository ---
+func (r *MockRepository) CreateList(_ context.Context, list *domain.List) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.ensureUserExists(list.OwnerID)
+	stored := *list
+	r.lists[list.ID] = &stored
+	return nil
+}
+
+func (r *MockRepository) GetList(_ context.Context, listID string) (*domain.List, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list, ok := r.lists[listID]
+	if !ok {
+		return nil, domain.ErrListNotFound
+	}
+	result := *list
+	return &result, nil
+}
+
+func (r *MockRepository) GetLists(_ context.Context, ownerID string) ([]domain.List, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []domain.List
+	for _, list := range r.lists {
+		if list.OwnerID == ownerID {
+			result = append(result, *list)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+func (r *MockRepository) UpdateList(_ context.Context, list *domain.List) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.lists[list.ID]; !ok {
+		return domain.ErrListNotFound
+	}
+	stored := *list
+	r.lists[list.ID] = &stored
+	return nil
+}
+
+func (r *MockRepository) DeleteList(_ context.Context, listID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.lists, listID)
+	delete(r.listMembers, listID)
+	return nil
+}
+
+func (r *MockRepository) AddListMember(_ context.Context, listID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.lists[listID]; !ok {
+		return domain.ErrListNotFound
+	}
+	r.ensureUserExists(userID)
+	if !slices.Contains(r.listMembers[listID], userID) {
+		r.listMembers[listID] = append(r.listMembers[listID], userID)
+	}
+	return nil
+}
+
+func (r *MockRepository) RemoveListMember(_ context.Context, listID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.listMembers[listID] = slices.DeleteFunc(r.listMembers[listID], func(id string) bool { return id == userID })
+	return nil
+}
+
+func (r *MockRepository) GetListMembers(_ context.Context, listID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.listMembers[listID]), nil
+}
+
+func (r *MockRepository) GetMemberLists(_ context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var listIDs []string
+	for listID, members := range r.listMembers {
+		if slices.Contains(members, userID) {
+			listIDs = append(listIDs, listID)
+		}
+	}
+	return listIDs, nil
+}
+
+func (r *MockRepository) GetListTimeline(_ context.Context, listID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members := r.listMembers[listID]
+	var tweets []*domain.Tweet
+	for _, tweet := range r.tweets {
+		if slices.Contains(members, tweet.UserID) && cursor.Before(*tweet) {
+			tweets = append(tweets, tweet)
+		}
+	}
+	return newestFirst(tweets, limit), nil
+}
+
+// --- TimelineRepository ---
+func (r *MockRepository) Get(_ context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	timelinePointers, ok := r.timelines[userID]
+	if !ok {
+		return []domain.Tweet{}, nil
+	}
+
+	muted := r.mutes[userID]
+	visible := make([]*domain.Tweet, 0, len(timelinePointers))
+	for _, tweet := range timelinePointers {
+		if !muted[tweet.UserID] && cursor.Before(*tweet) {
+			visible = append(visible, tweet)
+		}
+	}
+
+	return newestFirst(visible, limit), nil
+}
+
+// --- FilterRepository ---
+func (r *MockRepository) AddFilter(_ context.Context, filter *domain.ContentFilter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.filters[filter.UserID] = append(r.filters[filter.UserID], *filter)
+	return nil
+}
+
+func (r *MockRepository) DeleteFilter(_ context.Context, userID, filterID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.filters[userID] = slices.DeleteFunc(r.filters[userID], func(f domain.ContentFilter) bool {
+		return f.ID == filterID
+	})
+	return nil
+}
+
+func (r *MockRepository) GetFilters(_ context.Context, userID string) ([]domain.ContentFilter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.filters[userID]), nil
+}
+
+// --- MentionRepository ---
+func (r *MockRepository) GetMentions(_ context.Context, userID string, limit int) ([]domain.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return newestFirst(r.mentions[userID], limit), nil
+}
+
+// --- HashtagRepository ---
+func (r *MockRepository) GetHashtagTweets(_ context.Context, tag string, limit int) ([]domain.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return newestFirst(r.hashtags[tag], limit), nil
+}
+
+func (r *MockRepository) CountHashtags(_ context.Context, since, until time.Time) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for tag, tweets := range r.hashtags {
+		for _, tweet := range tweets {
+			if r.protected[tweet.UserID] || r.userStatus(tweet.UserID) != domain.UserActive {
+				continue
+			}
+			if !tweet.CreatedAt.Before(since) && tweet.CreatedAt.Before(until) {
+				counts[tag]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+func (r *MockRepository) CountHashtagBuckets(_ context.Context, since, until time.Time, bucketSize time.Duration) ([]domain.HashtagCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type key struct {
+		bucket int64
+		tag    string
+	}
+	counts := make(map[key]int)
+	for tag, tweets := range r.hashtags {
+		for _, tweet := range tweets {
+			if r.protected[tweet.UserID] || r.userStatus(tweet.UserID) != domain.UserActive {
+				continue
+			}
+			if !tweet.CreatedAt.Before(since) && tweet.CreatedAt.Before(until) {
+				counts[key{tweet.CreatedAt.Truncate(bucketSize).Unix(), tag}]++
+			}
+		}
+	}
+
+	var result []domain.HashtagCount
+	for k, count := range counts {
+		result = append(result, domain.HashtagCount{Bucket: time.Unix(k.bucket, 0).UTC(), Tag: k.tag, Count: count})
+	}
+	return result, nil
+}
+
+// --- TrendRepository ---
+func (r *MockRepository) SaveTrends(_ context.Context, trends []domain.Trend) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.trends = append([]domain.Trend(nil), trends...)
+	return nil
+}
+
+// RebuildHashtagCounts has nothing to do: CountHashtags counts the stored
+// tweets.
+func (r *MockRepository) RebuildHashtagCounts(_ context.Context) error {
+	return nil
+}
+
+func (r *MockRepository) GetTrends(_ context.Context) ([]domain.Trend, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]domain.Trend{}, r.trends...), nil
+}
+
+// --- SearchRepository ---
+func (r *MockRepository) Search(_ context.Context, query domain.SearchQuery, limit int) ([]domain.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []*domain.Tweet
+	if query.HasText() {
+		for id := range r.index.match(query.Terms, query.Phrases) {
+			if tweet := r.tweets[id]; query.Matches(*tweet) {
+				matches = append(matches, tweet)
+			}
+		}
+	} else {
+		for _, tweet := range r.tweets {
+			if query.Matches(*tweet) {
+				matches = append(matches, tweet)
+			}
+		}
+	}
+
+	return newestFirst(matches, limit), nil
+}
+
+// --- NotificationRepository ---
+func (r *MockRepository) AddNotifications(_ context.Context, notifications []domain.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, n := range notifications {
+		r.notifications[n.RecipientID] = append(r.notifications[n.RecipientID], &n)
+	}
+	return nil
+}
+
+func (r *MockRepository) GetNotifications(_ context.Context, userID string, limit int) ([]domain.Notification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.notifications[userID]
+	result := make([]domain.Notification, 0, min(limit, len(stored)))
+	for i := len(stored) - 1; i >= 0 && len(result) < limit; i-- {
+		result = append(result, *stored[i])
+	}
+	return result, nil
+}
+
+func (r *MockRepository) MarkNotificationsRead(_ context.Context, userID string, notificationIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make(map[string]bool, len(notificationIDs))
+	for _, id := range notificationIDs {
+		ids[id] = true
+	}
+	for _, n := range r.notifications[userID] {
+		if len(ids) == 0 || ids[n.ID] {
+			n.Read = true
+		}
+	}
+	return nil
+}
+
+// --- MessageRepository ---
+func (r *MockRepository) CreateConversation(_ context.Context, conversation *domain.Conversation) (*domain.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := conversation.DirectKey()
+	if existingID, ok := r.directConversations[key]; ok {
+		return cloneConversation(r.conversations[existingID]), nil
+	}
+
+	for _, id := range conversation.ParticipantIDs() {
+		r.ensureUserExists(id)
+	}
+	stored := cloneConversation(conversation)
+	r.conversations[stored.ID] = stored
+	if key != "" {
+		r.directConversations[key] = stored.ID
+	}
+	return cloneConversation(stored), nil
+}
+
+func (r *MockRepository) GetConversation(_ context.Context, conversationID string) (*domain.Conversation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	conversation, ok := r.conversations[conversationID]
+	if !ok {
+		return nil, domain.ErrConversationNotFound
+	}
+	return cloneConversation(conversation), nil
+}
+
+func (r *MockRepository) GetConversations(_ context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Conversation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []domain.Conversation
+	for _, conversation := range r.conversations {
+		if conversation.HasParticipant(userID) && cursor.BeforeItem(conversation.LastMessageAt, conversation.ID) {
+			result = append(result, *cloneConversation(conversation))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].LastMessageAt.Equal(result[j].LastMessageAt) {
+			return result[i].ID > result[j].ID
+		}
+		return result[i].LastMessageAt.After(result[j].LastMessageAt)
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (r *MockRepository) AddMessage(_ context.Context, message *domain.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[message.ConversationID]
+	if !ok {
+		return domain.ErrConversationNotFound
+	}
+
+	r.messages[message.ConversationID] = append(r.messages[message.ConversationID], *message)
+	conversation.LastMessageAt = message.CreatedAt
+	markRead(conversation, message.SenderID, message.CreatedAt)
+	return nil
+}
+
+func (r *MockRepository) GetMessages(_ context.Context, conversationID string, cursor *domain.Cursor, limit int) ([]domain.Message, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.messages[conversationID]
+	result := make([]domain.Message, 0, min(limit, len(stored)))
+	for i := len(stored) - 1; i >= 0 && len(result) < limit; i-- {
+		if cursor.BeforeItem(stored[i].CreatedAt, stored[i].ID) {
+			result = append(result, stored[i])
+		}
+	}
+	return result, nil
+}
+
+func (r *MockRepository) MarkConversationRead(_ context.Context, conversationID, userID string, readAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[conversationID]
+	if !ok {
+		return domain.ErrConversationNotFound
+	}
+	markRead(conversation, userID, readAt)
+	return nil
+}
+
+// markRead moves a participant's read receipt forward, never backwards.
+func markRead(conversation *domain.Conversation, userID string, readAt time.Time) {
+	for i, p := range conversation.Participants {
+		if p.UserID == userID && (p.LastReadAt == nil || readAt.After(*p.LastReadAt)) {
+			conversation.Participants[i].LastReadAt = &readAt
+		}
+	}
+}
+
+func cloneConversation(conversation *domain.Conversation) *domain.Conversation {
+	clone := *conversation
+	clone.Participants = slices.Clone(conversation.Participants)
+	return &clone
+}
+
+func newestFirst(tweetPointers []*domain.Tweet, limit int) []domain.Tweet {
+	sorted := make([]*domain.Tweet, len(tweetPointers))
+	copy(sorted, tweetPointers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].ID > sorted[j].ID
+		}
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	result := make([]domain.Tweet, len(sorted))
+	for i, tweetPtr := range sorted {
+		result[i] = *tweetPtr
+	}
+	return result
 }
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockRepository_ArchivedTweets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success: should get and delete a tweet after it was archived", func(t *testing.T) {
+		repo := NewMockRepository()
+		tweet := &domain.Tweet{ID: "tweet-1", UserID: "ana", Text: "Viejo", CreatedAt: now.AddDate(-2, 0, 0)}
+		require.NoError(t, repo.PublishTx(ctx, tweet))
+
+		archived, err := repo.ArchiveTweets(ctx, now.AddDate(-1, 0, 0), 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, archived)
+
+		found, err := repo.GetTweet(ctx, "tweet-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Viejo", found.Text)
+
+		require.NoError(t, repo.DeleteTx(ctx, found))
+
+		_, err = repo.GetTweet(ctx, "tweet-1")
+		assert.Equal(t, domain.ErrTweetNotFound, err)
+		tweets, err := repo.GetUserTweets(ctx, "ana")
+		require.NoError(t, err)
+		assert.Empty(t, tweets)
+		assert.Equal(t, domain.ErrTweetNotFound, repo.DeleteTx(ctx, found))
+	})
+
+	t.Run("Success: should keep the history, poll results and bookmarks of an archived tweet", func(t *testing.T) {
+		repo := NewMockRepository()
+		publishedAt := now.AddDate(-2, 0, 0)
+		tweet := &domain.Tweet{
+			ID: "tweet-1", UserID: "ana", Text: "¿Mate o café?", CreatedAt: publishedAt,
+			Poll: &domain.Poll{Options: []string{"Mate", "Café"}, EndsAt: publishedAt.Add(time.Hour)},
+		}
+		require.NoError(t, repo.PublishTx(ctx, tweet))
+		require.NoError(t, repo.AddPollVote(ctx, "tweet-1", "beto", 0, publishedAt))
+		editedAt := publishedAt.Add(time.Minute)
+		edited := *tweet
+		edited.Text, edited.EditedAt = "¿Mate o café? Vale uno solo", &editedAt
+		require.NoError(t, repo.EditTx(ctx, tweet, &edited))
+		require.NoError(t, repo.AddBookmark(ctx, "beto", "tweet-1", now))
+
+		_, err := repo.ArchiveTweets(ctx, now.AddDate(-1, 0, 0), 10)
+		require.NoError(t, err)
+
+		revisions, err := repo.GetTweetRevisions(ctx, "tweet-1")
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TweetRevision{{Text: "¿Mate o café?", CreatedAt: publishedAt}}, revisions)
+		tallies, err := repo.GetPollTallies(ctx, "tweet-1", 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 0}, tallies)
+		bookmarks, err := repo.GetBookmarks(ctx, "beto", nil, 10)
+		require.NoError(t, err)
+		require.Len(t, bookmarks, 1)
+		assert.Equal(t, "tweet-1", bookmarks[0].Tweet.ID)
+
+		require.NoError(t, repo.DeleteTx(ctx, tweet))
+
+		revisions, err = repo.GetTweetRevisions(ctx, "tweet-1")
+		require.NoError(t, err)
+		assert.Empty(t, revisions)
+		bookmarks, err = repo.GetBookmarks(ctx, "beto", nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, bookmarks)
+	})
+}
+
+func TestMockRepository_CountHashtags(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success: should only count the hashtags of public, active accounts", func(t *testing.T) {
+		repo := NewMockRepository()
+		for _, userID := range []string{"ana", "beto", "carla", "dani"} {
+			tweet := &domain.Tweet{ID: "tweet-" + userID, UserID: userID, Text: "#golang", CreatedAt: now, Hashtags: []domain.Hashtag{{Tag: "golang"}}}
+			require.NoError(t, repo.PublishTx(ctx, tweet))
+		}
+		require.NoError(t, repo.SetProtected(ctx, "beto", true))
+		require.NoError(t, repo.SetStatus(ctx, "carla", domain.UserSuspended))
+		require.NoError(t, repo.SetStatus(ctx, "dani", domain.UserDeactivated))
+
+		counts, err := repo.CountHashtags(ctx, now.Add(-time.Hour), now.Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"golang": 1}, counts)
+	})
+
+	t.Run("Success: should count the hashtags per bucket", func(t *testing.T) {
+		repo := NewMockRepository()
+		for i, createdAt := range []time.Time{now, now.Add(time.Minute), now.Add(5 * time.Minute)} {
+			tweet := &domain.Tweet{ID: fmt.Sprintf("tweet-%d", i), UserID: "ana", Text: "#golang", CreatedAt: createdAt, Hashtags: []domain.Hashtag{{Tag: "golang"}}}
+			require.NoError(t, repo.PublishTx(ctx, tweet))
+		}
+
+		counts, err := repo.CountHashtagBuckets(ctx, now, now.Add(time.Hour), 5*time.Minute)
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.HashtagCount{
+			{Bucket: now, Tag: "golang", Count: 2},
+			{Bucket: now.Add(5 * time.Minute), Tag: "golang", Count: 1},
+		}, counts)
+	})
+}
+
+func TestMockRepository_SetStatusUnlessSuspended(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Failure: should not reactivate an account waiting to be erased", func(t *testing.T) {
+		repo := NewMockRepository()
+		require.NoError(t, repo.SetStatus(ctx, "ana", domain.UserDeactivated))
+		require.NoError(t, repo.RequestErasure(ctx, "ana", time.Now()))
+
+		err := repo.SetStatusUnlessSuspended(ctx, "ana", domain.UserActive)
+
+		assert.Equal(t, domain.ErrAccountErasurePending, err)
+		users, err := repo.GetUsers(ctx, []string{"ana"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.UserDeactivated, users[0].Status)
+	})
+}
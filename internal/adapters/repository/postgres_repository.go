@@ -2,17 +2,29 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var ErrUserNotFound = errors.New("user not found")
 
+// foreignKeyViolation is the SQLSTATE Postgres reports when a referenced row
+// does not exist, and notNullViolation the one for a NULL in a NOT NULL column.
+const (
+	foreignKeyViolation = "23503"
+	notNullViolation    = "23502"
+)
+
 type PostgresRepository struct {
 	db     *pgxpool.Pool
 	logger *slog.Logger
@@ -25,13 +37,45 @@ func NewPostgresRepository(db *pgxpool.Pool, logger *slog.Logger) *PostgresRepos
 	}
 }
 
-func (r *PostgresRepository) FollowTx(ctx context.Context, userID, userToFollowID string) error {
+func (r *PostgresRepository) FollowTx(ctx context.Context, userID, userToFollowID string) (bool, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer tx.Rollback(ctx)
 
+	if err := checkNotBlocked(ctx, tx, userID, userToFollowID); err != nil {
+		return false, err
+	}
+
+	followed, err := execFollowBatch(tx.SendBatch(ctx, followBatch(userID, userToFollowID)))
+	if err != nil {
+		return false, fmt.Errorf("error in follow batch transaction: %w", err)
+	}
+
+	return followed, tx.Commit(ctx)
+}
+
+// checkNotBlocked returns ErrUserBlocked if either user has blocked the other.
+func checkNotBlocked(ctx context.Context, tx pgx.Tx, userID, otherUserID string) error {
+	var blocked bool
+	blockedQuery := `
+		SELECT EXISTS (
+			SELECT 1 FROM blocks
+			WHERE (user_id = $1 AND blocked_id = $2) OR (user_id = $2 AND blocked_id = $1)
+		)`
+	if err := tx.QueryRow(ctx, blockedQuery, userID, otherUserID).Scan(&blocked); err != nil {
+		return fmt.Errorf("error checking blocks: %w", err)
+	}
+	if blocked {
+		return domain.ErrUserBlocked
+	}
+	return nil
+}
+
+// followBatch adds the follow edge and backfills the follower's timeline with
+// the latest tweets of the followed user.
+func followBatch(userID, userToFollowID string) *pgx.Batch {
 	batch := &pgx.Batch{}
 
 	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
@@ -45,10 +89,189 @@ func (r *PostgresRepository) FollowTx(ctx context.Context, userID, userToFollowI
 		INSERT INTO timelines (user_id, tweet_id, tweet_created_at)
 		SELECT $1, id, created_at FROM tweets WHERE user_id = $2
 		ORDER BY created_at DESC LIMIT 50
-		ON CONFLICT (user_id, tweet_id) DO NOTHING`
+		ON CONFLICT (user_id, tweet_id, tweet_created_at) DO NOTHING`
 	batch.Queue(backfillQuery, userID, userToFollowID)
+	batch.Queue(markGrownTimelinesQuery, []string{userID})
+
+	return batch
+}
+
+// execFollowBatch runs a followBatch and reports whether it inserted the
+// follow edge, which the conflict on an existing one skips.
+func execFollowBatch(br pgx.BatchResults) (bool, error) {
+	defer br.Close()
+
+	for range 2 { // the users
+		if _, err := br.Exec(); err != nil {
+			return false, err
+		}
+	}
+	tag, err := br.Exec()
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, br.Close()
+}
+
+// markGrownTimelinesQuery records the users whose timelines got entries, so
+// that retention only trims those.
+const markGrownTimelinesQuery = `
+	INSERT INTO grown_timelines (user_id) SELECT unnest($1::VARCHAR[])
+	ON CONFLICT (user_id) DO NOTHING`
+
+func (r *PostgresRepository) GetFollowers(ctx context.Context, userID string) ([]string, error) {
+	query := "SELECT follower_id FROM followers WHERE user_id=$1"
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) GetFollowedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error) {
+	query := "SELECT user_id FROM followers WHERE follower_id = $1 AND user_id = ANY($2)"
+	rows, err := r.db.Query(ctx, query, userID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) GetUsers(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	query := "SELECT id, protected, status FROM users WHERE id = ANY($1)"
+	rows, err := r.db.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.User])
+}
+
+func (r *PostgresRepository) SetProtected(ctx context.Context, userID string, protected bool) error {
+	query := `
+		INSERT INTO users (id, protected, created_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (id) DO UPDATE SET protected = EXCLUDED.protected`
+	_, err := r.db.Exec(ctx, query, userID, protected)
+	return err
+}
+
+func (r *PostgresRepository) SetStatus(ctx context.Context, userID string, status domain.UserStatus) error {
+	query := `
+		INSERT INTO users (id, status, created_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (id) DO UPDATE SET status = EXCLUDED.status`
+	_, err := r.db.Exec(ctx, query, userID, string(status))
+	return err
+}
+
+// SetStatusUnlessSuspended only looks at the erasure requests to tell the
+// two refusals apart once the update matched no row.
+func (r *PostgresRepository) SetStatusUnlessSuspended(ctx context.Context, userID string, status domain.UserStatus) error {
+	query := `
+		INSERT INTO users (id, status, created_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (id) DO UPDATE SET status = EXCLUDED.status
+		WHERE users.status <> 'suspended'
+			AND (EXCLUDED.status <> 'active' OR NOT EXISTS (SELECT 1 FROM erasure_requests WHERE user_id = $1))`
+	tag, err := r.db.Exec(ctx, query, userID, string(status))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	var suspended bool
+	if err := r.db.QueryRow(ctx, "SELECT status = 'suspended' FROM users WHERE id = $1", userID).Scan(&suspended); err != nil {
+		return err
+	}
+	if suspended {
+		return domain.ErrAccountSuspended
+	}
+	return domain.ErrAccountErasurePending
+}
+
+func (r *PostgresRepository) SetStatusIfSuspended(ctx context.Context, userID string, status domain.UserStatus) error {
+	query := "UPDATE users SET status = $2 WHERE id = $1 AND status = 'suspended'"
+	tag, err := r.db.Exec(ctx, query, userID, string(status))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAccountNotSuspended
+	}
+	return nil
+}
+
+func (r *PostgresRepository) AddFollowRequest(ctx context.Context, request domain.FollowRequest) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkNotBlocked(ctx, tx, request.RequesterID, request.TargetID); err != nil {
+		return false, err
+	}
+
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, request.RequesterID)
+	batch.Queue(userInsertQuery, request.TargetID)
 
 	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return false, fmt.Errorf("error in follow request batch transaction: %w", err)
+	}
+
+	requestInsertQuery := `
+		INSERT INTO follow_requests (target_id, requester_id, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`
+	tag, err := tx.Exec(ctx, requestInsertQuery, request.TargetID, request.RequesterID, request.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("error inserting follow request: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *PostgresRepository) GetFollowRequests(ctx context.Context, targetID string, limit int) ([]domain.FollowRequest, error) {
+	query := `
+		SELECT target_id, requester_id, created_at FROM follow_requests
+		WHERE target_id = $1
+		ORDER BY created_at DESC LIMIT $2`
+	rows, err := r.db.Query(ctx, query, targetID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.FollowRequest])
+}
+
+func (r *PostgresRepository) ApproveFollowRequestTx(ctx context.Context, targetID, requesterID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, "DELETE FROM follow_requests WHERE target_id = $1 AND requester_id = $2", targetID, requesterID)
+	if err != nil {
+		return fmt.Errorf("error deleting follow request: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrFollowRequestNotFound
+	}
+
+	br := tx.SendBatch(ctx, followBatch(requesterID, targetID))
 	if err := br.Close(); err != nil {
 		return fmt.Errorf("error in follow batch transaction: %w", err)
 	}
@@ -56,9 +279,107 @@ func (r *PostgresRepository) FollowTx(ctx context.Context, userID, userToFollowI
 	return tx.Commit(ctx)
 }
 
-func (r *PostgresRepository) GetFollowers(ctx context.Context, userID string) ([]string, error) {
-	query := "SELECT follower_id FROM followers WHERE user_id=$1"
-	rows, err := r.db.Query(ctx, query, userID)
+func (r *PostgresRepository) DeleteFollowRequest(ctx context.Context, targetID, requesterID string) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM follow_requests WHERE target_id = $1 AND requester_id = $2", targetID, requesterID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrFollowRequestNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) BlockTx(ctx context.Context, userID, blockedUserID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, userID)
+	batch.Queue(userInsertQuery, blockedUserID)
+
+	batch.Queue("INSERT INTO blocks (user_id, blocked_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", userID, blockedUserID)
+
+	batch.Queue(`
+		DELETE FROM followers
+		WHERE (user_id = $1 AND follower_id = $2) OR (user_id = $2 AND follower_id = $1)`,
+		userID, blockedUserID)
+
+	batch.Queue(`
+		DELETE FROM follow_requests
+		WHERE (target_id = $1 AND requester_id = $2) OR (target_id = $2 AND requester_id = $1)`,
+		userID, blockedUserID)
+
+	batch.Queue(`
+		DELETE FROM timelines tl USING tweets t
+		WHERE tl.tweet_id = t.id AND tl.tweet_created_at = t.created_at
+		AND ((tl.user_id = $1 AND t.user_id = $2) OR (tl.user_id = $2 AND t.user_id = $1))`,
+		userID, blockedUserID)
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error in block batch transaction: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresRepository) Unblock(ctx context.Context, userID, blockedUserID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM blocks WHERE user_id = $1 AND blocked_id = $2", userID, blockedUserID)
+	return err
+}
+
+func (r *PostgresRepository) Mute(ctx context.Context, userID, mutedUserID string) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, userID)
+	batch.Queue(userInsertQuery, mutedUserID)
+	batch.Queue("INSERT INTO mutes (user_id, muted_id) VALUES ($1, $2) ON CONFLICT DO NOTHING", userID, mutedUserID)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error in mute batch: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) Unmute(ctx context.Context, userID, mutedUserID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM mutes WHERE user_id = $1 AND muted_id = $2", userID, mutedUserID)
+	return err
+}
+
+func (r *PostgresRepository) GetBlockedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error) {
+	query := `
+		SELECT blocked_id FROM blocks WHERE user_id = $1 AND blocked_id = ANY($2)
+		UNION
+		SELECT user_id FROM blocks WHERE blocked_id = $1 AND user_id = ANY($2)`
+	rows, err := r.db.Query(ctx, query, userID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) GetMutedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT muted_id FROM mutes WHERE user_id = $1 AND muted_id = ANY($2)", userID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) GetUserIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT id FROM users WHERE id > $1 ORDER BY id LIMIT $2", afterID, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +388,40 @@ func (r *PostgresRepository) GetFollowers(ctx context.Context, userID string) ([
 	return pgx.CollectRows(rows, pgx.RowTo[string])
 }
 
+// GetSuggestionCandidates returns the accounts followed by the accounts
+// userID follows, with how many of them do, leaving out accounts userID
+// already follows, has asked to follow or has a block with.
+func (r *PostgresRepository) GetSuggestionCandidates(ctx context.Context, userID string, limit int) ([]domain.Suggestion, error) {
+	query := `
+		WITH followed AS (
+			SELECT user_id FROM followers WHERE follower_id = $1
+		)
+		SELECT f.user_id, COUNT(*) AS mutual_count,
+			(SELECT MAX(t.created_at) FROM tweets t WHERE t.user_id = f.user_id) AS last_tweet_at
+		FROM followers f
+		WHERE f.follower_id IN (SELECT user_id FROM followed)
+		AND f.user_id <> $1
+		AND f.user_id NOT IN (SELECT user_id FROM followed)
+		AND NOT EXISTS (SELECT 1 FROM follow_requests fr WHERE fr.target_id = f.user_id AND fr.requester_id = $1)
+		AND NOT EXISTS (
+			SELECT 1 FROM blocks b
+			WHERE (b.user_id = $1 AND b.blocked_id = f.user_id) OR (b.user_id = f.user_id AND b.blocked_id = $1)
+		)
+		GROUP BY f.user_id
+		ORDER BY mutual_count DESC, last_tweet_at DESC NULLS LAST, f.user_id
+		LIMIT $2`
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Suggestion, error) {
+		var s domain.Suggestion
+		err := row.Scan(&s.UserID, &s.MutualCount, &s.LastTweetAt)
+		return s, err
+	})
+}
+
 func (r *PostgresRepository) PublishTx(ctx context.Context, tweet *domain.Tweet) error {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
@@ -74,16 +429,62 @@ func (r *PostgresRepository) PublishTx(ctx context.Context, tweet *domain.Tweet)
 	}
 	defer tx.Rollback(ctx)
 
+	return publishTweet(ctx, tx, tweet)
+}
+
+// PublishDraftTx deletes the draft first: when two requests race, the second
+// one blocks on the row lock and then finds nothing to delete.
+func (r *PostgresRepository) PublishDraftTx(ctx context.Context, draft *domain.Draft, tweet *domain.Tweet) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, "DELETE FROM drafts WHERE id = $1 AND user_id = $2", draft.ID, draft.UserID)
+	if err != nil {
+		return fmt.Errorf("error deleting draft: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDraftNotFound
+	}
+
+	return publishTweet(ctx, tx, tweet)
+}
+
+// publishTweet inserts the tweet and fans it out to the author's followers,
+// then commits tx.
+func publishTweet(ctx context.Context, tx pgx.Tx, tweet *domain.Tweet) error {
 	userQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
 	if _, err := tx.Exec(ctx, userQuery, tweet.UserID); err != nil {
 		return fmt.Errorf("error ensuring author user existence: %w", err)
 	}
 
-	tweetQuery := "INSERT INTO tweets (id, user_id, text, created_at) VALUES ($1, $2, $3, $4)"
-	if _, err := tx.Exec(ctx, tweetQuery, tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt); err != nil {
+	idQuery := "INSERT INTO tweet_ids (id, user_id, created_at) VALUES ($1, $2, $3) ON CONFLICT (id) DO NOTHING"
+	tag, err := tx.Exec(ctx, idQuery, tweet.ID, tweet.UserID, tweet.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error registering tweet id: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTweetAlreadyPublished
+	}
+
+	tweetQuery := "INSERT INTO tweets (id, user_id, text, created_at, media_ids) VALUES ($1, $2, $3, $4, $5)"
+	if _, err := tx.Exec(ctx, tweetQuery, tweet.ID, tweet.UserID, tweet.Text, tweet.CreatedAt, mediaIDs(tweet)); err != nil {
 		return fmt.Errorf("error inserting tweet: %w", err)
 	}
 
+	if err := insertEntities(ctx, tx, tweet); err != nil {
+		return err
+	}
+
+	if tweet.Poll != nil {
+		pollQuery := "INSERT INTO polls (tweet_id, tweet_created_at, options, ends_at) VALUES ($1, $2, $3, $4)"
+		if _, err := tx.Exec(ctx, pollQuery, tweet.ID, tweet.CreatedAt, tweet.Poll.Options, tweet.Poll.EndsAt); err != nil {
+			return fmt.Errorf("error inserting poll: %w", err)
+		}
+	}
+
 	followersQuery := "SELECT follower_id FROM followers WHERE user_id = $1"
 	rows, err := tx.Query(ctx, followersQuery, tweet.UserID)
 	if err != nil {
@@ -102,6 +503,7 @@ func (r *PostgresRepository) PublishTx(ctx context.Context, tweet *domain.Tweet)
 		for _, followerID := range followers {
 			batch.Queue(fanOutQuery, followerID, tweet.ID, tweet.CreatedAt)
 		}
+		batch.Queue(markGrownTimelinesQuery, followers)
 		br := tx.SendBatch(ctx, batch)
 		if err := br.Close(); err != nil {
 			return fmt.Errorf("error in fan-out to follower timelines: %w", err)
@@ -111,16 +513,1833 @@ func (r *PostgresRepository) PublishTx(ctx context.Context, tweet *domain.Tweet)
 	return tx.Commit(ctx)
 }
 
-func (r *PostgresRepository) Get(ctx context.Context, userID string, limit int) ([]domain.Tweet, error) {
+// GetTweet reads the creation time from tweet_ids first, so that only the
+// tweet's partition is scanned.
+func (r *PostgresRepository) GetTweet(ctx context.Context, tweetID string) (*domain.Tweet, error) {
 	query := `
-		SELECT t.id, t.user_id, t.text, t.created_at
-		FROM timelines tl JOIN tweets t ON tl.tweet_id = t.id
-		WHERE tl.user_id = $1 ORDER BY tl.tweet_created_at DESC LIMIT $2`
-	rows, err := r.db.Query(ctx, query, userID, limit)
+		SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids FROM tweets t
+		WHERE t.id = $1 AND t.created_at = (SELECT created_at FROM tweet_ids WHERE id = $1)`
+	tweets, err := r.queryTweets(ctx, query, tweetID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	if len(tweets) == 0 {
+		tweets, err = r.queryArchivedTweets(ctx, "SELECT data FROM archived_tweets WHERE id = $1", tweetID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(tweets) == 0 {
+		return nil, domain.ErrTweetNotFound
+	}
+	return &tweets[0], nil
+}
+
+// DeleteTx deletes the tweet's ID and relies on the ON DELETE CASCADE foreign
+// keys of schema.sql to remove the tweet, or its archive row, together with
+// its timeline entries, entities, notifications and bookmarks in the same
+// statement.
+func (r *PostgresRepository) DeleteTx(ctx context.Context, tweet *domain.Tweet) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM tweet_ids WHERE id = $1", tweet.ID)
+	if err != nil {
+		return fmt.Errorf("error deleting tweet: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTweetNotFound
+	}
+	return nil
+}
+
+// EditTx updates the tweet and replaces its mentions, hashtags and links in
+// one transaction. The previous text is stored under the time it was published,
+// so a concurrent edit of the same version fails on the revision primary key
+// instead of silently losing a revision.
+func (r *PostgresRepository) EditTx(ctx context.Context, before, after *domain.Tweet) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, "UPDATE tweets SET text = $3, edited_at = $4 WHERE id = $1 AND created_at = $2", after.ID, after.CreatedAt, after.Text, after.EditedAt)
+	if err != nil {
+		return fmt.Errorf("error updating tweet: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTweetNotFound
+	}
+
+	previous := before.Revision()
+	revisionQuery := "INSERT INTO tweet_revisions (tweet_id, tweet_created_at, text, created_at) VALUES ($1, $2, $3, $4)"
+	if _, err := tx.Exec(ctx, revisionQuery, before.ID, before.CreatedAt, previous.Text, previous.CreatedAt); err != nil {
+		return fmt.Errorf("error inserting tweet revision: %w", err)
+	}
+
+	batch := &pgx.Batch{}
+	batch.Queue("DELETE FROM tweet_mentions WHERE tweet_id = $1", after.ID)
+	batch.Queue("DELETE FROM tweet_hashtags WHERE tweet_id = $1", after.ID)
+	batch.Queue("DELETE FROM tweet_links WHERE tweet_id = $1", after.ID)
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("error deleting tweet entities: %w", err)
+	}
+	if err := insertEntities(ctx, tx, after); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresRepository) GetTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	query := "SELECT text, created_at FROM tweet_revisions WHERE tweet_id = $1 ORDER BY created_at"
+	rows, err := r.db.Query(ctx, query, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.TweetRevision])
+}
 
-	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.Tweet])
+// AddPollVote only inserts the vote while the poll is open; the primary key
+// on (tweet_id, user_id) keeps it to one vote per user even under concurrent
+// requests.
+func (r *PostgresRepository) AddPollVote(ctx context.Context, tweetID, userID string, option int, now time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	userQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	if _, err := tx.Exec(ctx, userQuery, userID); err != nil {
+		return fmt.Errorf("error ensuring voter existence: %w", err)
+	}
+
+	voteQuery := `
+		INSERT INTO poll_votes (tweet_id, user_id, option_index, created_at)
+		SELECT tweet_id, $2, $3, $4 FROM polls WHERE tweet_id = $1 AND ends_at > $4
+		ON CONFLICT DO NOTHING`
+	tag, err := tx.Exec(ctx, voteQuery, tweetID, userID, option, now)
+	if err != nil {
+		return fmt.Errorf("error inserting poll vote: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return r.rejectedVoteError(ctx, tx, tweetID, now)
+	}
+	return tx.Commit(ctx)
+}
+
+// rejectedVoteError explains why AddPollVote inserted no row.
+func (r *PostgresRepository) rejectedVoteError(ctx context.Context, tx pgx.Tx, tweetID string, now time.Time) error {
+	var endsAt time.Time
+	err := tx.QueryRow(ctx, "SELECT ends_at FROM polls WHERE tweet_id = $1", tweetID).Scan(&endsAt)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return domain.ErrPollNotFound
+	case err != nil:
+		return err
+	case !now.Before(endsAt):
+		return domain.ErrPollClosed
+	default:
+		return domain.ErrAlreadyVoted
+	}
+}
+
+func (r *PostgresRepository) GetPollTallies(ctx context.Context, tweetID string, options int) ([]int, error) {
+	query := "SELECT option_index, COUNT(*) FROM poll_votes WHERE tweet_id = $1 GROUP BY option_index"
+	rows, err := r.db.Query(ctx, query, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tallies := make([]int, options)
+	for rows.Next() {
+		var option, votes int
+		if err := rows.Scan(&option, &votes); err != nil {
+			return nil, err
+		}
+		if option >= 0 && option < options {
+			tallies[option] = votes
+		}
+	}
+	return tallies, rows.Err()
+}
+
+func (r *PostgresRepository) GetPollVote(ctx context.Context, tweetID, userID string) (*int, error) {
+	var option int
+	err := r.db.QueryRow(ctx, "SELECT option_index FROM poll_votes WHERE tweet_id = $1 AND user_id = $2", tweetID, userID).Scan(&option)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &option, nil
+}
+
+// mediaIDs returns the media of the tweet as a non-nil slice, since
+// tweets.media_ids cannot be NULL.
+func mediaIDs(tweet *domain.Tweet) []string {
+	if tweet.MediaIDs == nil {
+		return []string{}
+	}
+	return tweet.MediaIDs
+}
+
+func (r *PostgresRepository) AddMedia(ctx context.Context, media *domain.Media) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, media.UserID)
+
+	mediaInsertQuery := `
+		INSERT INTO media (id, user_id, content_type, width, height, size, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	batch.Queue(mediaInsertQuery, media.ID, media.UserID, media.ContentType, media.Width, media.Height, media.Size, media.CreatedAt)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error inserting media: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetMedia(ctx context.Context, mediaID string) (*domain.Media, error) {
+	query := "SELECT id, user_id, content_type, width, height, size, created_at FROM media WHERE id = $1"
+	rows, err := r.db.Query(ctx, query, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	media, err := pgx.CollectRows(rows, pgx.RowToStructByPos[domain.Media])
+	if err != nil {
+		return nil, err
+	}
+	if len(media) == 0 {
+		return nil, domain.ErrMediaNotFound
+	}
+	return &media[0], nil
+}
+
+// ClaimPendingLinks uses FOR UPDATE SKIP LOCKED so that replicas claiming at
+// the same time split the pending links between them instead of fetching the
+// same pages. The claim moves next_attempt_at to the end of the lease.
+func (r *PostgresRepository) ClaimPendingLinks(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.PendingLink, error) {
+	query := `
+		UPDATE link_unfurls SET attempts = attempts + 1, next_attempt_at = $2
+		WHERE url IN (
+			SELECT url FROM link_unfurls
+			WHERE next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING url, attempts`
+	rows, err := r.db.Query(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.PendingLink, error) {
+		var link domain.PendingLink
+		err := row.Scan(&link.URL, &link.Attempts)
+		return link, err
+	})
+}
+
+// SaveLinkPreview stores the preview and takes its link off the queue in the
+// same batch.
+func (r *PostgresRepository) SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error {
+	batch := &pgx.Batch{}
+	batch.Queue(`
+		INSERT INTO link_previews (url, title, description, image_url, site_name, fetched_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (url) DO UPDATE SET
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			image_url = EXCLUDED.image_url,
+			site_name = EXCLUDED.site_name,
+			fetched_at = EXCLUDED.fetched_at`,
+		preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.SiteName, preview.FetchedAt)
+	batch.Queue("DELETE FROM link_unfurls WHERE url = $1", preview.URL)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error saving link preview: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) RetryLink(ctx context.Context, url string, retryAt time.Time) error {
+	_, err := r.db.Exec(ctx, "UPDATE link_unfurls SET next_attempt_at = $2 WHERE url = $1", url, retryAt)
+	return err
+}
+
+// insertEntities stores the mentions, hashtags and links of the tweet.
+func insertEntities(ctx context.Context, tx pgx.Tx, tweet *domain.Tweet) error {
+	if len(tweet.Mentions) > 0 {
+		batch := &pgx.Batch{}
+		mentionQuery := `
+			INSERT INTO tweet_mentions (tweet_id, user_id, byte_start, byte_end, char_start, char_end, tweet_created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`
+		for _, m := range tweet.Mentions {
+			batch.Queue(mentionQuery, tweet.ID, m.UserID, m.ByteStart, m.ByteEnd, m.CharStart, m.CharEnd, tweet.CreatedAt)
+		}
+		br := tx.SendBatch(ctx, batch)
+		if err := br.Close(); err != nil {
+			return fmt.Errorf("error inserting tweet mentions: %w", err)
+		}
+	}
+
+	if len(tweet.Hashtags) > 0 {
+		batch := &pgx.Batch{}
+		hashtagQuery := `
+			INSERT INTO tweet_hashtags (tweet_id, tag, byte_start, byte_end, char_start, char_end, tweet_created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`
+		for _, h := range tweet.Hashtags {
+			batch.Queue(hashtagQuery, tweet.ID, h.Tag, h.ByteStart, h.ByteEnd, h.CharStart, h.CharEnd, tweet.CreatedAt)
+		}
+		br := tx.SendBatch(ctx, batch)
+		if err := br.Close(); err != nil {
+			return fmt.Errorf("error inserting tweet hashtags: %w", err)
+		}
+	}
+
+	if len(tweet.Links) > 0 {
+		batch := &pgx.Batch{}
+		linkQuery := `
+			INSERT INTO tweet_links (tweet_id, url, byte_start, byte_end, char_start, char_end, tweet_created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`
+		// Links without a preview are queued for the unfurl job, due right
+		// away.
+		unfurlQuery := `
+			INSERT INTO link_unfurls (url, next_attempt_at)
+			SELECT $1, NOW() WHERE NOT EXISTS (SELECT 1 FROM link_previews WHERE url = $1)
+			ON CONFLICT (url) DO NOTHING`
+		for _, l := range tweet.Links {
+			batch.Queue(linkQuery, tweet.ID, l.URL, l.ByteStart, l.ByteEnd, l.CharStart, l.CharEnd, tweet.CreatedAt)
+			batch.Queue(unfurlQuery, l.URL)
+		}
+		br := tx.SendBatch(ctx, batch)
+		if err := br.Close(); err != nil {
+			return fmt.Errorf("error inserting tweet links: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *PostgresRepository) AddScheduledTweet(ctx context.Context, tweet *domain.ScheduledTweet) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, tweet.UserID)
+
+	scheduledInsertQuery := `
+		INSERT INTO scheduled_tweets (id, user_id, text, publish_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+	batch.Queue(scheduledInsertQuery, tweet.ID, tweet.UserID, tweet.Text, tweet.PublishAt, tweet.CreatedAt)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error inserting scheduled tweet: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetScheduledTweet(ctx context.Context, tweetID string) (*domain.ScheduledTweet, error) {
+	query := "SELECT id, user_id, text, publish_at, created_at FROM scheduled_tweets WHERE id = $1"
+	tweets, err := r.queryScheduledTweets(ctx, query, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tweets) == 0 {
+		return nil, domain.ErrScheduledTweetNotFound
+	}
+	return &tweets[0], nil
+}
+
+func (r *PostgresRepository) GetScheduledTweets(ctx context.Context, userID string) ([]domain.ScheduledTweet, error) {
+	query := `
+		SELECT id, user_id, text, publish_at, created_at
+		FROM scheduled_tweets
+		WHERE user_id = $1
+		ORDER BY publish_at, id`
+	return r.queryScheduledTweets(ctx, query, userID)
+}
+
+func (r *PostgresRepository) queryScheduledTweets(ctx context.Context, query string, args ...any) ([]domain.ScheduledTweet, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.ScheduledTweet])
+}
+
+func (r *PostgresRepository) UpdateScheduledTweet(ctx context.Context, tweet *domain.ScheduledTweet) error {
+	query := "UPDATE scheduled_tweets SET text = $2, publish_at = $3 WHERE id = $1 AND claimed_until IS NULL"
+	tag, err := r.db.Exec(ctx, query, tweet.ID, tweet.Text, tweet.PublishAt)
+	if err != nil {
+		return fmt.Errorf("error updating scheduled tweet: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return r.unchangedScheduledTweetError(ctx, tweet.ID)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) DeleteScheduledTweet(ctx context.Context, tweetID string) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM scheduled_tweets WHERE id = $1 AND claimed_until IS NULL", tweetID)
+	if err != nil {
+		return fmt.Errorf("error deleting scheduled tweet: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return r.unchangedScheduledTweetError(ctx, tweetID)
+	}
+	return nil
+}
+
+// unchangedScheduledTweetError explains why an update or delete guarded by
+// "claimed_until IS NULL" matched no row.
+func (r *PostgresRepository) unchangedScheduledTweetError(ctx context.Context, tweetID string) error {
+	var claimed bool
+	err := r.db.QueryRow(ctx, "SELECT claimed_until IS NOT NULL FROM scheduled_tweets WHERE id = $1", tweetID).Scan(&claimed)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return domain.ErrScheduledTweetNotFound
+	case err != nil:
+		return err
+	case claimed:
+		return domain.ErrScheduledTweetPublished
+	default:
+		return domain.ErrScheduledTweetNotFound
+	}
+}
+
+// ClaimDueTweets uses FOR UPDATE SKIP LOCKED so that replicas claiming at the
+// same time split the due tweets between them instead of sharing them.
+func (r *PostgresRepository) ClaimDueTweets(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.ScheduledTweet, error) {
+	query := `
+		UPDATE scheduled_tweets SET claimed_until = $2
+		WHERE id IN (
+			SELECT id FROM scheduled_tweets
+			WHERE publish_at <= $1 AND (claimed_until IS NULL OR claimed_until <= $1)
+			ORDER BY publish_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, text, publish_at, created_at`
+	tweets, err := r.queryScheduledTweets(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(tweets, func(a, b domain.ScheduledTweet) int {
+		return a.PublishAt.Compare(b.PublishAt)
+	})
+	return tweets, nil
+}
+
+func (r *PostgresRepository) ReleaseScheduledTweet(ctx context.Context, tweetID string) error {
+	_, err := r.db.Exec(ctx, "UPDATE scheduled_tweets SET claimed_until = NULL WHERE id = $1", tweetID)
+	return err
+}
+
+func (r *PostgresRepository) CompleteScheduledTweet(ctx context.Context, tweetID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM scheduled_tweets WHERE id = $1", tweetID)
+	return err
+}
+
+func (r *PostgresRepository) AddDraft(ctx context.Context, draft *domain.Draft) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, draft.UserID)
+
+	draftInsertQuery := `
+		INSERT INTO drafts (id, user_id, text, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)`
+	batch.Queue(draftInsertQuery, draft.ID, draft.UserID, draft.Text, draft.CreatedAt, draft.UpdatedAt)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error inserting draft: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetDraft(ctx context.Context, draftID string) (*domain.Draft, error) {
+	drafts, err := r.queryDrafts(ctx, "SELECT id, user_id, text, created_at, updated_at FROM drafts WHERE id = $1", draftID)
+	if err != nil {
+		return nil, err
+	}
+	if len(drafts) == 0 {
+		return nil, domain.ErrDraftNotFound
+	}
+	return &drafts[0], nil
+}
+
+func (r *PostgresRepository) GetDrafts(ctx context.Context, userID string) ([]domain.Draft, error) {
+	query := `
+		SELECT id, user_id, text, created_at, updated_at
+		FROM drafts
+		WHERE user_id = $1
+		ORDER BY updated_at DESC, id DESC`
+	return r.queryDrafts(ctx, query, userID)
+}
+
+func (r *PostgresRepository) queryDrafts(ctx context.Context, query string, args ...any) ([]domain.Draft, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.Draft])
+}
+
+func (r *PostgresRepository) UpdateDraft(ctx context.Context, draft *domain.Draft) error {
+	query := "UPDATE drafts SET text = $3, updated_at = $4 WHERE id = $1 AND user_id = $2"
+	tag, err := r.db.Exec(ctx, query, draft.ID, draft.UserID, draft.Text, draft.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("error updating draft: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDraftNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) DeleteDraft(ctx context.Context, userID, draftID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM drafts WHERE id = $1 AND user_id = $2", draftID, userID)
+	return err
+}
+
+func (r *PostgresRepository) AddHeldTweet(ctx context.Context, tweet *domain.HeldTweet) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, tweet.UserID)
+
+	var pollOptions []string
+	var pollDuration *int64
+	if poll := tweet.Content.Poll; poll != nil {
+		seconds := int64(poll.Duration / time.Second)
+		pollOptions, pollDuration = poll.Options, &seconds
+	}
+	mediaIDs := tweet.Content.MediaIDs
+	if mediaIDs == nil {
+		mediaIDs = []string{}
+	}
+	heldInsertQuery := `
+		INSERT INTO held_tweets (id, user_id, text, media_ids, poll_options, poll_duration_seconds, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	batch.Queue(heldInsertQuery, tweet.ID, tweet.UserID, tweet.Content.Text, mediaIDs, pollOptions, pollDuration, tweet.Reason, tweet.CreatedAt)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error inserting held tweet: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetHeldTweets(ctx context.Context, limit int) ([]domain.HeldTweet, error) {
+	query := `
+		SELECT id, user_id, text, media_ids, poll_options, poll_duration_seconds, reason, created_at
+		FROM held_tweets
+		ORDER BY created_at, id
+		LIMIT $1`
+	return r.queryHeldTweets(ctx, query, limit)
+}
+
+func (r *PostgresRepository) TakeHeldTweet(ctx context.Context, tweetID string) (*domain.HeldTweet, error) {
+	query := `
+		DELETE FROM held_tweets WHERE id = $1
+		RETURNING id, user_id, text, media_ids, poll_options, poll_duration_seconds, reason, created_at`
+	tweets, err := r.queryHeldTweets(ctx, query, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tweets) == 0 {
+		return nil, domain.ErrHeldTweetNotFound
+	}
+	return &tweets[0], nil
+}
+
+func (r *PostgresRepository) queryHeldTweets(ctx context.Context, query string, args ...any) ([]domain.HeldTweet, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.HeldTweet, error) {
+		var t domain.HeldTweet
+		var pollOptions []string
+		var pollDuration *int64
+		err := row.Scan(&t.ID, &t.UserID, &t.Content.Text, &t.Content.MediaIDs, &pollOptions, &pollDuration, &t.Reason, &t.CreatedAt)
+		if pollDuration != nil {
+			t.Content.Poll = &domain.PollSpec{Options: pollOptions, Duration: time.Duration(*pollDuration) * time.Second}
+		}
+		return t, err
+	})
+}
+
+// uniqueViolation is the SQLSTATE Postgres reports when a row conflicts with
+// a unique index.
+const uniqueViolation = "23505"
+
+func (r *PostgresRepository) AddReport(ctx context.Context, report *domain.Report) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, report.ReporterID)
+	batch.Queue(userInsertQuery, report.ReportedUserID)
+	reportInsertQuery := `
+		INSERT INTO reports (id, reporter_id, target_type, target_id, reported_user_id, reason, comment, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	batch.Queue(reportInsertQuery, report.ID, report.ReporterID, string(report.TargetType), report.TargetID, report.ReportedUserID,
+		string(report.Reason), report.Comment, string(report.Status), report.CreatedAt, report.UpdatedAt)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return domain.ErrAlreadyReported
+		}
+		return fmt.Errorf("error inserting report: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetReport(ctx context.Context, reportID string) (*domain.Report, error) {
+	query := `
+		SELECT id, reporter_id, target_type, target_id, reported_user_id, reason, comment, status, moderator_id, resolution, note, created_at, updated_at
+		FROM reports WHERE id = $1`
+	reports, err := r.queryReports(ctx, query, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return nil, domain.ErrReportNotFound
+	}
+	return &reports[0], nil
+}
+
+func (r *PostgresRepository) GetReports(ctx context.Context, status domain.ReportStatus, limit int) ([]domain.Report, error) {
+	query := `
+		SELECT id, reporter_id, target_type, target_id, reported_user_id, reason, comment, status, moderator_id, resolution, note, created_at, updated_at
+		FROM reports WHERE status = $1
+		ORDER BY created_at, id
+		LIMIT $2`
+	return r.queryReports(ctx, query, string(status), limit)
+}
+
+func (r *PostgresRepository) UpdateReport(ctx context.Context, report *domain.Report) error {
+	query := `
+		UPDATE reports SET status = $2, moderator_id = $3, resolution = $4, note = $5, updated_at = $6
+		WHERE id = $1 AND status <> 'resolved'`
+	tag, err := r.db.Exec(ctx, query, report.ID, string(report.Status), report.ModeratorID, string(report.Resolution), report.Note, report.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrReportResolved
+	}
+	return nil
+}
+
+func (r *PostgresRepository) queryReports(ctx context.Context, query string, args ...any) ([]domain.Report, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.Report])
+}
+
+// GetUserTweets includes the archived tweets, which are older than every
+// live one.
+func (r *PostgresRepository) GetUserTweets(ctx context.Context, userID string) ([]domain.Tweet, error) {
+	query := `
+		SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids
+		FROM tweets t WHERE t.user_id = $1
+		ORDER BY t.created_at DESC, t.id DESC`
+	tweets, err := r.queryTweets(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := r.queryArchivedTweets(ctx, "SELECT data FROM archived_tweets WHERE user_id = $1 ORDER BY created_at DESC, id DESC", userID)
+	if err != nil {
+		return nil, err
+	}
+	return append(tweets, archived...), nil
+}
+
+func (r *PostgresRepository) GetFollowing(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT user_id FROM followers WHERE follower_id = $1", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) GetNotifiedUsers(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT DISTINCT recipient_id FROM notifications WHERE actor_id = $1", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) GetFollowersOfFollowers(ctx context.Context, userID string) ([]string, error) {
+	query := `
+		SELECT DISTINCT f2.follower_id FROM followers f1
+		JOIN followers f2 ON f2.user_id = f1.follower_id
+		WHERE f1.user_id = $1 AND f2.follower_id <> $1`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) GetUserMedia(ctx context.Context, userID string) ([]domain.Media, error) {
+	query := "SELECT id, user_id, content_type, width, height, size, created_at FROM media WHERE user_id = $1"
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.Media])
+}
+
+func (r *PostgresRepository) RequestErasure(ctx context.Context, userID string, requestedAt time.Time) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, userID)
+	batch.Queue("INSERT INTO erasure_requests (user_id, requested_at) VALUES ($1, $2) ON CONFLICT (user_id) DO NOTHING", userID, requestedAt)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error inserting erasure request: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetErasureRequests(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT user_id FROM erasure_requests ORDER BY requested_at, user_id LIMIT $1", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// EraseUserTx deletes the user row and lets the ON DELETE CASCADE foreign
+// keys of schema.sql remove the rest: tweets with everything derived from
+// them, follow edges in both directions, timeline entries, messages, lists,
+// media metadata and the erasure request itself. Conversations left without
+// participants are deleted first, since nothing else would reach them.
+func (r *PostgresRepository) EraseUserTx(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	batch.Queue(`
+		DELETE FROM conversations c
+		WHERE c.id IN (SELECT conversation_id FROM conversation_participants WHERE user_id = $1)
+		AND NOT EXISTS (SELECT 1 FROM conversation_participants p WHERE p.conversation_id = c.id AND p.user_id <> $1)`,
+		userID)
+	batch.Queue("DELETE FROM users WHERE id = $1", userID)
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error in erase user batch transaction: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresRepository) AddBookmark(ctx context.Context, userID, tweetID string, bookmarkedAt time.Time) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, userID)
+	batch.Queue(`
+		INSERT INTO bookmarks (user_id, tweet_id, tweet_created_at, created_at)
+		VALUES ($1, $2, (SELECT created_at FROM tweet_ids WHERE id = $2), $3)
+		ON CONFLICT DO NOTHING`, userID, tweetID, bookmarkedAt)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		// A missing tweet leaves tweet_created_at NULL, or fails the foreign
+		// key if it is deleted in between.
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && (pgErr.Code == notNullViolation || pgErr.Code == foreignKeyViolation) {
+			return domain.ErrTweetNotFound
+		}
+		return fmt.Errorf("error in bookmark batch: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) RemoveBookmark(ctx context.Context, userID, tweetID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM bookmarks WHERE user_id = $1 AND tweet_id = $2", userID, tweetID)
+	return err
+}
+
+// GetBookmarks reads the page from the live and the archived tweets
+// separately and merges both.
+func (r *PostgresRepository) GetBookmarks(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Bookmark, error) {
+	page := " WHERE b.user_id = $1"
+	args := []any{userID}
+	if cursor != nil {
+		page += " AND (b.created_at, b.tweet_id) < ($2, $3)"
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+	page += fmt.Sprintf(" ORDER BY b.created_at DESC, b.tweet_id DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	bookmarks, err := r.queryLiveBookmarks(ctx, `
+		SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids, b.created_at
+		FROM bookmarks b JOIN tweets t ON t.id = b.tweet_id AND t.created_at = b.tweet_created_at`+page, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, "SELECT a.data, b.created_at FROM bookmarks b JOIN archived_tweets a ON a.id = b.tweet_id"+page, args...)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Bookmark, error) {
+		var b domain.Bookmark
+		var data []byte
+		if err := row.Scan(&data, &b.BookmarkedAt); err != nil {
+			return b, err
+		}
+		return b, json.Unmarshal(data, &b.Tweet)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks = append(bookmarks, archived...)
+	slices.SortStableFunc(bookmarks, func(a, b domain.Bookmark) int {
+		if c := b.BookmarkedAt.Compare(a.BookmarkedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.Tweet.ID, a.Tweet.ID)
+	})
+	return bookmarks[:min(limit, len(bookmarks))], nil
+}
+
+func (r *PostgresRepository) queryLiveBookmarks(ctx context.Context, query string, args ...any) ([]domain.Bookmark, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	bookmarks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Bookmark, error) {
+		var b domain.Bookmark
+		err := row.Scan(&b.Tweet.ID, &b.Tweet.UserID, &b.Tweet.Text, &b.Tweet.CreatedAt, &b.Tweet.EditedAt, &b.Tweet.MediaIDs, &b.BookmarkedAt)
+		return b, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tweets := make([]domain.Tweet, len(bookmarks))
+	for i, b := range bookmarks {
+		tweets[i] = b.Tweet
+	}
+	if err := r.loadEntities(ctx, tweets); err != nil {
+		return nil, err
+	}
+	for i := range bookmarks {
+		bookmarks[i].Tweet = tweets[i]
+	}
+	return bookmarks, nil
+}
+
+func (r *PostgresRepository) CreateList(ctx context.Context, list *domain.List) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, list.OwnerID)
+
+	listInsertQuery := `
+		INSERT INTO lists (id, owner_id, name, description, private, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	batch.Queue(listInsertQuery, list.ID, list.OwnerID, list.Name, list.Description, list.Private, list.CreatedAt)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error inserting list: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetList(ctx context.Context, listID string) (*domain.List, error) {
+	lists, err := r.queryLists(ctx, "SELECT id, owner_id, name, description, private, created_at FROM lists WHERE id = $1", listID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lists) == 0 {
+		return nil, domain.ErrListNotFound
+	}
+	return &lists[0], nil
+}
+
+func (r *PostgresRepository) GetLists(ctx context.Context, ownerID string) ([]domain.List, error) {
+	query := `
+		SELECT id, owner_id, name, description, private, created_at
+		FROM lists
+		WHERE owner_id = $1
+		ORDER BY created_at`
+	return r.queryLists(ctx, query, ownerID)
+}
+
+func (r *PostgresRepository) queryLists(ctx context.Context, query string, args ...any) ([]domain.List, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.List])
+}
+
+func (r *PostgresRepository) UpdateList(ctx context.Context, list *domain.List) error {
+	query := "UPDATE lists SET name = $2, description = $3, private = $4 WHERE id = $1"
+	tag, err := r.db.Exec(ctx, query, list.ID, list.Name, list.Description, list.Private)
+	if err != nil {
+		return fmt.Errorf("error updating list: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrListNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) DeleteList(ctx context.Context, listID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM lists WHERE id = $1", listID)
+	return err
+}
+
+func (r *PostgresRepository) AddListMember(ctx context.Context, listID, userID string) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, userID)
+	batch.Queue("INSERT INTO list_members (list_id, user_id, created_at) VALUES ($1, $2, NOW()) ON CONFLICT DO NOTHING", listID, userID)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return domain.ErrListNotFound
+		}
+		return fmt.Errorf("error in list member batch: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) RemoveListMember(ctx context.Context, listID, userID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM list_members WHERE list_id = $1 AND user_id = $2", listID, userID)
+	return err
+}
+
+func (r *PostgresRepository) GetListMembers(ctx context.Context, listID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT user_id FROM list_members WHERE list_id = $1 ORDER BY created_at", listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) GetMemberLists(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT list_id FROM list_members WHERE user_id = $1", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// GetListTimeline is fan-out-on-read: unlike the home timeline there is no
+// materialized table, the members' tweets are merged at query time through
+// idx_tweets_user_created_at.
+func (r *PostgresRepository) GetListTimeline(ctx context.Context, listID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	query := `
+		SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids
+		FROM list_members lm JOIN tweets t ON t.user_id = lm.user_id
+		WHERE lm.list_id = $1`
+	args := []any{listID}
+	if cursor != nil {
+		query += " AND t.created_at <= $2 AND (t.created_at, t.id) < ($2, $3)"
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+	query += fmt.Sprintf(" ORDER BY t.created_at DESC, t.id DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+	return r.queryTweets(ctx, query, args...)
+}
+
+// Get lets PostgreSQL prune partitions on both tables: the planner does not
+// prune on row comparisons, so the cursor is also given as a plain bound on
+// the partition key, and joining on the creation time lets every timeline
+// entry probe only the tweets partition of its month.
+func (r *PostgresRepository) Get(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	query := `
+		SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids
+		FROM timelines tl JOIN tweets t ON t.id = tl.tweet_id AND t.created_at = tl.tweet_created_at
+		WHERE tl.user_id = $1
+		AND NOT EXISTS (SELECT 1 FROM mutes m WHERE m.user_id = $1 AND m.muted_id = t.user_id)`
+	args := []any{userID}
+	if cursor != nil {
+		query += " AND tl.tweet_created_at <= $2 AND (tl.tweet_created_at, tl.tweet_id) < ($2, $3)"
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+	query += fmt.Sprintf(" ORDER BY tl.tweet_created_at DESC, tl.tweet_id DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+	return r.queryTweets(ctx, query, args...)
+}
+
+// GetProfileTweets reads a page from the live tweets and one from the
+// archive and merges them; both are served by a (user_id, created_at) index.
+func (r *PostgresRepository) GetProfileTweets(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	query := `
+		SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids
+		FROM tweets t WHERE t.user_id = $1`
+	archiveQuery := "SELECT data FROM archived_tweets WHERE user_id = $1"
+	args := []any{userID}
+	if cursor != nil {
+		query += " AND t.created_at <= $2 AND (t.created_at, t.id) < ($2, $3)"
+		archiveQuery += " AND (created_at, id) < ($2, $3)"
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+	query += fmt.Sprintf(" ORDER BY t.created_at DESC, t.id DESC LIMIT $%d", len(args)+1)
+	archiveQuery += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	tweets, err := r.queryTweets(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := r.queryArchivedTweets(ctx, archiveQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tweets = append(tweets, archived...)
+	slices.SortStableFunc(tweets, func(a, b domain.Tweet) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	return tweets[:min(limit, len(tweets))], nil
+}
+
+func (r *PostgresRepository) queryArchivedTweets(ctx context.Context, query string, args ...any) ([]domain.Tweet, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Tweet, error) {
+		var t domain.Tweet
+		var data []byte
+		if err := row.Scan(&data); err != nil {
+			return t, err
+		}
+		return t, json.Unmarshal(data, &t)
+	})
+}
+
+// TrimTimelinesBefore deletes through idx_timelines_created_at, a batch of
+// rows at a time.
+func (r *PostgresRepository) TrimTimelinesBefore(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM timelines
+		WHERE tweet_created_at < $1 AND (user_id, tweet_id, tweet_created_at) IN (
+			SELECT user_id, tweet_id, tweet_created_at FROM timelines WHERE tweet_created_at < $1 LIMIT $2
+		)`
+	tag, err := r.db.Exec(ctx, query, cutoff, limit)
+	if err != nil {
+		return 0, fmt.Errorf("error trimming timelines: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *PostgresRepository) GetGrownTimelines(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.db.Query(ctx, "SELECT user_id FROM grown_timelines ORDER BY user_id LIMIT $1", limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// TrimTimelines clears the users' marks before trimming, in the same
+// transaction, so an entry fanned out meanwhile marks the timeline again.
+func (r *PostgresRepository) TrimTimelines(ctx context.Context, userIDs []string, keep int) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "DELETE FROM grown_timelines WHERE user_id = ANY($1)", userIDs); err != nil {
+		return 0, fmt.Errorf("error clearing grown timelines: %w", err)
+	}
+
+	query := `
+		DELETE FROM timelines tl USING (
+			SELECT user_id, tweet_id, tweet_created_at FROM (
+				SELECT user_id, tweet_id, tweet_created_at,
+					ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY tweet_created_at DESC, tweet_id DESC) AS position
+				FROM timelines WHERE user_id = ANY($1)
+			) ranked
+			WHERE position > $2
+		) old
+		WHERE tl.user_id = old.user_id AND tl.tweet_id = old.tweet_id AND tl.tweet_created_at = old.tweet_created_at`
+	tag, err := tx.Exec(ctx, query, userIDs, keep)
+	if err != nil {
+		return 0, fmt.Errorf("error trimming timelines: %w", err)
+	}
+	return int(tag.RowsAffected()), tx.Commit(ctx)
+}
+
+// ArchiveTweets locks a batch of old tweets, copies each one with its
+// entities into archived_tweets and deletes it, which cascades to its
+// timeline entries and entities. Its ID stays in tweet_ids, and with it the
+// revisions, poll, votes, bookmarks and notifications that reference it.
+// SKIP LOCKED lets concurrent runs and edits go on with other rows.
+func (r *PostgresRepository) ArchiveTweets(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM tweets WHERE created_at < $1
+		ORDER BY created_at, id LIMIT $2
+		FOR UPDATE SKIP LOCKED`, cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	tweets, err := r.queryTweets(ctx, "SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids FROM tweets t WHERE t.id = ANY($1) AND t.created_at < $2", ids, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	batch := &pgx.Batch{}
+	for _, tweet := range tweets {
+		data, err := json.Marshal(tweet)
+		if err != nil {
+			return 0, err
+		}
+		batch.Queue(`
+			INSERT INTO archived_tweets (id, user_id, created_at, data) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (id) DO NOTHING`, tweet.ID, tweet.UserID, tweet.CreatedAt, data)
+	}
+	batch.Queue("DELETE FROM tweets WHERE id = ANY($1) AND created_at < $2", ids, cutoff)
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return 0, fmt.Errorf("error in archive batch transaction: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// partitionLockTimeout bounds how long partition maintenance waits for the
+// locks it needs, so that it gives up instead of queueing every query on the
+// table behind it.
+const partitionLockTimeout = "SET LOCAL lock_timeout = '5s'"
+
+func (r *PostgresRepository) GetPartitions(ctx context.Context, table string) ([]domain.Partition, error) {
+	query := `
+		SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid
+		WHERE i.inhparent = $1::regclass`
+	rows, err := r.db.Query(ctx, query, table)
+	if err != nil {
+		return nil, err
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+
+	var partitions []domain.Partition
+	for _, name := range names {
+		if p, ok := domain.ParsePartition(table, name); ok {
+			partitions = append(partitions, p)
+		}
+	}
+	slices.SortFunc(partitions, func(a, b domain.Partition) int { return a.From.Compare(b.From) })
+	return partitions, nil
+}
+
+// partitionKeys are the columns the partitioned tables are partitioned on.
+var partitionKeys = map[string]string{
+	domain.TweetsTable:    "created_at",
+	domain.TimelinesTable: "tweet_created_at",
+}
+
+// CreatePartition builds the statement by hand, since DDL takes no
+// parameters: the names are quoted identifiers and the bounds are formatted
+// from times. Replicas creating partitions of the same table are serialized
+// on an advisory lock, since IF NOT EXISTS alone is not enough: two
+// concurrent CREATE TABLE statements can both pass the check and the second
+// one fails.
+//
+// PostgreSQL refuses to create a partition while the default partition holds
+// rows of its month. Timeline rows are then moved over: the default partition
+// is detached, the month created, its rows moved and the default attached
+// back, all in the same transaction. Tweet rows cannot be moved that way,
+// since the tables that point at them keep the default partition from being
+// detached, so CreatePartition fails with domain.ErrPartitionRowsInDefault.
+func (r *PostgresRepository) CreatePartition(ctx context.Context, partition domain.Partition) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, partitionLockTimeout); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "partitions:"+partition.Table); err != nil {
+		return fmt.Errorf("error locking partitions of %s: %w", partition.Table, err)
+	}
+
+	table := pgx.Identifier{partition.Table}.Sanitize()
+	name := pgx.Identifier{partition.Name()}.Sanitize()
+	defaultName := pgx.Identifier{partition.Table + "_default"}.Sanitize()
+	inMonth := fmt.Sprintf("%s >= $1 AND %s < $2", partitionKeys[partition.Table], partitionKeys[partition.Table])
+
+	var hasRows bool
+	hasRowsQuery := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE %s)", defaultName, inMonth)
+	if err := tx.QueryRow(ctx, hasRowsQuery, partition.From, partition.To).Scan(&hasRows); err != nil {
+		return err
+	}
+	if hasRows && partition.Table != domain.TimelinesTable {
+		return fmt.Errorf("error creating partition %s: %w", partition.Name(), domain.ErrPartitionRowsInDefault)
+	}
+
+	if hasRows {
+		if _, err := tx.Exec(ctx, fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", table, defaultName)); err != nil {
+			return fmt.Errorf("error detaching partition %s_default: %w", partition.Table, err)
+		}
+	}
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+		name, table, partition.From.Format(time.RFC3339), partition.To.Format(time.RFC3339))
+	if _, err := tx.Exec(ctx, query); err != nil {
+		return fmt.Errorf("error creating partition %s: %w", partition.Name(), err)
+	}
+	if hasRows {
+		moveQuery := fmt.Sprintf(`
+			WITH moved AS (DELETE FROM %s WHERE %s RETURNING *)
+			INSERT INTO %s SELECT * FROM moved`, defaultName, inMonth, name)
+		if _, err := tx.Exec(ctx, moveQuery, partition.From, partition.To); err != nil {
+			return fmt.Errorf("error moving rows into partition %s: %w", partition.Name(), err)
+		}
+		if _, err := tx.Exec(ctx, fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s DEFAULT", table, defaultName)); err != nil {
+			return fmt.Errorf("error attaching partition %s_default: %w", partition.Table, err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// DetachPartition checks for rows before detaching, since detaching a tweets
+// partition that timeline entries still point at fails on the foreign key,
+// and again once the detach holds the partition, in case a follow copied an
+// old tweet into it in between; the rollback then attaches it back. The
+// detached partition is moved to the detached schema.
+func (r *PostgresRepository) DetachPartition(ctx context.Context, partition domain.Partition, onlyIfEmpty bool) (bool, error) {
+	name := pgx.Identifier{partition.Name()}.Sanitize()
+	hasRowsQuery := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s)", name)
+
+	var hasRows bool
+	if onlyIfEmpty {
+		if err := r.db.QueryRow(ctx, hasRowsQuery).Scan(&hasRows); err != nil || hasRows {
+			return false, err
+		}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, partitionLockTimeout); err != nil {
+		return false, err
+	}
+	detachQuery := fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", pgx.Identifier{partition.Table}.Sanitize(), name)
+	if _, err := tx.Exec(ctx, detachQuery); err != nil {
+		return false, fmt.Errorf("error detaching partition %s: %w", partition.Name(), err)
+	}
+	if onlyIfEmpty {
+		if err := tx.QueryRow(ctx, hasRowsQuery).Scan(&hasRows); err != nil || hasRows {
+			return false, err
+		}
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s", name, detachedSchema)); err != nil {
+		return false, fmt.Errorf("error moving partition %s: %w", partition.Name(), err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// detachedSchema holds the detached partitions until they are dropped.
+const detachedSchema = "detached"
+
+func (r *PostgresRepository) DropPartition(ctx context.Context, partition domain.Partition) error {
+	name := pgx.Identifier{detachedSchema, partition.Name()}.Sanitize()
+	if _, err := r.db.Exec(ctx, "DROP TABLE IF EXISTS "+name); err != nil {
+		return fmt.Errorf("error dropping partition %s: %w", partition.Name(), err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) AddFilter(ctx context.Context, filter *domain.ContentFilter) error {
+	batch := &pgx.Batch{}
+
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	batch.Queue(userInsertQuery, filter.UserID)
+
+	filterInsertQuery := `
+		INSERT INTO content_filters (id, user_id, kind, value, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	batch.Queue(filterInsertQuery, filter.ID, filter.UserID, string(filter.Kind), filter.Value, filter.ExpiresAt, filter.CreatedAt)
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error inserting content filter: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) DeleteFilter(ctx context.Context, userID, filterID string) error {
+	_, err := r.db.Exec(ctx, "DELETE FROM content_filters WHERE user_id = $1 AND id = $2", userID, filterID)
+	return err
+}
+
+func (r *PostgresRepository) GetFilters(ctx context.Context, userID string) ([]domain.ContentFilter, error) {
+	query := `
+		SELECT id, user_id, kind, value, expires_at, created_at
+		FROM content_filters
+		WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY created_at`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.ContentFilter])
+}
+
+// GetMentions picks the newest mentions through
+// idx_tweet_mentions_user_created_at before joining them to their tweets. A
+// tweet that mentions the user twice has two rows, hence the DISTINCT ON.
+func (r *PostgresRepository) GetMentions(ctx context.Context, userID string, limit int) ([]domain.Tweet, error) {
+	query := `
+		SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids
+		FROM (
+			SELECT DISTINCT ON (tweet_created_at, tweet_id) tweet_id, tweet_created_at
+			FROM tweet_mentions WHERE user_id = $1
+			ORDER BY tweet_created_at DESC, tweet_id
+			LIMIT $2
+		) m
+		JOIN tweets t ON t.id = m.tweet_id AND t.created_at = m.tweet_created_at
+		ORDER BY t.created_at DESC`
+	return r.queryTweets(ctx, query, userID, limit)
+}
+
+func (r *PostgresRepository) GetHashtagTweets(ctx context.Context, tag string, limit int) ([]domain.Tweet, error) {
+	query := `
+		SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids
+		FROM tweets t
+		WHERE (t.id, t.created_at) IN (SELECT tweet_id, tweet_created_at FROM tweet_hashtags WHERE tag = $1)
+		ORDER BY t.created_at DESC LIMIT $2`
+	return r.queryTweets(ctx, query, tag, limit)
+}
+
+func (r *PostgresRepository) CountHashtags(ctx context.Context, since, until time.Time) (map[string]int, error) {
+	query := `
+		SELECT h.tag, COUNT(DISTINCT h.tweet_id) FROM tweet_hashtags h
+		JOIN tweet_ids i ON i.id = h.tweet_id
+		JOIN users u ON u.id = i.user_id
+		WHERE h.tweet_created_at >= $1 AND h.tweet_created_at < $2
+			AND NOT u.protected AND u.status = 'active'
+		GROUP BY h.tag`
+	rows, err := r.db.Query(ctx, query, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var tag string
+		var count int
+		if err := rows.Scan(&tag, &count); err != nil {
+			return nil, err
+		}
+		counts[tag] = count
+	}
+	return counts, rows.Err()
+}
+
+func (r *PostgresRepository) CountHashtagBuckets(ctx context.Context, since, until time.Time, bucketSize time.Duration) ([]domain.HashtagCount, error) {
+	query := `
+		SELECT date_bin($3, h.tweet_created_at, TIMESTAMPTZ 'epoch'), h.tag, COUNT(DISTINCT h.tweet_id)
+		FROM tweet_hashtags h
+		JOIN tweet_ids i ON i.id = h.tweet_id
+		JOIN users u ON u.id = i.user_id
+		WHERE h.tweet_created_at >= $1 AND h.tweet_created_at < $2
+			AND NOT u.protected AND u.status = 'active'
+		GROUP BY 1, 2`
+	rows, err := r.db.Query(ctx, query, since, until, bucketSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []domain.HashtagCount
+	for rows.Next() {
+		var count domain.HashtagCount
+		if err := rows.Scan(&count.Bucket, &count.Tag, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+func (r *PostgresRepository) AddNotifications(ctx context.Context, notifications []domain.Notification) error {
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO notifications (id, recipient_id, type, actor_id, tweet_id, tweet_created_at, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), (SELECT created_at FROM tweet_ids WHERE id = $5), $6)`
+	for _, n := range notifications {
+		batch.Queue(query, n.ID, n.RecipientID, string(n.Type), n.ActorID, n.TweetID, n.CreatedAt)
+	}
+
+	br := r.db.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error inserting notifications: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) GetNotifications(ctx context.Context, userID string, limit int) ([]domain.Notification, error) {
+	query := `
+		SELECT id, recipient_id, type, actor_id, COALESCE(tweet_id, ''), created_at, read_at IS NOT NULL
+		FROM notifications WHERE recipient_id = $1
+		ORDER BY created_at DESC LIMIT $2`
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.Notification])
+}
+
+func (r *PostgresRepository) MarkNotificationsRead(ctx context.Context, userID string, notificationIDs []string) error {
+	query := "UPDATE notifications SET read_at = NOW() WHERE recipient_id = $1 AND read_at IS NULL"
+	args := []any{userID}
+	if len(notificationIDs) > 0 {
+		query += " AND id = ANY($2)"
+		args = append(args, notificationIDs)
+	}
+	_, err := r.db.Exec(ctx, query, args...)
+	return err
+}
+
+func (r *PostgresRepository) CreateConversation(ctx context.Context, conversation *domain.Conversation) (*domain.Conversation, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	conversationQuery := `
+		INSERT INTO conversations (id, direct_key, created_at, last_message_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4)
+		ON CONFLICT (direct_key) DO NOTHING`
+	tag, err := tx.Exec(ctx, conversationQuery, conversation.ID, conversation.DirectKey(), conversation.CreatedAt, conversation.LastMessageAt)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting conversation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		var existingID string
+		if err := tx.QueryRow(ctx, "SELECT id FROM conversations WHERE direct_key = $1", conversation.DirectKey()).Scan(&existingID); err != nil {
+			return nil, fmt.Errorf("error loading existing conversation: %w", err)
+		}
+		return r.GetConversation(ctx, existingID)
+	}
+
+	batch := &pgx.Batch{}
+	userInsertQuery := "INSERT INTO users (id, created_at) VALUES ($1, NOW()) ON CONFLICT (id) DO NOTHING"
+	participantInsertQuery := `
+		INSERT INTO conversation_participants (conversation_id, user_id, last_read_at)
+		VALUES ($1, $2, $3)`
+	for _, p := range conversation.Participants {
+		batch.Queue(userInsertQuery, p.UserID)
+		batch.Queue(participantInsertQuery, conversation.ID, p.UserID, p.LastReadAt)
+	}
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return nil, fmt.Errorf("error inserting conversation participants: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+func (r *PostgresRepository) GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error) {
+	conversations, err := r.queryConversations(ctx, "SELECT id, created_at, last_message_at FROM conversations WHERE id = $1", conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(conversations) == 0 {
+		return nil, domain.ErrConversationNotFound
+	}
+	return &conversations[0], nil
+}
+
+func (r *PostgresRepository) GetConversations(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Conversation, error) {
+	query := `
+		SELECT c.id, c.created_at, c.last_message_at
+		FROM conversations c JOIN conversation_participants p ON p.conversation_id = c.id
+		WHERE p.user_id = $1`
+	args := []any{userID}
+	if cursor != nil {
+		query += " AND (c.last_message_at, c.id) < ($2, $3)"
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+	query += fmt.Sprintf(" ORDER BY c.last_message_at DESC, c.id DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+	return r.queryConversations(ctx, query, args...)
+}
+
+// queryConversations runs a query selecting (id, created_at, last_message_at)
+// from conversations and loads the participants of every returned one.
+func (r *PostgresRepository) queryConversations(ctx context.Context, query string, args ...any) ([]domain.Conversation, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	conversations, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Conversation, error) {
+		var c domain.Conversation
+		err := row.Scan(&c.ID, &c.CreatedAt, &c.LastMessageAt)
+		return c, err
+	})
+	if err != nil || len(conversations) == 0 {
+		return conversations, err
+	}
+
+	ids := make([]string, len(conversations))
+	byID := make(map[string]*domain.Conversation, len(conversations))
+	for i := range conversations {
+		ids[i] = conversations[i].ID
+		byID[conversations[i].ID] = &conversations[i]
+	}
+
+	participantRows, err := r.db.Query(ctx, `
+		SELECT conversation_id, user_id, last_read_at FROM conversation_participants
+		WHERE conversation_id = ANY($1)
+		ORDER BY user_id`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer participantRows.Close()
+
+	for participantRows.Next() {
+		var conversationID string
+		var p domain.Participant
+		if err := participantRows.Scan(&conversationID, &p.UserID, &p.LastReadAt); err != nil {
+			return nil, err
+		}
+		byID[conversationID].Participants = append(byID[conversationID].Participants, p)
+	}
+	return conversations, participantRows.Err()
+}
+
+func (r *PostgresRepository) AddMessage(ctx context.Context, message *domain.Message) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	batch.Queue(`
+		INSERT INTO messages (id, conversation_id, sender_id, text, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		message.ID, message.ConversationID, message.SenderID, message.Text, message.CreatedAt)
+	batch.Queue("UPDATE conversations SET last_message_at = $2 WHERE id = $1", message.ConversationID, message.CreatedAt)
+	batch.Queue(markReadQuery, message.ConversationID, message.SenderID, message.CreatedAt)
+
+	br := tx.SendBatch(ctx, batch)
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("error in message batch transaction: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresRepository) GetMessages(ctx context.Context, conversationID string, cursor *domain.Cursor, limit int) ([]domain.Message, error) {
+	query := `
+		SELECT id, conversation_id, sender_id, text, created_at
+		FROM messages WHERE conversation_id = $1`
+	args := []any{conversationID}
+	if cursor != nil {
+		query += " AND (created_at, id) < ($2, $3)"
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[domain.Message])
+}
+
+// markReadQuery moves a participant's read receipt forward, never backwards.
+const markReadQuery = `
+	UPDATE conversation_participants SET last_read_at = GREATEST(COALESCE(last_read_at, $3), $3)
+	WHERE conversation_id = $1 AND user_id = $2`
+
+func (r *PostgresRepository) MarkConversationRead(ctx context.Context, conversationID, userID string, readAt time.Time) error {
+	_, err := r.db.Exec(ctx, markReadQuery, conversationID, userID, readAt)
+	return err
+}
+
+// searchConfigs are the text search configurations tweets are indexed with;
+// they must match the ones used by the search_vector column in schema.sql.
+var searchConfigs = []string{"spanish", "english"}
+
+func (r *PostgresRepository) Search(ctx context.Context, query domain.SearchQuery, limit int) ([]domain.Tweet, error) {
+	var conditions []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.HasText() {
+		var textArgs []string
+		for _, term := range query.Terms {
+			textArgs = append(textArgs, "plainto_tsquery('%[1]s', "+arg(term)+")")
+		}
+		for _, phrase := range query.Phrases {
+			textArgs = append(textArgs, "phraseto_tsquery('%[1]s', "+arg(phrase)+")")
+		}
+
+		perConfig := make([]string, len(searchConfigs))
+		for i, cfg := range searchConfigs {
+			perConfig[i] = "(" + fmt.Sprintf(strings.Join(textArgs, " && "), cfg) + ")"
+		}
+		conditions = append(conditions, "t.search_vector @@ ("+strings.Join(perConfig, " || ")+")")
+	}
+	if query.FromUserID != "" {
+		conditions = append(conditions, "t.user_id = "+arg(query.FromUserID))
+	}
+	if !query.Since.IsZero() {
+		conditions = append(conditions, "t.created_at >= "+arg(query.Since))
+	}
+	if !query.Until.IsZero() {
+		conditions = append(conditions, "t.created_at < "+arg(query.Until))
+	}
+	if query.Cursor != nil {
+		createdAt := arg(query.Cursor.CreatedAt)
+		conditions = append(conditions, "t.created_at <= "+createdAt, fmt.Sprintf("(t.created_at, t.id) < (%s, %s)", createdAt, arg(query.Cursor.ID)))
+	}
+
+	sql := `
+		SELECT t.id, t.user_id, t.text, t.created_at, t.edited_at, t.media_ids
+		FROM tweets t
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY t.created_at DESC, t.id DESC LIMIT ` + arg(limit)
+	return r.queryTweets(ctx, sql, args...)
+}
+
+// queryTweets runs a query selecting (id, user_id, text, created_at) from
+// tweets and loads the entities of every returned tweet.
+func (r *PostgresRepository) queryTweets(ctx context.Context, query string, args ...any) ([]domain.Tweet, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tweets, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Tweet, error) {
+		var t domain.Tweet
+		err := row.Scan(&t.ID, &t.UserID, &t.Text, &t.CreatedAt, &t.EditedAt, &t.MediaIDs)
+		return t, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadEntities(ctx, tweets); err != nil {
+		return nil, err
+	}
+	return tweets, nil
+}
+
+func (r *PostgresRepository) loadEntities(ctx context.Context, tweets []domain.Tweet) error {
+	if len(tweets) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Tweet, len(tweets))
+	ids := make([]string, len(tweets))
+	for i := range tweets {
+		byID[tweets[i].ID] = &tweets[i]
+		ids[i] = tweets[i].ID
+	}
+
+	if err := r.loadMentions(ctx, ids, byID); err != nil {
+		return err
+	}
+	if err := r.loadHashtags(ctx, ids, byID); err != nil {
+		return err
+	}
+	if err := r.loadLinks(ctx, ids, byID); err != nil {
+		return err
+	}
+	return r.loadPolls(ctx, ids, byID)
+}
+
+func (r *PostgresRepository) loadPolls(ctx context.Context, ids []string, byID map[string]*domain.Tweet) error {
+	rows, err := r.db.Query(ctx, "SELECT tweet_id, options, ends_at FROM polls WHERE tweet_id = ANY($1)", ids)
+	if err != nil {
+		return fmt.Errorf("error loading polls: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweetID string
+		var p domain.Poll
+		if err := rows.Scan(&tweetID, &p.Options, &p.EndsAt); err != nil {
+			return fmt.Errorf("error scanning poll: %w", err)
+		}
+		byID[tweetID].Poll = &p
+	}
+	return rows.Err()
+}
+
+func (r *PostgresRepository) loadMentions(ctx context.Context, ids []string, byID map[string]*domain.Tweet) error {
+	query := `
+		SELECT tweet_id, user_id, byte_start, byte_end, char_start, char_end
+		FROM tweet_mentions WHERE tweet_id = ANY($1) ORDER BY byte_start`
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("error loading tweet mentions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweetID string
+		var m domain.Mention
+		if err := rows.Scan(&tweetID, &m.UserID, &m.ByteStart, &m.ByteEnd, &m.CharStart, &m.CharEnd); err != nil {
+			return fmt.Errorf("error scanning tweet mention: %w", err)
+		}
+		m.Handle = m.UserID
+		byID[tweetID].Mentions = append(byID[tweetID].Mentions, m)
+	}
+	return rows.Err()
+}
+
+func (r *PostgresRepository) loadHashtags(ctx context.Context, ids []string, byID map[string]*domain.Tweet) error {
+	query := `
+		SELECT tweet_id, tag, byte_start, byte_end, char_start, char_end
+		FROM tweet_hashtags WHERE tweet_id = ANY($1) ORDER BY byte_start`
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("error loading tweet hashtags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweetID string
+		var h domain.Hashtag
+		if err := rows.Scan(&tweetID, &h.Tag, &h.ByteStart, &h.ByteEnd, &h.CharStart, &h.CharEnd); err != nil {
+			return fmt.Errorf("error scanning tweet hashtag: %w", err)
+		}
+		byID[tweetID].Hashtags = append(byID[tweetID].Hashtags, h)
+	}
+	return rows.Err()
+}
+
+// loadLinks attaches each link's preview once it has been unfurled into a
+// card.
+func (r *PostgresRepository) loadLinks(ctx context.Context, ids []string, byID map[string]*domain.Tweet) error {
+	query := `
+		SELECT l.tweet_id, l.url, l.byte_start, l.byte_end, l.char_start, l.char_end,
+			p.title, p.description, p.image_url, p.site_name, p.fetched_at
+		FROM tweet_links l
+		LEFT JOIN link_previews p ON p.url = l.url AND p.title <> ''
+		WHERE l.tweet_id = ANY($1) ORDER BY l.byte_start`
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return fmt.Errorf("error loading tweet links: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tweetID string
+		var l domain.Link
+		var title, description, imageURL, siteName *string
+		var fetchedAt *time.Time
+		if err := rows.Scan(&tweetID, &l.URL, &l.ByteStart, &l.ByteEnd, &l.CharStart, &l.CharEnd,
+			&title, &description, &imageURL, &siteName, &fetchedAt); err != nil {
+			return fmt.Errorf("error scanning tweet link: %w", err)
+		}
+		if title != nil {
+			l.Preview = &domain.LinkPreview{
+				URL:         l.URL,
+				Title:       *title,
+				Description: *description,
+				ImageURL:    *imageURL,
+				SiteName:    *siteName,
+				FetchedAt:   *fetchedAt,
+			}
+		}
+		byID[tweetID].Links = append(byID[tweetID].Links, l)
+	}
+	return rows.Err()
 }
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+)
+
+// searchIndex is an in-memory inverted index mapping each token to the
+// positions where it appears in every tweet, which is enough to answer both
+// term and phrase queries.
+type searchIndex struct {
+	postings map[string]map[string][]int
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{postings: make(map[string]map[string][]int)}
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+}
+
+func (idx *searchIndex) add(tweet *domain.Tweet) {
+	for pos, token := range tokenize(tweet.Text) {
+		docs, ok := idx.postings[token]
+		if !ok {
+			docs = make(map[string][]int)
+			idx.postings[token] = docs
+		}
+		docs[tweet.ID] = append(docs[tweet.ID], pos)
+	}
+}
+
+func (idx *searchIndex) remove(tweet *domain.Tweet) {
+	for _, token := range tokenize(tweet.Text) {
+		delete(idx.postings[token], tweet.ID)
+		if len(idx.postings[token]) == 0 {
+			delete(idx.postings, token)
+		}
+	}
+}
+
+// match returns the IDs of the tweets containing every term and every phrase.
+func (idx *searchIndex) match(terms, phrases []string) map[string]bool {
+	var result map[string]bool
+	intersect := func(ids map[string]bool) {
+		if result == nil {
+			result = ids
+			return
+		}
+		for id := range result {
+			if !ids[id] {
+				delete(result, id)
+			}
+		}
+	}
+
+	for _, term := range terms {
+		for _, token := range tokenize(term) {
+			ids := make(map[string]bool)
+			for id := range idx.postings[token] {
+				ids[id] = true
+			}
+			intersect(ids)
+		}
+	}
+	for _, phrase := range phrases {
+		intersect(idx.matchPhrase(tokenize(phrase)))
+	}
+
+	if result == nil {
+		return map[string]bool{}
+	}
+	return result
+}
+
+func (idx *searchIndex) matchPhrase(tokens []string) map[string]bool {
+	ids := make(map[string]bool)
+	if len(tokens) == 0 {
+		return ids
+	}
+
+	for id, starts := range idx.postings[tokens[0]] {
+		for _, start := range starts {
+			if idx.phraseAt(id, tokens, start) {
+				ids[id] = true
+				break
+			}
+		}
+	}
+	return ids
+}
+
+func (idx *searchIndex) phraseAt(id string, tokens []string, start int) bool {
+	for offset, token := range tokens[1:] {
+		found := false
+		for _, pos := range idx.postings[token][id] {
+			if pos == start+offset+1 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+// StreamingRepository publishes a timeline event for every follower, plus one
+// on the tweet's own thread, once a tweet has been fanned out, so connected
+// clients get it without polling.
+type StreamingRepository struct {
+	broker        ports.EventBroker
+	nextTweetRepo ports.TweetRepository
+	nextUserRepo  ports.UserRepository
+	logger        *slog.Logger
+}
+
+func NewStreamingRepository(
+	broker ports.EventBroker,
+	tweetRepo ports.TweetRepository,
+	userRepo ports.UserRepository,
+	logger *slog.Logger,
+) *StreamingRepository {
+	return &StreamingRepository{
+		broker:        broker,
+		nextTweetRepo: tweetRepo,
+		nextUserRepo:  userRepo,
+		logger:        logger.With("component", "StreamingRepository"),
+	}
+}
+
+func (r *StreamingRepository) PublishTx(ctx context.Context, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.PublishTx(ctx, tweet); err != nil {
+		return err
+	}
+
+	r.publish(ctx, domain.EventTweet, tweet)
+	return nil
+}
+
+func (r *StreamingRepository) PublishDraftTx(ctx context.Context, draft *domain.Draft, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.PublishDraftTx(ctx, draft, tweet); err != nil {
+		return err
+	}
+
+	r.publish(ctx, domain.EventTweet, tweet)
+	return nil
+}
+
+func (r *StreamingRepository) GetTweet(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	return r.nextTweetRepo.GetTweet(ctx, tweetID)
+}
+
+// DeleteTx tells the same audience as PublishTx that the tweet is gone, so
+// live clients can drop it.
+func (r *StreamingRepository) DeleteTx(ctx context.Context, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.DeleteTx(ctx, tweet); err != nil {
+		return err
+	}
+
+	r.publish(ctx, domain.EventTweetDeleted, tweet)
+	return nil
+}
+
+// EditTx sends the edited tweet to the same audience as PublishTx, so live
+// clients can replace the text they show.
+func (r *StreamingRepository) EditTx(ctx context.Context, before, after *domain.Tweet) error {
+	if err := r.nextTweetRepo.EditTx(ctx, before, after); err != nil {
+		return err
+	}
+
+	r.publish(ctx, domain.EventTweetEdited, after)
+	return nil
+}
+
+func (r *StreamingRepository) GetTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	return r.nextTweetRepo.GetTweetRevisions(ctx, tweetID)
+}
+
+// publish sends an event about the tweet to its thread and to the timeline of
+// every follower of the author.
+func (r *StreamingRepository) publish(ctx context.Context, eventType domain.EventType, tweet *domain.Tweet) {
+	followers, err := r.nextUserRepo.GetFollowers(ctx, tweet.UserID)
+	if err != nil {
+		r.logger.Error("Failed to get followers for streaming", "error", err, "userID", tweet.UserID)
+		return
+	}
+
+	events := make([]domain.Event, 0, len(followers)+1)
+	events = append(events, domain.Event{Topic: domain.ThreadTopic(tweet.ID), Type: eventType, Tweet: tweet})
+	for _, followerID := range followers {
+		events = append(events, domain.Event{Topic: domain.TimelineTopic(followerID), Type: eventType, Tweet: tweet})
+	}
+	if err := r.broker.Publish(ctx, events...); err != nil {
+		r.logger.Error("Failed to publish timeline events", "error", err, "tweetID", tweet.ID)
+	}
+}
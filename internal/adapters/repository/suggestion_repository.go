@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// suggestionsTTL bounds how long suggestions survive if the refresh job
+// stops running; a working job overwrites them long before.
+const suggestionsTTL = 24 * time.Hour
+
+// SuggestionCachingRepository stores the precomputed "who to follow"
+// suggestions in Redis and delegates the graph queries to the next
+// repository.
+type SuggestionCachingRepository struct {
+	redisClient        *redis.Client
+	nextSuggestionRepo ports.SuggestionRepository
+	logger             *slog.Logger
+}
+
+func NewSuggestionCachingRepository(client *redis.Client, suggestionRepo ports.SuggestionRepository, logger *slog.Logger) *SuggestionCachingRepository {
+	return &SuggestionCachingRepository{
+		redisClient:        client,
+		nextSuggestionRepo: suggestionRepo,
+		logger:             logger.With("component", "SuggestionCachingRepository"),
+	}
+}
+
+func suggestionsCacheKey(userID string) string {
+	return "suggestions:" + userID
+}
+
+const suggestionsRefreshLeaseKey = "suggestions:refresh-lease"
+
+func (r *SuggestionCachingRepository) GetUserIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	return r.nextSuggestionRepo.GetUserIDs(ctx, afterID, limit)
+}
+
+func (r *SuggestionCachingRepository) GetSuggestionCandidates(ctx context.Context, userID string, limit int) ([]domain.Suggestion, error) {
+	return r.nextSuggestionRepo.GetSuggestionCandidates(ctx, userID, limit)
+}
+
+func (r *SuggestionCachingRepository) SaveSuggestions(ctx context.Context, userID string, suggestions []domain.Suggestion) error {
+	data, err := json.Marshal(suggestions)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Set(ctx, suggestionsCacheKey(userID), data, suggestionsTTL).Err()
+}
+
+// AcquireRefreshLease relies on SET NX PX: only one replica creates the key,
+// and it expires on its own, so a replica that dies holding it does not stop
+// the refresh for longer than ttl.
+func (r *SuggestionCachingRepository) AcquireRefreshLease(ctx context.Context, ttl time.Duration) (bool, error) {
+	return r.redisClient.SetNX(ctx, suggestionsRefreshLeaseKey, uuid.NewString(), ttl).Result()
+}
+
+func (r *SuggestionCachingRepository) GetSuggestions(ctx context.Context, userID string) ([]domain.Suggestion, bool, error) {
+	val, err := r.redisClient.Get(ctx, suggestionsCacheKey(userID)).Result()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var suggestions []domain.Suggestion
+	if err := json.Unmarshal([]byte(val), &suggestions); err != nil {
+		r.logger.Warn("Discarding unreadable cached suggestions", "error", err, "userID", userID)
+		return nil, false, nil
+	}
+	return suggestions, true, nil
+}
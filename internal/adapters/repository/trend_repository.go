@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	hashtagBucketSize      = 5 * time.Minute
+	hashtagRebuildInterval = 15 * time.Minute
+	hashtagRebuildLeaseKey = "hashtags:rebuild-lease"
+	trendsKey              = "trends"
+)
+
+// TrendingRepository keeps per-bucket hashtag counters in Redis sorted sets so
+// that trend computation does not have to scan tweet_hashtags on every run.
+// Windows older than the counters' retention are delegated to the next
+// HashtagRepository. Buckets are kept one bucket longer than the retention,
+// so a window of exactly the retention, whose first bucket starts before it,
+// is still counted from Redis. Only the hashtags of public, active accounts
+// are counted, since the trends are public. The counters follow publishes,
+// edits and deletes as they happen, and RebuildHashtagCounts recomputes them
+// now and then for whatever those updates miss.
+type TrendingRepository struct {
+	redisClient     *redis.Client
+	nextTweetRepo   ports.TweetRepository
+	nextHashtagRepo ports.HashtagRepository
+	nextUserRepo    ports.UserRepository
+	logger          *slog.Logger
+	retention       time.Duration
+	now             func() time.Time
+}
+
+func NewTrendingRepository(
+	client *redis.Client,
+	tweetRepo ports.TweetRepository,
+	hashtagRepo ports.HashtagRepository,
+	userRepo ports.UserRepository,
+	retention time.Duration,
+	logger *slog.Logger,
+) *TrendingRepository {
+	return &TrendingRepository{
+		redisClient:     client,
+		nextTweetRepo:   tweetRepo,
+		nextHashtagRepo: hashtagRepo,
+		nextUserRepo:    userRepo,
+		logger:          logger.With("component", "TrendingRepository"),
+		retention:       retention,
+		now:             time.Now,
+	}
+}
+
+func hashtagBucketKey(bucket time.Time) string {
+	return "hashtags:counts:" + strconv.FormatInt(bucket.Unix(), 10)
+}
+
+// bucketRetained reports whether the counters of the bucket holding t are
+// still kept at now.
+func bucketRetained(t, now time.Time, retention time.Duration) bool {
+	return t.Truncate(hashtagBucketSize).Add(retention + hashtagBucketSize).After(now)
+}
+
+// bucketExpiry is when Redis drops the bucket starting at bucket. It is one
+// bucket after bucketRetained turns false, so a bucket still retained when a
+// read starts is not dropped in the middle of it.
+func bucketExpiry(bucket time.Time, retention time.Duration) time.Time {
+	return bucket.Add(retention + 2*hashtagBucketSize)
+}
+
+// rebuildRange returns the range of the buckets RebuildHashtagCounts
+// recomputes at now: the retained ones but the current bucket and the one
+// before it.
+func rebuildRange(now time.Time, retention time.Duration) (since, until time.Time) {
+	since = now.Add(-retention).Truncate(hashtagBucketSize)
+	until = now.Truncate(hashtagBucketSize).Add(-hashtagBucketSize)
+	return since, until
+}
+
+// hashtagBuckets returns the buckets that cover [since, until).
+func hashtagBuckets(since, until time.Time) []time.Time {
+	var buckets []time.Time
+	for bucket := since.Truncate(hashtagBucketSize); bucket.Before(until); bucket = bucket.Add(hashtagBucketSize) {
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+func (r *TrendingRepository) PublishTx(ctx context.Context, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.PublishTx(ctx, tweet); err != nil {
+		return err
+	}
+
+	tags := tweet.HashtagTags()
+	if len(tags) == 0 {
+		return nil
+	}
+
+	r.incrementHashtags(ctx, tweet, tags, 1)
+	return nil
+}
+
+func (r *TrendingRepository) PublishDraftTx(ctx context.Context, draft *domain.Draft, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.PublishDraftTx(ctx, draft, tweet); err != nil {
+		return err
+	}
+
+	if tags := tweet.HashtagTags(); len(tags) > 0 {
+		r.incrementHashtags(ctx, tweet, tags, 1)
+	}
+	return nil
+}
+
+func (r *TrendingRepository) GetTweet(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	return r.nextTweetRepo.GetTweet(ctx, tweetID)
+}
+
+// DeleteTx takes the tweet's hashtags back out of its bucket, if the bucket
+// is still retained, so deleted tweets stop pushing trends.
+func (r *TrendingRepository) DeleteTx(ctx context.Context, tweet *domain.Tweet) error {
+	if err := r.nextTweetRepo.DeleteTx(ctx, tweet); err != nil {
+		return err
+	}
+
+	tags := tweet.HashtagTags()
+	if len(tags) == 0 || !bucketRetained(tweet.CreatedAt, r.now(), r.retention) {
+		return nil
+	}
+
+	r.incrementHashtags(ctx, tweet, tags, -1)
+	return nil
+}
+
+// EditTx moves the counters from the hashtags the edit removed to the ones it
+// added. Both stay in the bucket of the original publication time.
+func (r *TrendingRepository) EditTx(ctx context.Context, before, after *domain.Tweet) error {
+	if err := r.nextTweetRepo.EditTx(ctx, before, after); err != nil {
+		return err
+	}
+	if !bucketRetained(before.CreatedAt, r.now(), r.retention) {
+		return nil
+	}
+
+	beforeTags, afterTags := before.HashtagTags(), after.HashtagTags()
+	var removed, added []string
+	for _, tag := range beforeTags {
+		if !slices.Contains(afterTags, tag) {
+			removed = append(removed, tag)
+		}
+	}
+	for _, tag := range afterTags {
+		if !slices.Contains(beforeTags, tag) {
+			added = append(added, tag)
+		}
+	}
+	if len(removed) > 0 {
+		r.incrementHashtags(ctx, before, removed, -1)
+	}
+	if len(added) > 0 {
+		r.incrementHashtags(ctx, after, added, 1)
+	}
+	return nil
+}
+
+func (r *TrendingRepository) GetTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	return r.nextTweetRepo.GetTweetRevisions(ctx, tweetID)
+}
+
+func (r *TrendingRepository) incrementHashtags(ctx context.Context, tweet *domain.Tweet, tags []string, delta float64) {
+	if !r.publicAuthor(ctx, tweet) {
+		return
+	}
+
+	bucket := tweet.CreatedAt.Truncate(hashtagBucketSize)
+	key := hashtagBucketKey(bucket)
+	pipe := r.redisClient.Pipeline()
+	for _, tag := range tags {
+		pipe.ZIncrBy(ctx, key, delta, tag)
+	}
+	pipe.ExpireAt(ctx, key, bucketExpiry(bucket, r.retention))
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logger.Error("Failed to update hashtag counters", "error", err, "tweetID", tweet.ID)
+	}
+}
+
+// publicAuthor reports whether the tweet's author is a public, active
+// account. A failed lookup skips the tweet, like a failed counter update.
+func (r *TrendingRepository) publicAuthor(ctx context.Context, tweet *domain.Tweet) bool {
+	users, err := r.nextUserRepo.GetUsers(ctx, []string{tweet.UserID})
+	if err != nil {
+		r.logger.Error("Failed to read the author of a tweet with hashtags", "error", err, "tweetID", tweet.ID)
+		return false
+	}
+	return len(users) > 0 && !users[0].Protected && users[0].Active()
+}
+
+// RebuildHashtagCounts replaces the retained buckets with their counts in
+// the next HashtagRepository, at most once per hashtagRebuildInterval across
+// replicas. That takes out the tweets archived or erased with their author,
+// those of authors who became protected or inactive since publishing, and
+// fixes the updates lost to a Redis error. The current bucket and the one
+// before it are left to the live updates, since a tweet published while the
+// counts are read would otherwise be lost or counted twice.
+func (r *TrendingRepository) RebuildHashtagCounts(ctx context.Context) error {
+	acquired, err := r.redisClient.SetNX(ctx, hashtagRebuildLeaseKey, uuid.NewString(), hashtagRebuildInterval).Result()
+	if err != nil || !acquired {
+		return err
+	}
+
+	since, until := rebuildRange(r.now(), r.retention)
+	counts, err := r.nextHashtagRepo.CountHashtagBuckets(ctx, since, until, hashtagBucketSize)
+	if err != nil {
+		return err
+	}
+
+	members := make(map[string][]redis.Z)
+	for _, c := range counts {
+		key := hashtagBucketKey(c.Bucket)
+		members[key] = append(members[key], redis.Z{Score: float64(c.Count), Member: c.Tag})
+	}
+	pipe := r.redisClient.TxPipeline()
+	for _, bucket := range hashtagBuckets(since, until) {
+		key := hashtagBucketKey(bucket)
+		pipe.Del(ctx, key)
+		if len(members[key]) > 0 {
+			pipe.ZAdd(ctx, key, members[key]...)
+			pipe.ExpireAt(ctx, key, bucketExpiry(bucket, r.retention))
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	r.logger.Info("Rebuilt hashtag counters", "buckets", len(hashtagBuckets(since, until)))
+	return nil
+}
+
+func (r *TrendingRepository) GetHashtagTweets(ctx context.Context, tag string, limit int) ([]domain.Tweet, error) {
+	return r.nextHashtagRepo.GetHashtagTweets(ctx, tag, limit)
+}
+
+func (r *TrendingRepository) CountHashtagBuckets(ctx context.Context, since, until time.Time, bucketSize time.Duration) ([]domain.HashtagCount, error) {
+	return r.nextHashtagRepo.CountHashtagBuckets(ctx, since, until, bucketSize)
+}
+
+func (r *TrendingRepository) CountHashtags(ctx context.Context, since, until time.Time) (map[string]int, error) {
+	if !bucketRetained(since, r.now(), r.retention) {
+		return r.nextHashtagRepo.CountHashtags(ctx, since, until)
+	}
+
+	pipe := r.redisClient.Pipeline()
+	var cmds []*redis.ZSliceCmd
+	for _, bucket := range hashtagBuckets(since, until) {
+		cmds = append(cmds, pipe.ZRangeWithScores(ctx, hashtagBucketKey(bucket), 0, -1))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		r.logger.Warn("Redis error reading hashtag counters, falling back", "error", err)
+		return r.nextHashtagRepo.CountHashtags(ctx, since, until)
+	}
+
+	counts := make(map[string]int)
+	for _, cmd := range cmds {
+		for _, z := range cmd.Val() {
+			counts[z.Member.(string)] += int(z.Score)
+		}
+	}
+	return counts, nil
+}
+
+func (r *TrendingRepository) SaveTrends(ctx context.Context, trends []domain.Trend) error {
+	data, err := json.Marshal(trends)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Set(ctx, trendsKey, data, 0).Err()
+}
+
+func (r *TrendingRepository) GetTrends(ctx context.Context) ([]domain.Trend, error) {
+	val, err := r.redisClient.Get(ctx, trendsKey).Result()
+	if err == redis.Nil {
+		return []domain.Trend{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var trends []domain.Trend
+	if err := json.Unmarshal([]byte(val), &trends); err != nil {
+		return nil, err
+	}
+	return trends, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketRetained(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 2, 30, 0, time.UTC)
+	retention := 25 * time.Hour
+
+	t.Run("Success: should keep serving a window of exactly the retention computed a moment ago", func(t *testing.T) {
+		since := now.Add(-retention - time.Second)
+
+		assert.True(t, bucketRetained(since, now, retention))
+	})
+
+	t.Run("Success: should not serve buckets that started a bucket before the retention", func(t *testing.T) {
+		since := now.Add(-retention - hashtagBucketSize)
+
+		assert.False(t, bucketRetained(since, now, retention))
+	})
+
+	t.Run("Success: should expire buckets only after they stop being retained", func(t *testing.T) {
+		bucket := now.Add(-retention).Truncate(hashtagBucketSize)
+
+		assert.True(t, bucketRetained(bucket, now, retention))
+		assert.True(t, bucketExpiry(bucket, retention).After(bucket.Add(retention+hashtagBucketSize)))
+	})
+}
+
+func TestHashtagBuckets(t *testing.T) {
+	t.Run("Success: should cover the window with whole buckets", func(t *testing.T) {
+		since := time.Date(2025, 6, 1, 12, 3, 0, 0, time.UTC)
+		until := time.Date(2025, 6, 1, 12, 12, 0, 0, time.UTC)
+
+		buckets := hashtagBuckets(since, until)
+
+		assert.Equal(t, []time.Time{
+			time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+			time.Date(2025, 6, 1, 12, 5, 0, 0, time.UTC),
+			time.Date(2025, 6, 1, 12, 10, 0, 0, time.UTC),
+		}, buckets)
+		assert.Equal(t, "hashtags:counts:1748779200", hashtagBucketKey(buckets[0]))
+	})
+}
+
+func TestRebuildRange(t *testing.T) {
+	t.Run("Success: should rebuild the retained buckets but the last two", func(t *testing.T) {
+		now := time.Date(2025, 6, 1, 12, 12, 0, 0, time.UTC)
+		retention := time.Hour
+
+		since, until := rebuildRange(now, retention)
+
+		assert.Equal(t, time.Date(2025, 6, 1, 11, 10, 0, 0, time.UTC), since)
+		assert.Equal(t, time.Date(2025, 6, 1, 12, 5, 0, 0, time.UTC), until)
+		assert.True(t, bucketRetained(since, now, retention))
+		assert.False(t, bucketRetained(since.Add(-hashtagBucketSize), now, retention))
+	})
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Bookmark is a tweet a user saved privately.
+type Bookmark struct {
+	Tweet        Tweet
+	BookmarkedAt time.Time
+}
+
+// CursorAfterBookmark pages bookmarks by when they were saved rather than by
+// when the tweet was published.
+func CursorAfterBookmark(b Bookmark) *Cursor {
+	return &Cursor{CreatedAt: b.BookmarkedAt, ID: b.Tweet.ID}
+}
+
+// NewBookmarkPage builds a page of bookmarked tweets out of up to limit+1
+// bookmarks.
+func NewBookmarkPage(bookmarks []Bookmark, limit int) TweetPage {
+	bookmarks, next := paginate(bookmarks, limit, CursorAfterBookmark)
+	tweets := make([]Tweet, len(bookmarks))
+	for i, b := range bookmarks {
+		tweets[i] = b.Tweet
+	}
+	return TweetPage{Tweets: tweets, NextCursor: next}
+}
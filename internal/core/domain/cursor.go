@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor points at the last item of a page ordered by (CreatedAt, ID)
+// descending; the next page starts right after it.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+func CursorAfter(t Tweet) *Cursor {
+	return &Cursor{CreatedAt: t.CreatedAt, ID: t.ID}
+}
+
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by Encode. An empty string means
+// "first page" and returns a nil cursor.
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	nanos, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{CreatedAt: time.Unix(0, n).UTC(), ID: id}, nil
+}
+
+// Before reports whether a tweet sorts after the cursor, i.e. belongs to the
+// next page.
+func (c *Cursor) Before(t Tweet) bool {
+	return c.BeforeItem(t.CreatedAt, t.ID)
+}
+
+// BeforeItem is Before for any item ordered by (createdAt, id) descending.
+func (c *Cursor) BeforeItem(createdAt time.Time, id string) bool {
+	if c == nil {
+		return true
+	}
+	if createdAt.Equal(c.CreatedAt) {
+		return id < c.ID
+	}
+	return createdAt.Before(c.CreatedAt)
+}
+
+type TweetPage struct {
+	Tweets     []Tweet
+	NextCursor string
+}
+
+// NewTweetPage builds a page out of up to limit+1 tweets: the extra tweet, if
+// present, only signals that another page exists.
+func NewTweetPage(tweets []Tweet, limit int) TweetPage {
+	tweets, next := paginate(tweets, limit, CursorAfter)
+	return TweetPage{Tweets: tweets, NextCursor: next}
+}
+
+// paginate trims up to limit+1 items to limit and returns the cursor of the
+// next page, or "" when the extra item is missing.
+func paginate[T any](items []T, limit int, cursorAfter func(T) *Cursor) ([]T, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	return items, cursorAfter(items[limit-1]).Encode()
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxDraftLength is deliberately above MaxTweetLength: drafts may run long
+// while they are being written and are only held to the tweet rules when
+// published.
+const MaxDraftLength = 2000
+
+var (
+	ErrDraftTooLong  = errors.New("draft exceeds 2000 characters")
+	ErrDraftNotFound = errors.New("draft not found")
+)
+
+// Draft is an unpublished tweet saved server-side so it follows the user
+// across devices.
+type Draft struct {
+	ID        string
+	UserID    string
+	Text      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewDraft(userID, text string, now time.Time) (*Draft, error) {
+	draft := &Draft{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		CreatedAt: now,
+	}
+	if err := draft.Update(text, now); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (d *Draft) Update(text string, now time.Time) error {
+	if len(text) > MaxDraftLength {
+		return ErrDraftTooLong
+	}
+	d.Text = text
+	d.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Span struct {
+	ByteStart int
+	ByteEnd   int
+	CharStart int
+	CharEnd   int
+}
+
+type Mention struct {
+	UserID string
+	Handle string
+	Span
+}
+
+type Hashtag struct {
+	Tag string
+	Span
+}
+
+// Link is a URL written in a tweet. Preview stays nil until the page has been
+// unfurled into a card.
+type Link struct {
+	URL     string
+	Preview *LinkPreview
+	Span
+}
+
+func isHandleRune(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// scanEntities finds every token introduced by sigil and returns its body
+// together with the span covering the sigil and the body.
+func scanEntities(text string, sigil rune, isBodyRune func(rune) bool) ([]string, []Span) {
+	var bodies []string
+	var spans []Span
+
+	prev := rune(-1)
+	charIdx := 0
+	for byteIdx := 0; byteIdx < len(text); {
+		r, size := utf8.DecodeRuneInString(text[byteIdx:])
+		if r != sigil || (prev != -1 && (isWordRune(prev) || prev == sigil)) {
+			prev = r
+			byteIdx += size
+			charIdx++
+			continue
+		}
+
+		end := byteIdx + size
+		endChar := charIdx + 1
+		for end < len(text) {
+			next, nextSize := utf8.DecodeRuneInString(text[end:])
+			if !isBodyRune(next) {
+				break
+			}
+			end += nextSize
+			endChar++
+		}
+
+		if endChar-charIdx > 1 {
+			bodies = append(bodies, text[byteIdx+size:end])
+			spans = append(spans, Span{ByteStart: byteIdx, ByteEnd: end, CharStart: charIdx, CharEnd: endChar})
+			prev, _ = utf8.DecodeLastRuneInString(text[:end])
+			byteIdx = end
+			charIdx = endChar
+			continue
+		}
+
+		prev = r
+		byteIdx += size
+		charIdx++
+	}
+	return bodies, spans
+}
+
+func ParseMentions(text string) []Mention {
+	handles, spans := scanEntities(text, '@', isHandleRune)
+	mentions := make([]Mention, 0, len(handles))
+	for i, handle := range handles {
+		mentions = append(mentions, Mention{Handle: handle, Span: spans[i]})
+	}
+	return mentions
+}
+
+func ParseHashtags(text string) []Hashtag {
+	tags, spans := scanEntities(text, '#', isWordRune)
+	hashtags := make([]Hashtag, 0, len(tags))
+	for i, tag := range tags {
+		if strings.IndexFunc(tag, unicode.IsLetter) == -1 {
+			continue
+		}
+		hashtags = append(hashtags, Hashtag{Tag: NormalizeHashtag(tag), Span: spans[i]})
+	}
+	return hashtags
+}
+
+// NormalizeHashtag returns the canonical form used to index a hashtag, so
+// that "#Go", "#GO" and "go" all refer to the same topic.
+func NormalizeHashtag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(tag, "#"))
+}
+
+// ParseLinks finds the http and https URLs in text. Trailing punctuation and
+// unbalanced closing brackets are left out, so "(see https://go.dev)." links
+// to https://go.dev.
+func ParseLinks(text string) []Link {
+	var links []Link
+	lower := strings.ToLower(text)
+	for byteIdx := 0; byteIdx < len(text); {
+		start := strings.Index(lower[byteIdx:], "http")
+		if start == -1 {
+			break
+		}
+		start += byteIdx
+		if !strings.HasPrefix(lower[start:], "http://") && !strings.HasPrefix(lower[start:], "https://") {
+			byteIdx = start + len("http")
+			continue
+		}
+		if prev, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && isWordRune(prev) {
+			byteIdx = start + len("http")
+			continue
+		}
+
+		end := start
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if unicode.IsSpace(r) || strings.ContainsRune(`<>"`, r) {
+				break
+			}
+			end += size
+		}
+		end = start + len(trimLinkSuffix(text[start:end]))
+
+		raw := text[start:end]
+		if u, err := url.Parse(raw); err == nil && u.Host != "" {
+			charStart := utf8.RuneCountInString(text[:start])
+			links = append(links, Link{URL: raw, Span: Span{
+				ByteStart: start,
+				ByteEnd:   end,
+				CharStart: charStart,
+				CharEnd:   charStart + utf8.RuneCountInString(raw),
+			}})
+		}
+		byteIdx = max(end, start+len("http"))
+	}
+	return links
+}
+
+// trimLinkSuffix drops the punctuation that usually ends the sentence around
+// a URL rather than the URL itself.
+func trimLinkSuffix(raw string) string {
+	for raw != "" {
+		last, size := utf8.DecodeLastRuneInString(raw)
+		switch {
+		case strings.ContainsRune(".,;:!?'*", last):
+		case last == ')' && strings.Count(raw, "(") < strings.Count(raw, ")"):
+		case last == ']' && strings.Count(raw, "[") < strings.Count(raw, "]"):
+		default:
+			return raw
+		}
+		raw = raw[:len(raw)-size]
+	}
+	return raw
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	t.Run("Success: should return byte and char offsets for each mention", func(t *testing.T) {
+		text := "¡Hola @user-1! cc @ana_b"
+
+		mentions := ParseMentions(text)
+
+		assert.Len(t, mentions, 2)
+		assert.Equal(t, "user-1", mentions[0].Handle)
+		assert.Equal(t, Span{ByteStart: 7, ByteEnd: 14, CharStart: 6, CharEnd: 13}, mentions[0].Span)
+		assert.Equal(t, "@user-1", text[mentions[0].ByteStart:mentions[0].ByteEnd])
+		assert.Equal(t, "ana_b", mentions[1].Handle)
+		assert.Equal(t, "@ana_b", text[mentions[1].ByteStart:mentions[1].ByteEnd])
+	})
+
+	t.Run("Success: should ignore emails and lone sigils", func(t *testing.T) {
+		mentions := ParseMentions("escribime a hola@example.com @ o @@user")
+
+		assert.Empty(t, mentions)
+	})
+}
+
+func TestParseHashtags(t *testing.T) {
+	t.Run("Success: should normalize tags and keep their offsets", func(t *testing.T) {
+		text := "Aprendiendo #GoLang y #año2025"
+
+		hashtags := ParseHashtags(text)
+
+		assert.Len(t, hashtags, 2)
+		assert.Equal(t, "golang", hashtags[0].Tag)
+		assert.Equal(t, "#GoLang", text[hashtags[0].ByteStart:hashtags[0].ByteEnd])
+		assert.Equal(t, "año2025", hashtags[1].Tag)
+		assert.Equal(t, 22, hashtags[1].CharStart)
+		assert.Equal(t, 30, hashtags[1].CharEnd)
+	})
+
+	t.Run("Success: should ignore numeric-only tags", func(t *testing.T) {
+		hashtags := ParseHashtags("somos el #1 de C#")
+
+		assert.Empty(t, hashtags)
+	})
+}
+
+func TestParseLinks(t *testing.T) {
+	t.Run("Success: should return each URL with its offsets", func(t *testing.T) {
+		text := "Leé esto: https://go.dev/blog?x=1 y http://example.com/a_(b)"
+
+		links := ParseLinks(text)
+
+		assert.Len(t, links, 2)
+		assert.Equal(t, "https://go.dev/blog?x=1", links[0].URL)
+		assert.Equal(t, links[0].URL, text[links[0].ByteStart:links[0].ByteEnd])
+		assert.Equal(t, 10, links[0].CharStart)
+		assert.Equal(t, "http://example.com/a_(b)", links[1].URL)
+	})
+
+	t.Run("Success: should leave out the punctuation around a URL", func(t *testing.T) {
+		links := ParseLinks("(mirá https://go.dev). ¿Y https://pkg.go.dev?")
+
+		assert.Len(t, links, 2)
+		assert.Equal(t, "https://go.dev", links[0].URL)
+		assert.Equal(t, "https://pkg.go.dev", links[1].URL)
+	})
+
+	t.Run("Success: should ignore text that only looks like a URL", func(t *testing.T) {
+		links := ParseLinks("http:// httpbin xhttps://example.com ftp://example.com")
+
+		assert.Empty(t, links)
+	})
+}
+
+func TestNewLinkPreview(t *testing.T) {
+	t.Run("Success: should collapse whitespace and truncate long fields", func(t *testing.T) {
+		preview := NewLinkPreview("https://go.dev", "  The Go\n Programming   Language ", strings.Repeat("a", 400), "", "Go", time.Now())
+
+		assert.Equal(t, "The Go Programming Language", preview.Title)
+		assert.Len(t, []rune(preview.Description), MaxPreviewDescriptionLength)
+		assert.True(t, strings.HasSuffix(preview.Description, "…"))
+		assert.True(t, preview.Unfurled())
+	})
+}
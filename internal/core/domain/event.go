@@ -0,0 +1,65 @@
+package domain
+
+import "strconv"
+
+type EventType string
+
+const (
+	EventTweet        EventType = "tweet"
+	EventTweetDeleted EventType = "tweet_deleted"
+	EventTweetEdited  EventType = "tweet_edited"
+	EventNotification EventType = "notification"
+)
+
+// Event is a real-time update addressed to a single topic. IDs are assigned
+// by the broker when the event is published and increase monotonically, so
+// clients can resume a stream from the last ID they saw.
+type Event struct {
+	ID           string
+	Topic        string
+	Type         EventType
+	Tweet        *Tweet
+	Notification *Notification
+}
+
+// InvolvesUser reports whether the event carries a tweet by the user or a
+// notification the user caused.
+func (e Event) InvolvesUser(userID string) bool {
+	return (e.Tweet != nil && e.Tweet.UserID == userID) ||
+		(e.Notification != nil && e.Notification.ActorID == userID)
+}
+
+// Payload returns the entity carried by the event.
+func (e Event) Payload() any {
+	if e.Type == EventNotification {
+		return e.Notification
+	}
+	return e.Tweet
+}
+
+func TimelineTopic(userID string) string {
+	return "timeline:" + userID
+}
+
+func NotificationsTopic(userID string) string {
+	return "notifications:" + userID
+}
+
+// ThreadTopic carries the updates of a tweet and its conversation.
+func ThreadTopic(tweetID string) string {
+	return "thread:" + tweetID
+}
+
+// EventIDAfter reports whether event ID a was published after event ID b. An
+// empty or malformed b sorts before every event.
+func EventIDAfter(a, b string) bool {
+	an, err := strconv.ParseUint(a, 10, 64)
+	if err != nil {
+		return false
+	}
+	bn, err := strconv.ParseUint(b, 10, 64)
+	if err != nil {
+		return true
+	}
+	return an > bn
+}
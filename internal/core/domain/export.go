@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// DataExport is the copy of their data a user can download: the account,
+// every tweet they published newest first, and both sides of their follow
+// graph.
+type DataExport struct {
+	User       User
+	Tweets     []Tweet
+	Following  []string
+	Followers  []string
+	ExportedAt time.Time
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const MaxFilterLength = 100
+
+var (
+	ErrInvalidFilter      = errors.New("filter must be a word, phrase or hashtag of up to 100 characters")
+	ErrFilterExpiryInPast = errors.New("filter expiry must be in the future")
+)
+
+type FilterKind string
+
+const (
+	FilterWord    FilterKind = "word"
+	FilterPhrase  FilterKind = "phrase"
+	FilterHashtag FilterKind = "hashtag"
+)
+
+// ContentFilter hides from a user's timeline the tweets containing a word, a
+// phrase or a hashtag. Filters without ExpiresAt never expire.
+type ContentFilter struct {
+	ID        string
+	UserID    string
+	Kind      FilterKind
+	Value     string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewContentFilter infers the kind of filter from its value: "#tag" filters
+// a hashtag, several words filter a phrase and anything else a single word.
+// Words and phrases are split into words the way tweets are, so that
+// punctuation and the @ of mentions do not keep them from matching: "@bob"
+// filters the word "bob" and "don't" the phrase "don t". Matching is
+// case-insensitive.
+func NewContentFilter(userID, value string, expiresAt *time.Time, now time.Time) (*ContentFilter, error) {
+	value = strings.Join(strings.Fields(strings.ToLower(value)), " ")
+	if value == "" || len(value) > MaxFilterLength {
+		return nil, ErrInvalidFilter
+	}
+
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, ErrFilterExpiryInPast
+	}
+
+	kind := FilterWord
+	switch {
+	case strings.HasPrefix(value, "#"):
+		kind = FilterHashtag
+		value = NormalizeHashtag(value)
+		if value == "" || strings.ContainsRune(value, ' ') {
+			return nil, ErrInvalidFilter
+		}
+	default:
+		words := textWords(value)
+		if len(words) == 0 {
+			return nil, ErrInvalidFilter
+		}
+		value = strings.Join(words, " ")
+		if len(words) > 1 {
+			kind = FilterPhrase
+		}
+	}
+
+	return &ContentFilter{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Kind:      kind,
+		Value:     value,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}, nil
+}
+
+func (f ContentFilter) ActiveAt(t time.Time) bool {
+	return f.ExpiresAt == nil || f.ExpiresAt.After(t)
+}
+
+func (f ContentFilter) Matches(tweet Tweet) bool {
+	switch f.Kind {
+	case FilterHashtag:
+		for _, h := range tweet.Hashtags {
+			if h.Tag == f.Value {
+				return true
+			}
+		}
+		return false
+	case FilterPhrase:
+		text := " " + strings.Join(textWords(tweet.Text), " ") + " "
+		return strings.Contains(text, " "+strings.Join(textWords(f.Value), " ")+" ")
+	default:
+		for _, word := range textWords(tweet.Text) {
+			if word == f.Value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// textWords splits text into lowercase words, dropping punctuation and the
+// sigils of mentions and hashtags.
+func textWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r) && r != '-'
+	})
+}
+
+// FilterTweets returns the tweets that none of the active filters match.
+func FilterTweets(tweets []Tweet, filters []ContentFilter, now time.Time) []Tweet {
+	var active []ContentFilter
+	for _, f := range filters {
+		if f.ActiveAt(now) {
+			active = append(active, f)
+		}
+	}
+	if len(active) == 0 {
+		return tweets
+	}
+
+	visible := make([]Tweet, 0, len(tweets))
+	for _, t := range tweets {
+		hidden := false
+		for _, f := range active {
+			if f.Matches(t) {
+				hidden = true
+				break
+			}
+		}
+		if !hidden {
+			visible = append(visible, t)
+		}
+	}
+	return visible
+}
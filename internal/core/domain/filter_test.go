@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContentFilter(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Success: should infer the kind of filter from its value", func(t *testing.T) {
+		word, err := NewContentFilter("user-1", "Spoiler", nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, FilterWord, word.Kind)
+		assert.Equal(t, "spoiler", word.Value)
+
+		phrase, err := NewContentFilter("user-1", "  final   de temporada ", nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, FilterPhrase, phrase.Kind)
+		assert.Equal(t, "final de temporada", phrase.Value)
+
+		hashtag, err := NewContentFilter("user-1", "#Elecciones", nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, FilterHashtag, hashtag.Kind)
+		assert.Equal(t, "elecciones", hashtag.Value)
+	})
+
+	t.Run("Success: should split words and phrases the way tweets are split", func(t *testing.T) {
+		punctuated, err := NewContentFilter("user-1", "Hola!", nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, FilterWord, punctuated.Kind)
+		assert.Equal(t, "hola", punctuated.Value)
+		assert.True(t, punctuated.Matches(Tweet{Text: "¡Hola, mundo!"}))
+
+		mention, err := NewContentFilter("user-1", "@Bob", nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, FilterWord, mention.Kind)
+		assert.Equal(t, "bob", mention.Value)
+		assert.True(t, mention.Matches(Tweet{Text: "Gracias @bob"}))
+
+		contraction, err := NewContentFilter("user-1", "don't", nil, now)
+		require.NoError(t, err)
+		assert.Equal(t, FilterPhrase, contraction.Kind)
+		assert.True(t, contraction.Matches(Tweet{Text: "I don't know"}))
+	})
+
+	t.Run("Failure: should reject empty values and past expiries", func(t *testing.T) {
+		_, err := NewContentFilter("user-1", "   ", nil, now)
+		assert.Equal(t, ErrInvalidFilter, err)
+
+		_, err = NewContentFilter("user-1", "#", nil, now)
+		assert.Equal(t, ErrInvalidFilter, err)
+
+		_, err = NewContentFilter("user-1", "!?", nil, now)
+		assert.Equal(t, ErrInvalidFilter, err)
+
+		past := now.Add(-time.Minute)
+		_, err = NewContentFilter("user-1", "spoiler", &past, now)
+		assert.Equal(t, ErrFilterExpiryInPast, err)
+	})
+}
+
+func TestFilterTweets(t *testing.T) {
+	now := time.Now()
+	filter := func(value string, expiresAt *time.Time) ContentFilter {
+		f, err := NewContentFilter("user-1", value, expiresAt, now.Add(-time.Hour))
+		require.NoError(t, err)
+		return *f
+	}
+	tweet := func(id, text string) Tweet {
+		t, _ := NewTweet("author", text)
+		t.ID = id
+		return *t
+	}
+
+	tweets := []Tweet{
+		tweet("t1", "Sin SPOILERS, pero el final me encantó"),
+		tweet("t2", "Alerta de spoiler: muere el protagonista"),
+		tweet("t3", "Mañana votamos #Elecciones2025"),
+		tweet("t4", "El Final de Temporada fue increíble"),
+	}
+
+	t.Run("Success: should hide tweets matching words, phrases or hashtags", func(t *testing.T) {
+		filters := []ContentFilter{filter("spoiler", nil), filter("#elecciones2025", nil), filter("final de temporada", nil)}
+
+		visible := FilterTweets(tweets, filters, now)
+
+		assert.Len(t, visible, 1)
+		assert.Equal(t, "t1", visible[0].ID)
+	})
+
+	t.Run("Success: should ignore expired filters", func(t *testing.T) {
+		expiry := now.Add(-time.Minute)
+		visible := FilterTweets(tweets, []ContentFilter{filter("spoiler", &expiry)}, now)
+
+		assert.Len(t, visible, len(tweets))
+	})
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrPageNotUnfurlable marks the fetch failures that retrying would not fix,
+// such as a destination that is not allowed or a page that is not HTML.
+var ErrPageNotUnfurlable = errors.New("page cannot be unfurled")
+
+// PendingLink is a link waiting for its preview, with the number of times its
+// page has been fetched, the current attempt included.
+type PendingLink struct {
+	URL      string
+	Attempts int
+}
+
+const (
+	MaxPreviewTitleLength       = 200
+	MaxPreviewDescriptionLength = 300
+)
+
+// LinkPreview is the card shown for a link, built from the Open Graph
+// metadata of the page it points to. Previews are shared by every tweet
+// linking the same URL. A preview without a Title records a page that could
+// not be unfurled, so it is not fetched again and no card is shown.
+type LinkPreview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+	FetchedAt   time.Time
+}
+
+func NewLinkPreview(url, title, description, imageURL, siteName string, now time.Time) *LinkPreview {
+	return &LinkPreview{
+		URL:         url,
+		Title:       truncate(strings.Join(strings.Fields(title), " "), MaxPreviewTitleLength),
+		Description: truncate(strings.Join(strings.Fields(description), " "), MaxPreviewDescriptionLength),
+		ImageURL:    imageURL,
+		SiteName:    truncate(strings.TrimSpace(siteName), MaxPreviewTitleLength),
+		FetchedAt:   now,
+	}
+}
+
+// Unfurled reports whether the page yielded a card.
+func (p *LinkPreview) Unfurled() bool {
+	return p.Title != ""
+}
+
+// truncate cuts s to at most n characters, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n-1])) + "…"
+}
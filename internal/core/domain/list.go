@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxListNameLength        = 25
+	MaxListDescriptionLength = 100
+)
+
+var (
+	ErrInvalidListName        = errors.New("list name must have between 1 and 25 characters")
+	ErrListDescriptionTooLong = errors.New("list description exceeds 100 characters")
+	ErrListNotFound           = errors.New("list not found")
+	ErrNotListOwner           = errors.New("only the owner can change a list")
+)
+
+// List is a curated set of accounts, independent of the follow graph, whose
+// tweets make up the list timeline. Private lists are only visible to their
+// owner.
+type List struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	Description string
+	Private     bool
+	CreatedAt   time.Time
+}
+
+func NewList(ownerID, name, description string, private bool) (*List, error) {
+	list := &List{
+		ID:        uuid.NewString(),
+		OwnerID:   ownerID,
+		CreatedAt: time.Now(),
+	}
+	if err := list.Update(name, description, private); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Update replaces the editable fields of the list after validating them.
+func (l *List) Update(name, description string, private bool) error {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxListNameLength {
+		return ErrInvalidListName
+	}
+	description = strings.TrimSpace(description)
+	if utf8.RuneCountInString(description) > MaxListDescriptionLength {
+		return ErrListDescriptionTooLong
+	}
+
+	l.Name = name
+	l.Description = description
+	l.Private = private
+	return nil
+}
+
+func (l List) VisibleTo(userID string) bool {
+	return !l.Private || l.OwnerID == userID
+}
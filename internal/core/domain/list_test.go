@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewList(t *testing.T) {
+	t.Run("Success: should trim the name and description", func(t *testing.T) {
+		list, err := NewList("ana", "  Periodistas ", " cuentas de noticias ", true)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Periodistas", list.Name)
+		assert.Equal(t, "cuentas de noticias", list.Description)
+		assert.True(t, list.VisibleTo("ana"))
+		assert.False(t, list.VisibleTo("beto"))
+	})
+
+	t.Run("Failure: should reject blank or long names and long descriptions", func(t *testing.T) {
+		_, err := NewList("ana", "   ", "", false)
+		assert.Equal(t, ErrInvalidListName, err)
+
+		_, err = NewList("ana", strings.Repeat("ñ", MaxListNameLength+1), "", false)
+		assert.Equal(t, ErrInvalidListName, err)
+
+		_, err = NewList("ana", "Periodistas", strings.Repeat("a", MaxListDescriptionLength+1), false)
+		assert.Equal(t, ErrListDescriptionTooLong, err)
+	})
+}
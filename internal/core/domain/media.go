@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxMediaSize is the largest upload accepted, in bytes.
+	MaxMediaSize = 5 << 20
+	// MaxMediaDimension bounds the width and height of uploaded images.
+	MaxMediaDimension = 4096
+	// MaxMediaPixels bounds the pixels decoded from an upload, adding up
+	// every frame of an animated GIF, so a small file cannot decode into a
+	// huge bitmap.
+	MaxMediaPixels = 16 << 20
+	// MaxGIFFrames bounds the frames of an animated GIF.
+	MaxGIFFrames = 200
+	// MaxThumbnailDimension is the size of the box thumbnails are fit into.
+	MaxThumbnailDimension = 320
+	MaxTweetMedia         = 4
+)
+
+var (
+	ErrMediaTooLarge        = errors.New("media exceeds the size limit")
+	ErrUnsupportedMediaType = errors.New("only JPEG, PNG and GIF images are supported")
+	ErrMediaNotFound        = errors.New("media not found")
+	ErrTooManyMedia         = errors.New("a tweet can have at most 4 media attachments")
+	ErrBlobNotFound         = errors.New("blob not found")
+)
+
+// Media is an image uploaded by a user, ready to be attached to their
+// tweets. The original and its thumbnail are stored in the blob store under
+// BlobKey and ThumbnailKey.
+type Media struct {
+	ID          string
+	UserID      string
+	ContentType string
+	Width       int
+	Height      int
+	Size        int
+	CreatedAt   time.Time
+}
+
+func NewMedia(userID, contentType string, width, height, size int, now time.Time) *Media {
+	return &Media{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		ContentType: contentType,
+		Width:       width,
+		Height:      height,
+		Size:        size,
+		CreatedAt:   now,
+	}
+}
+
+func (m *Media) BlobKey() string {
+	return m.ID + "/original"
+}
+
+func (m *Media) ThumbnailKey() string {
+	return m.ID + "/thumbnail"
+}
+
+// ThumbnailContentType is JPEG for photos and PNG for everything else, which
+// keeps the transparency of PNG and GIF images.
+func (m *Media) ThumbnailContentType() string {
+	if m.ContentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// MediaBlob is the content of a media or of its thumbnail. Private media
+// belong to accounts whose tweets not everyone may see, so they must not be
+// kept by shared caches.
+type MediaBlob struct {
+	Data        []byte
+	ContentType string
+	Private     bool
+}
+
+// ThumbnailSize returns the dimensions of an image of width x height scaled
+// down, keeping its aspect ratio, to fit in a MaxThumbnailDimension box.
+// Smaller images keep their size.
+func ThumbnailSize(width, height int) (int, int) {
+	if width <= MaxThumbnailDimension && height <= MaxThumbnailDimension {
+		return width, height
+	}
+	if width >= height {
+		return MaxThumbnailDimension, max(1, height*MaxThumbnailDimension/width)
+	}
+	return max(1, width*MaxThumbnailDimension/height), MaxThumbnailDimension
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxMessageLength            = 1000
+	MaxConversationParticipants = 10
+)
+
+var (
+	ErrMessageEmpty         = errors.New("message cannot be empty")
+	ErrMessageTooLong       = errors.New("message exceeds 1000 characters")
+	ErrInvalidParticipants  = errors.New("a conversation needs between 2 and 10 different participants")
+	ErrConversationNotFound = errors.New("conversation not found")
+)
+
+// Participant is a member of a conversation. LastReadAt is the read receipt:
+// every message sent up to that moment has been seen by the participant.
+type Participant struct {
+	UserID     string
+	LastReadAt *time.Time
+}
+
+// Conversation is a one-to-one or small group direct message thread.
+type Conversation struct {
+	ID            string
+	Participants  []Participant
+	CreatedAt     time.Time
+	LastMessageAt time.Time
+}
+
+// NewConversation starts a conversation between creatorID and the other
+// participants; duplicates and the creator itself are ignored in the list.
+func NewConversation(creatorID string, participantIDs []string, now time.Time) (*Conversation, error) {
+	ids := []string{creatorID}
+	for _, id := range participantIDs {
+		if id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 || len(ids) > MaxConversationParticipants {
+		return nil, ErrInvalidParticipants
+	}
+	slices.Sort(ids)
+
+	conversation := &Conversation{
+		ID:            uuid.NewString(),
+		CreatedAt:     now,
+		LastMessageAt: now,
+	}
+	for _, id := range ids {
+		participant := Participant{UserID: id}
+		if id == creatorID {
+			participant.LastReadAt = &now
+		}
+		conversation.Participants = append(conversation.Participants, participant)
+	}
+	return conversation, nil
+}
+
+func (c Conversation) ParticipantIDs() []string {
+	ids := make([]string, len(c.Participants))
+	for i, p := range c.Participants {
+		ids[i] = p.UserID
+	}
+	return ids
+}
+
+func (c Conversation) HasParticipant(userID string) bool {
+	return slices.Contains(c.ParticipantIDs(), userID)
+}
+
+// OtherParticipantIDs returns every participant but userID.
+func (c Conversation) OtherParticipantIDs(userID string) []string {
+	var ids []string
+	for _, p := range c.Participants {
+		if p.UserID != userID {
+			ids = append(ids, p.UserID)
+		}
+	}
+	return ids
+}
+
+// DirectKey identifies one-to-one conversations so that the same two users
+// always share a single one; group conversations have no key.
+func (c Conversation) DirectKey() string {
+	if len(c.Participants) != 2 {
+		return ""
+	}
+	return strings.Join(c.ParticipantIDs(), "|")
+}
+
+type Message struct {
+	ID             string
+	ConversationID string
+	SenderID       string
+	Text           string
+	CreatedAt      time.Time
+}
+
+func NewMessage(conversationID, senderID, text string, now time.Time) (*Message, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, ErrMessageEmpty
+	}
+	if utf8.RuneCountInString(text) > MaxMessageLength {
+		return nil, ErrMessageTooLong
+	}
+	return &Message{
+		ID:             uuid.NewString(),
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Text:           text,
+		CreatedAt:      now,
+	}, nil
+}
+
+func CursorAfterMessage(m Message) *Cursor {
+	return &Cursor{CreatedAt: m.CreatedAt, ID: m.ID}
+}
+
+// CursorAfterConversation pages conversations by their latest activity.
+func CursorAfterConversation(c Conversation) *Cursor {
+	return &Cursor{CreatedAt: c.LastMessageAt, ID: c.ID}
+}
+
+type MessagePage struct {
+	Messages   []Message
+	NextCursor string
+}
+
+func NewMessagePage(messages []Message, limit int) MessagePage {
+	messages, next := paginate(messages, limit, CursorAfterMessage)
+	return MessagePage{Messages: messages, NextCursor: next}
+}
+
+type ConversationPage struct {
+	Conversations []Conversation
+	NextCursor    string
+}
+
+func NewConversationPage(conversations []Conversation, limit int) ConversationPage {
+	conversations, next := paginate(conversations, limit, CursorAfterConversation)
+	return ConversationPage{Conversations: conversations, NextCursor: next}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConversation(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Success: should share the direct key regardless of who starts it", func(t *testing.T) {
+		first, err := NewConversation("ana", []string{"beto"}, now)
+		require.NoError(t, err)
+		second, err := NewConversation("beto", []string{"ana", "beto"}, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"ana", "beto"}, first.ParticipantIDs())
+		assert.Equal(t, first.DirectKey(), second.DirectKey())
+		assert.Equal(t, now, first.CreatedAt)
+		assert.Equal(t, &now, first.Participants[0].LastReadAt)
+		assert.Nil(t, first.Participants[1].LastReadAt)
+	})
+
+	t.Run("Success: group conversations have no direct key", func(t *testing.T) {
+		group, err := NewConversation("ana", []string{"beto", "carla"}, now)
+		require.NoError(t, err)
+
+		assert.Empty(t, group.DirectKey())
+		assert.Equal(t, []string{"ana", "carla"}, group.OtherParticipantIDs("beto"))
+	})
+
+	t.Run("Failure: should reject conversations with oneself or too many users", func(t *testing.T) {
+		_, err := NewConversation("ana", []string{"ana"}, now)
+		assert.Equal(t, ErrInvalidParticipants, err)
+
+		crowd := []string{"b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}
+		_, err = NewConversation("a", crowd, now)
+		assert.Equal(t, ErrInvalidParticipants, err)
+	})
+}
+
+func TestNewMessage(t *testing.T) {
+	now := time.Now()
+
+	_, err := NewMessage("conversation-1", "ana", "   ", now)
+	assert.Equal(t, ErrMessageEmpty, err)
+
+	_, err = NewMessage("conversation-1", "ana", strings.Repeat("ñ", MaxMessageLength+1), now)
+	assert.Equal(t, ErrMessageTooLong, err)
+
+	message, err := NewMessage("conversation-1", "ana", "hola", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "ana", message.SenderID)
+	assert.Equal(t, now, message.CreatedAt)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ModerationAction string
+
+const (
+	ModerationAllow  ModerationAction = "allow"
+	ModerationReject ModerationAction = "reject"
+	ModerationHold   ModerationAction = "hold"
+)
+
+var (
+	ErrContentRejected    = errors.New("content rejected by moderation")
+	ErrTweetHeldForReview = errors.New("tweet held for review")
+	ErrHeldTweetNotFound  = errors.New("held tweet not found")
+)
+
+// ModerationDecision is the verdict of a moderation policy. Reason is a
+// short machine-readable code, e.g. "SPAM", explaining a rejection or hold.
+type ModerationDecision struct {
+	Action ModerationAction
+	Reason string
+}
+
+// RejectionError reports content rejected by moderation together with the
+// reason code of the decision. It matches ErrContentRejected.
+type RejectionError struct {
+	Reason string
+}
+
+func (e *RejectionError) Error() string {
+	return ErrContentRejected.Error() + ": " + e.Reason
+}
+
+func (e *RejectionError) Is(target error) bool {
+	return target == ErrContentRejected
+}
+
+// HeldTweet is a tweet waiting for a moderator, who either approves it, so
+// it is published and fanned out as if it had just been posted, or rejects
+// it.
+type HeldTweet struct {
+	ID        string
+	UserID    string
+	Content   TweetContent
+	Reason    string
+	CreatedAt time.Time
+}
+
+func NewHeldTweet(userID string, content TweetContent, reason string, now time.Time) *HeldTweet {
+	return &HeldTweet{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Content:   content,
+		Reason:    reason,
+		CreatedAt: now,
+	}
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotificationFollow         NotificationType = "follow"
+	NotificationMention        NotificationType = "mention"
+	NotificationFollowRequest  NotificationType = "follow_request"
+	NotificationFollowAccepted NotificationType = "follow_accepted"
+)
+
+type Notification struct {
+	ID          string
+	RecipientID string
+	Type        NotificationType
+	ActorID     string
+	TweetID     string
+	CreatedAt   time.Time
+	Read        bool
+}
+
+func NewNotification(recipientID string, notificationType NotificationType, actorID, tweetID string) Notification {
+	return Notification{
+		ID:          uuid.NewString(),
+		RecipientID: recipientID,
+		Type:        notificationType,
+		ActorID:     actorID,
+		TweetID:     tweetID,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// NotificationGroup collapses notifications of the same type about the same
+// tweet (if any) and read state into a single entry, e.g. "X and 4 others
+// followed you".
+type NotificationGroup struct {
+	Type            NotificationType
+	TweetID         string
+	ActorIDs        []string
+	Summary         string
+	LatestAt        time.Time
+	Read            bool
+	NotificationIDs []string
+}
+
+type NotificationFeed struct {
+	Groups      []NotificationGroup
+	UnreadCount int
+}
+
+// AggregateNotifications groups notifications, which must be sorted newest
+// first; groups keep that order and list each actor once, most recent first.
+func AggregateNotifications(notifications []Notification) NotificationFeed {
+	type groupKey struct {
+		notificationType NotificationType
+		tweetID          string
+		read             bool
+	}
+
+	feed := NotificationFeed{Groups: []NotificationGroup{}}
+	groupIdx := make(map[groupKey]int)
+	seenActors := make(map[groupKey]map[string]bool)
+
+	for _, n := range notifications {
+		if !n.Read {
+			feed.UnreadCount++
+		}
+
+		key := groupKey{n.Type, n.TweetID, n.Read}
+		idx, ok := groupIdx[key]
+		if !ok {
+			idx = len(feed.Groups)
+			groupIdx[key] = idx
+			seenActors[key] = make(map[string]bool)
+			feed.Groups = append(feed.Groups, NotificationGroup{
+				Type:     n.Type,
+				TweetID:  n.TweetID,
+				LatestAt: n.CreatedAt,
+				Read:     n.Read,
+			})
+		}
+
+		group := &feed.Groups[idx]
+		group.NotificationIDs = append(group.NotificationIDs, n.ID)
+		if !seenActors[key][n.ActorID] {
+			seenActors[key][n.ActorID] = true
+			group.ActorIDs = append(group.ActorIDs, n.ActorID)
+		}
+	}
+
+	for i := range feed.Groups {
+		feed.Groups[i].Summary = feed.Groups[i].summary()
+	}
+	return feed
+}
+
+func (g NotificationGroup) summary() string {
+	var action string
+	switch g.Type {
+	case NotificationFollow:
+		action = "followed you"
+	case NotificationMention:
+		action = "mentioned you"
+	case NotificationFollowRequest:
+		action = "requested to follow you"
+	case NotificationFollowAccepted:
+		action = "accepted your follow request"
+	default:
+		action = string(g.Type)
+	}
+
+	switch others := len(g.ActorIDs) - 1; others {
+	case 0:
+		return fmt.Sprintf("%s %s", g.ActorIDs[0], action)
+	case 1:
+		return fmt.Sprintf("%s and 1 other %s", g.ActorIDs[0], action)
+	default:
+		return fmt.Sprintf("%s and %d others %s", g.ActorIDs[0], others, action)
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateNotifications(t *testing.T) {
+	now := time.Now()
+	notification := func(id string, notificationType NotificationType, actorID, tweetID string, age time.Duration, read bool) Notification {
+		return Notification{ID: id, RecipientID: "user-1", Type: notificationType, ActorID: actorID, TweetID: tweetID, CreatedAt: now.Add(-age), Read: read}
+	}
+
+	t.Run("Success: should collapse unread follows into a single group", func(t *testing.T) {
+		feed := AggregateNotifications([]Notification{
+			notification("n5", NotificationFollow, "ana", "", 1*time.Minute, false),
+			notification("n4", NotificationMention, "beto", "tweet-1", 2*time.Minute, false),
+			notification("n3", NotificationFollow, "carla", "", 3*time.Minute, false),
+			notification("n2", NotificationFollow, "dani", "", 4*time.Minute, false),
+			notification("n1", NotificationFollow, "eva", "", 5*time.Minute, true),
+		})
+
+		assert.Equal(t, 4, feed.UnreadCount)
+		assert.Len(t, feed.Groups, 3)
+		assert.Equal(t, "ana and 2 others followed you", feed.Groups[0].Summary)
+		assert.Equal(t, []string{"n5", "n3", "n2"}, feed.Groups[0].NotificationIDs)
+		assert.Equal(t, "beto mentioned you", feed.Groups[1].Summary)
+		assert.Equal(t, "tweet-1", feed.Groups[1].TweetID)
+		assert.True(t, feed.Groups[2].Read)
+	})
+
+	t.Run("Success: should list repeated actors once", func(t *testing.T) {
+		feed := AggregateNotifications([]Notification{
+			notification("n2", NotificationFollow, "ana", "", 1*time.Minute, false),
+			notification("n1", NotificationFollow, "ana", "", 2*time.Minute, false),
+		})
+
+		assert.Equal(t, "ana followed you", feed.Groups[0].Summary)
+	})
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Tables partitioned by month on the creation time of their tweets.
+const (
+	TweetsTable    = "tweets"
+	TimelinesTable = "timelines"
+)
+
+// ErrPartitionRowsInDefault is returned when a month cannot get its partition
+// because the default partition already holds rows of that month.
+var ErrPartitionRowsInDefault = errors.New("the default partition holds rows of the month")
+
+const partitionSuffixLayout = "2006_01"
+
+// Partition is one month of a partitioned table, from the start of the month
+// (inclusive) to the start of the next one (exclusive), in UTC.
+type Partition struct {
+	Table string
+	From  time.Time
+	To    time.Time
+}
+
+// MonthlyPartition returns the partition of table that holds t.
+func MonthlyPartition(table string, t time.Time) Partition {
+	t = t.UTC()
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return Partition{Table: table, From: from, To: from.AddDate(0, 1, 0)}
+}
+
+// ParsePartition recognises the partitions named by Name. Any other table
+// attached to table, such as its default partition, is not a monthly one.
+func ParsePartition(table, name string) (Partition, bool) {
+	suffix, ok := strings.CutPrefix(name, table+"_")
+	if !ok {
+		return Partition{}, false
+	}
+	from, err := time.Parse(partitionSuffixLayout, suffix)
+	if err != nil {
+		return Partition{}, false
+	}
+	return MonthlyPartition(table, from), true
+}
+
+// Name is the table name of the partition, e.g. tweets_2025_07.
+func (p Partition) Name() string {
+	return p.Table + "_" + p.From.Format(partitionSuffixLayout)
+}
+
+// Next returns the partition of the following month.
+func (p Partition) Next() Partition {
+	return MonthlyPartition(p.Table, p.To)
+}
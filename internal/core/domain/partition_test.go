@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthlyPartition(t *testing.T) {
+	t.Run("Success: should cover the UTC month of the time", func(t *testing.T) {
+		at := time.Date(2025, 12, 31, 22, 0, 0, 0, time.FixedZone("ART", -3*60*60))
+
+		p := MonthlyPartition(TweetsTable, at)
+
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), p.From)
+		assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), p.To)
+		assert.Equal(t, "tweets_2026_01", p.Name())
+		assert.Equal(t, "tweets_2026_02", p.Next().Name())
+	})
+}
+
+func TestParsePartition(t *testing.T) {
+	t.Run("Success: should parse the names of monthly partitions", func(t *testing.T) {
+		p, ok := ParsePartition(TimelinesTable, "timelines_2025_07")
+
+		require.True(t, ok)
+		assert.Equal(t, MonthlyPartition(TimelinesTable, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)), p)
+	})
+
+	t.Run("Failure: should skip other tables", func(t *testing.T) {
+		_, ok := ParsePartition(TimelinesTable, "timelines_default")
+		assert.False(t, ok)
+
+		_, ok = ParsePartition(TweetsTable, "tweets_default")
+		assert.False(t, ok)
+
+		_, ok = ParsePartition(TweetsTable, "timelines_2025_07")
+		assert.False(t, ok)
+	})
+}
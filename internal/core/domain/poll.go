@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	MinPollOptions      = 2
+	MaxPollOptions      = 4
+	MaxPollOptionLength = 25
+	MinPollDuration     = 5 * time.Minute
+	MaxPollDuration     = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidPollOptions  = errors.New("a poll needs between 2 and 4 distinct, non-empty options")
+	ErrPollOptionTooLong   = errors.New("poll options cannot exceed 25 characters")
+	ErrInvalidPollDuration = errors.New("a poll must last between 5 minutes and 7 days")
+	ErrPollNotFound        = errors.New("poll not found")
+	ErrPollClosed          = errors.New("the poll is closed")
+	ErrAlreadyVoted        = errors.New("already voted in this poll")
+	ErrInvalidPollOption   = errors.New("invalid poll option")
+)
+
+// PollSpec is the poll an author asks for when publishing a tweet.
+type PollSpec struct {
+	Options  []string
+	Duration time.Duration
+}
+
+// Poll is attached to a tweet and accepts votes until EndsAt, when it closes
+// on its own.
+type Poll struct {
+	Options []string
+	EndsAt  time.Time
+}
+
+func NewPoll(spec PollSpec, now time.Time) (*Poll, error) {
+	if len(spec.Options) < MinPollOptions || len(spec.Options) > MaxPollOptions {
+		return nil, ErrInvalidPollOptions
+	}
+	options := make([]string, len(spec.Options))
+	for i, option := range spec.Options {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return nil, ErrInvalidPollOptions
+		}
+		if len([]rune(option)) > MaxPollOptionLength {
+			return nil, ErrPollOptionTooLong
+		}
+		for _, previous := range options[:i] {
+			if strings.EqualFold(previous, option) {
+				return nil, ErrInvalidPollOptions
+			}
+		}
+		options[i] = option
+	}
+	if spec.Duration < MinPollDuration || spec.Duration > MaxPollDuration {
+		return nil, ErrInvalidPollDuration
+	}
+
+	return &Poll{Options: options, EndsAt: now.Add(spec.Duration).UTC()}, nil
+}
+
+func (p *Poll) Closed(now time.Time) bool {
+	return !now.Before(p.EndsAt)
+}
+
+// PollResults is a poll as seen by one user. Votes holds the tally of each
+// option and stays nil until the user has voted or the poll has closed, so
+// the running results cannot sway anyone's vote.
+type PollResults struct {
+	Options     []string
+	EndsAt      time.Time
+	Closed      bool
+	VotedOption *int
+	Votes       []int
+	TotalVotes  *int
+}
+
+func NewPollResults(poll *Poll, tallies []int, votedOption *int, now time.Time) PollResults {
+	results := PollResults{
+		Options:     poll.Options,
+		EndsAt:      poll.EndsAt,
+		Closed:      poll.Closed(now),
+		VotedOption: votedOption,
+	}
+	if results.Closed || votedOption != nil {
+		total := 0
+		for _, votes := range tallies {
+			total += votes
+		}
+		results.Votes = tallies
+		results.TotalVotes = &total
+	}
+	return results
+}
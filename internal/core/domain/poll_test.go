@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPoll(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success: should create a poll that ends after its duration", func(t *testing.T) {
+		poll, err := NewPoll(PollSpec{Options: []string{" Sí ", "No"}, Duration: time.Hour}, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Sí", "No"}, poll.Options)
+		assert.Equal(t, now.Add(time.Hour), poll.EndsAt)
+		assert.False(t, poll.Closed(now.Add(59*time.Minute)))
+		assert.True(t, poll.Closed(now.Add(time.Hour)))
+	})
+
+	t.Run("Failure: should reject invalid polls", func(t *testing.T) {
+		cases := []struct {
+			name string
+			spec PollSpec
+			err  error
+		}{
+			{"one option", PollSpec{Options: []string{"Sí"}, Duration: time.Hour}, ErrInvalidPollOptions},
+			{"five options", PollSpec{Options: []string{"a", "b", "c", "d", "e"}, Duration: time.Hour}, ErrInvalidPollOptions},
+			{"empty option", PollSpec{Options: []string{"Sí", "  "}, Duration: time.Hour}, ErrInvalidPollOptions},
+			{"repeated option", PollSpec{Options: []string{"Sí", "sí"}, Duration: time.Hour}, ErrInvalidPollOptions},
+			{"long option", PollSpec{Options: []string{"Sí", strings.Repeat("a", 26)}, Duration: time.Hour}, ErrPollOptionTooLong},
+			{"too short", PollSpec{Options: []string{"Sí", "No"}, Duration: time.Minute}, ErrInvalidPollDuration},
+			{"too long", PollSpec{Options: []string{"Sí", "No"}, Duration: 8 * 24 * time.Hour}, ErrInvalidPollDuration},
+		}
+		for _, tc := range cases {
+			_, err := NewPoll(tc.spec, now)
+			assert.Equal(t, tc.err, err, tc.name)
+		}
+	})
+}
+
+func TestNewPollResults(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	poll := &Poll{Options: []string{"Sí", "No"}, EndsAt: now.Add(time.Hour)}
+	tallies := []int{3, 1}
+
+	t.Run("Success: should hide the tallies from users who have not voted", func(t *testing.T) {
+		results := NewPollResults(poll, tallies, nil, now)
+
+		assert.False(t, results.Closed)
+		assert.Nil(t, results.Votes)
+		assert.Nil(t, results.TotalVotes)
+	})
+
+	t.Run("Success: should show the tallies after voting", func(t *testing.T) {
+		option := 1
+		results := NewPollResults(poll, tallies, &option, now)
+
+		assert.Equal(t, []int{3, 1}, results.Votes)
+		assert.Equal(t, 4, *results.TotalVotes)
+		assert.Equal(t, 1, *results.VotedOption)
+	})
+
+	t.Run("Success: should show the tallies to everyone once closed", func(t *testing.T) {
+		results := NewPollResults(poll, tallies, nil, now.Add(time.Hour))
+
+		assert.True(t, results.Closed)
+		assert.Equal(t, []int{3, 1}, results.Votes)
+	})
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const MaxReportCommentLength = 500
+
+type ReportTarget string
+
+const (
+	ReportTargetTweet ReportTarget = "tweet"
+	ReportTargetUser  ReportTarget = "user"
+)
+
+type ReportReason string
+
+const (
+	ReportReasonSpam       ReportReason = "spam"
+	ReportReasonHarassment ReportReason = "harassment"
+	ReportReasonHate       ReportReason = "hate"
+	ReportReasonViolence   ReportReason = "violence"
+	ReportReasonOther      ReportReason = "other"
+)
+
+// ReportStatus is where a report is in the review: open until an admin
+// picks it up, triaged while it is being looked into, resolved once decided.
+type ReportStatus string
+
+const (
+	ReportOpen     ReportStatus = "open"
+	ReportTriaged  ReportStatus = "triaged"
+	ReportResolved ReportStatus = "resolved"
+)
+
+// ReportResolution records whether action was taken on a resolved report.
+type ReportResolution string
+
+const (
+	ReportActioned  ReportResolution = "actioned"
+	ReportDismissed ReportResolution = "dismissed"
+)
+
+var (
+	ErrInvalidReportTarget     = errors.New("reports target a tweet or a user")
+	ErrInvalidReportReason     = errors.New("invalid report reason")
+	ErrInvalidReportStatus     = errors.New("invalid report status")
+	ErrInvalidReportResolution = errors.New("a report is resolved as actioned or dismissed")
+	ErrReportCommentTooLong    = errors.New("report comment exceeds 500 character limit")
+	ErrCannotReportSelf        = errors.New("users cannot report themselves")
+	ErrAlreadyReported         = errors.New("there is already an open report from the user on this target")
+	ErrReportNotFound          = errors.New("report not found")
+	ErrReportResolved          = errors.New("the report is already resolved")
+)
+
+// Report is a user's complaint about a tweet or an account. ReportedUserID
+// is the account held responsible: the reported user, or the author of the
+// reported tweet. ModeratorID is the admin who last handled the report.
+type Report struct {
+	ID             string
+	ReporterID     string
+	TargetType     ReportTarget
+	TargetID       string
+	ReportedUserID string
+	Reason         ReportReason
+	Comment        string
+	Status         ReportStatus
+	ModeratorID    string
+	Resolution     ReportResolution
+	Note           string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// ReportInput is what a user submits to report a tweet or an account.
+type ReportInput struct {
+	TargetType ReportTarget
+	TargetID   string
+	Reason     ReportReason
+	Comment    string
+}
+
+func NewReport(reporterID, reportedUserID string, input ReportInput, now time.Time) (*Report, error) {
+	if input.TargetType != ReportTargetTweet && input.TargetType != ReportTargetUser {
+		return nil, ErrInvalidReportTarget
+	}
+	switch input.Reason {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHate, ReportReasonViolence, ReportReasonOther:
+	default:
+		return nil, ErrInvalidReportReason
+	}
+	if len([]rune(input.Comment)) > MaxReportCommentLength {
+		return nil, ErrReportCommentTooLong
+	}
+	if reportedUserID == reporterID {
+		return nil, ErrCannotReportSelf
+	}
+
+	return &Report{
+		ID:             uuid.NewString(),
+		ReporterID:     reporterID,
+		TargetType:     input.TargetType,
+		TargetID:       input.TargetID,
+		ReportedUserID: reportedUserID,
+		Reason:         input.Reason,
+		Comment:        input.Comment,
+		Status:         ReportOpen,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+func ParseReportStatus(status string) (ReportStatus, error) {
+	switch s := ReportStatus(status); s {
+	case ReportOpen, ReportTriaged, ReportResolved:
+		return s, nil
+	}
+	return "", ErrInvalidReportStatus
+}
+
+// Triage marks the report as being looked into by the moderator.
+func (r *Report) Triage(moderatorID string, now time.Time) error {
+	if r.Status == ReportResolved {
+		return ErrReportResolved
+	}
+	r.Status = ReportTriaged
+	r.ModeratorID = moderatorID
+	r.UpdatedAt = now
+	return nil
+}
+
+// Resolve closes the report. Resolved reports are final.
+func (r *Report) Resolve(moderatorID string, resolution ReportResolution, note string, now time.Time) error {
+	if r.Status == ReportResolved {
+		return ErrReportResolved
+	}
+	if resolution != ReportActioned && resolution != ReportDismissed {
+		return ErrInvalidReportResolution
+	}
+	if len([]rune(note)) > MaxReportCommentLength {
+		return ErrReportCommentTooLong
+	}
+	r.Status = ReportResolved
+	r.ModeratorID = moderatorID
+	r.Resolution = resolution
+	r.Note = note
+	r.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	input := ReportInput{TargetType: ReportTargetTweet, TargetID: "tweet-1", Reason: ReportReasonSpam, Comment: "Vende seguidores"}
+
+	t.Run("Success: should open a report on the target", func(t *testing.T) {
+		report, err := NewReport("ana", "beto", input, now)
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, report.ID)
+		assert.Equal(t, ReportOpen, report.Status)
+		assert.Equal(t, "beto", report.ReportedUserID)
+		assert.Equal(t, now, report.CreatedAt)
+	})
+
+	t.Run("Failure: should reject invalid reports", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			reported string
+			input    ReportInput
+			err      error
+		}{
+			{"unknown target", "beto", ReportInput{TargetType: "list", TargetID: "list-1", Reason: ReportReasonSpam}, ErrInvalidReportTarget},
+			{"unknown reason", "beto", ReportInput{TargetType: ReportTargetUser, TargetID: "beto", Reason: "boring"}, ErrInvalidReportReason},
+			{"long comment", "beto", ReportInput{TargetType: ReportTargetUser, TargetID: "beto", Reason: ReportReasonOther, Comment: strings.Repeat("a", 501)}, ErrReportCommentTooLong},
+			{"own tweet", "ana", input, ErrCannotReportSelf},
+		}
+		for _, tc := range cases {
+			_, err := NewReport("ana", tc.reported, tc.input, now)
+			assert.Equal(t, tc.err, err, tc.name)
+		}
+	})
+}
+
+func TestReport_Resolve(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success: should record the decision of the moderator", func(t *testing.T) {
+		report := &Report{Status: ReportOpen}
+
+		require.NoError(t, report.Triage("admin-1", now))
+		assert.Equal(t, ReportTriaged, report.Status)
+
+		err := report.Resolve("admin-2", ReportActioned, "Cuenta suspendida", now.Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.Equal(t, ReportResolved, report.Status)
+		assert.Equal(t, "admin-2", report.ModeratorID)
+		assert.Equal(t, ReportActioned, report.Resolution)
+		assert.Equal(t, now.Add(time.Hour), report.UpdatedAt)
+	})
+
+	t.Run("Failure: should not change resolved reports", func(t *testing.T) {
+		report := &Report{Status: ReportResolved, Resolution: ReportDismissed}
+
+		assert.Equal(t, ErrReportResolved, report.Triage("admin-1", now))
+		assert.Equal(t, ErrReportResolved, report.Resolve("admin-1", ReportActioned, "", now))
+		assert.Equal(t, ReportDismissed, report.Resolution)
+	})
+
+	t.Run("Failure: should only accept known resolutions", func(t *testing.T) {
+		report := &Report{Status: ReportOpen}
+
+		err := report.Resolve("admin-1", "ignored", "", now)
+
+		assert.Equal(t, ErrInvalidReportResolution, err)
+		assert.Equal(t, ReportOpen, report.Status)
+	})
+}
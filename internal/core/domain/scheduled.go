@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPublishAtInPast         = errors.New("publish_at must be in the future")
+	ErrScheduledTweetNotFound  = errors.New("scheduled tweet not found")
+	ErrScheduledTweetPublished = errors.New("scheduled tweet is already being published")
+)
+
+// ScheduledTweet is a tweet waiting to be published at PublishAt by the
+// scheduler. Once published it becomes a Tweet with the same ID, so that
+// publishing it again is detected, dated when it was actually published.
+type ScheduledTweet struct {
+	ID        string
+	UserID    string
+	Text      string
+	PublishAt time.Time
+	CreatedAt time.Time
+}
+
+func NewScheduledTweet(userID, text string, publishAt, now time.Time) (*ScheduledTweet, error) {
+	scheduled := &ScheduledTweet{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		CreatedAt: now,
+	}
+	if err := scheduled.Update(text, publishAt, now); err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+// Update replaces the text and publication time, applying the same rules as
+// when the tweet was scheduled.
+func (s *ScheduledTweet) Update(text string, publishAt, now time.Time) error {
+	if len(text) > MaxTweetLength {
+		return ErrTweetTooLong
+	}
+	if !publishAt.After(now) {
+		return ErrPublishAtInPast
+	}
+
+	s.Text = text
+	s.PublishAt = publishAt.UTC()
+	return nil
+}
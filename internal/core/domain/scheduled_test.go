@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewScheduledTweet(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Success: should store the publication time in UTC", func(t *testing.T) {
+		publishAt := now.Add(time.Hour).In(time.FixedZone("ART", -3*60*60))
+
+		scheduled, err := NewScheduledTweet("ana", "Lanzamiento", publishAt, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, time.UTC, scheduled.PublishAt.Location())
+		assert.True(t, scheduled.PublishAt.Equal(publishAt))
+	})
+
+	t.Run("Failure: should reject past times and long texts", func(t *testing.T) {
+		_, err := NewScheduledTweet("ana", "Lanzamiento", now, now)
+		assert.Equal(t, ErrPublishAtInPast, err)
+
+		_, err = NewScheduledTweet("ana", strings.Repeat("a", MaxTweetLength+1), now.Add(time.Hour), now)
+		assert.Equal(t, ErrTweetTooLong, err)
+	})
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptySearchQuery   = errors.New("search query is empty")
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+)
+
+type SearchQuery struct {
+	Terms      []string
+	Phrases    []string
+	FromUserID string
+	Since      time.Time
+	Until      time.Time
+	Cursor     *Cursor
+}
+
+func (q SearchQuery) HasText() bool {
+	return len(q.Terms) > 0 || len(q.Phrases) > 0
+}
+
+// ParseSearchQuery understands plain terms, "quoted phrases" and the
+// from:<user>, since:<YYYY-MM-DD> and until:<YYYY-MM-DD> operators. until is
+// exclusive, so until:2025-01-02 matches everything posted on January 1st.
+func ParseSearchQuery(raw string) (SearchQuery, error) {
+	var q SearchQuery
+
+	rest := strings.TrimSpace(raw)
+	for rest != "" {
+		if rest[0] == '"' {
+			phrase, after, _ := strings.Cut(rest[1:], `"`)
+			if phrase = strings.TrimSpace(phrase); phrase != "" {
+				q.Phrases = append(q.Phrases, phrase)
+			}
+			rest = strings.TrimSpace(after)
+			continue
+		}
+
+		token, after, _ := strings.Cut(rest, " ")
+		rest = strings.TrimSpace(after)
+
+		operator, value, isOperator := strings.Cut(token, ":")
+		switch {
+		case isOperator && operator == "from" && value != "":
+			q.FromUserID = strings.TrimPrefix(value, "@")
+		case isOperator && (operator == "since" || operator == "until"):
+			day, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				return SearchQuery{}, ErrInvalidSearchQuery
+			}
+			if operator == "since" {
+				q.Since = day
+			} else {
+				q.Until = day
+			}
+		default:
+			q.Terms = append(q.Terms, token)
+		}
+	}
+
+	if !q.HasText() && q.FromUserID == "" {
+		return SearchQuery{}, ErrEmptySearchQuery
+	}
+	return q, nil
+}
+
+// Matches reports whether a tweet satisfies the non-textual filters of the
+// query (author, date range and cursor).
+func (q SearchQuery) Matches(t Tweet) bool {
+	if q.FromUserID != "" && t.UserID != q.FromUserID {
+		return false
+	}
+	if !q.Since.IsZero() && t.CreatedAt.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && !t.CreatedAt.Before(q.Until) {
+		return false
+	}
+	return q.Cursor.Before(t)
+}
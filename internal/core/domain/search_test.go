@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	t.Run("Success: should split terms, phrases and operators", func(t *testing.T) {
+		query, err := ParseSearchQuery(`golang "hexagonal architecture" from:@user-1 since:2025-01-01 until:2025-02-01`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"golang"}, query.Terms)
+		assert.Equal(t, []string{"hexagonal architecture"}, query.Phrases)
+		assert.Equal(t, "user-1", query.FromUserID)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), query.Since)
+		assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), query.Until)
+	})
+
+	t.Run("Failure: should reject an empty query", func(t *testing.T) {
+		_, err := ParseSearchQuery("   ")
+
+		assert.Equal(t, ErrEmptySearchQuery, err)
+	})
+
+	t.Run("Failure: should reject malformed dates", func(t *testing.T) {
+		_, err := ParseSearchQuery("golang since:ayer")
+
+		assert.Equal(t, ErrInvalidSearchQuery, err)
+	})
+}
+
+func TestCursor(t *testing.T) {
+	t.Run("Success: should round-trip through its encoded form", func(t *testing.T) {
+		cursor := Cursor{CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 123, time.UTC), ID: "tweet-1"}
+
+		decoded, err := DecodeCursor(cursor.Encode())
+
+		assert.NoError(t, err)
+		assert.Equal(t, cursor, *decoded)
+	})
+
+	t.Run("Failure: should reject garbage", func(t *testing.T) {
+		_, err := DecodeCursor("not-a-cursor")
+
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// SuggestionActivityHalfLife is how long a candidate can go without tweeting
+// before their suggestion score is halved.
+const SuggestionActivityHalfLife = 7 * 24 * time.Hour
+
+// Suggestion is an account followed by people the user follows ("friends of
+// friends"). MutualCount is how many of the user's followings follow it and
+// LastTweetAt is nil for accounts that never tweeted.
+type Suggestion struct {
+	UserID      string
+	MutualCount int
+	LastTweetAt *time.Time
+	Score       float64
+}
+
+// SuggestionScore weighs the mutual count by recent activity, so that a
+// dormant account followed by many friends does not hide active ones.
+// Accounts that never tweeted score zero and are ranked by mutuals alone.
+func SuggestionScore(mutualCount int, lastTweetAt *time.Time, now time.Time) float64 {
+	if lastTweetAt == nil {
+		return 0
+	}
+	idle := max(now.Sub(*lastTweetAt), 0)
+	return float64(mutualCount) * math.Pow(0.5, float64(idle)/float64(SuggestionActivityHalfLife))
+}
+
+// RankSuggestions scores the candidates and sorts them best first. Ties are
+// broken by mutual count and then by user ID to keep the order stable.
+func RankSuggestions(suggestions []Suggestion, now time.Time) {
+	for i := range suggestions {
+		suggestions[i].Score = SuggestionScore(suggestions[i].MutualCount, suggestions[i].LastTweetAt, now)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.MutualCount != b.MutualCount {
+			return a.MutualCount > b.MutualCount
+		}
+		return a.UserID < b.UserID
+	})
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankSuggestions(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(-d)
+		return &ts
+	}
+
+	suggestions := []Suggestion{
+		{UserID: "dormant", MutualCount: 5, LastTweetAt: at(8 * SuggestionActivityHalfLife)},
+		{UserID: "silent", MutualCount: 9},
+		{UserID: "active", MutualCount: 3, LastTweetAt: at(time.Hour)},
+		{UserID: "quiet", MutualCount: 2},
+	}
+
+	RankSuggestions(suggestions, now)
+
+	var order []string
+	for _, s := range suggestions {
+		order = append(order, s.UserID)
+	}
+	assert.Equal(t, []string{"active", "dormant", "silent", "quiet"}, order)
+	assert.InDelta(t, 5.0/256, suggestions[1].Score, 1e-9)
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+type Trend struct {
+	Tag      string
+	Count    int
+	Baseline int
+	Score    float64
+}
+
+// HashtagCount is how many tweets used a hashtag in the bucket of time
+// starting at Bucket.
+type HashtagCount struct {
+	Bucket time.Time
+	Tag    string
+	Count  int
+}
+
+// TrendScore measures how much more a hashtag is used in the current window
+// than its baseline rate would predict. Windows are compared by duration, so
+// a baseline of 24h against a window of 1h expects 1/24 of the baseline count.
+func TrendScore(count, baseline int, window, baselineWindow time.Duration) float64 {
+	expected := 0.0
+	if baselineWindow > 0 {
+		expected = float64(baseline) * float64(window) / float64(baselineWindow)
+	}
+	return (float64(count) - expected) / math.Sqrt(expected+1)
+}
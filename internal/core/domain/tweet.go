@@ -7,15 +7,51 @@ import (
 	"github.com/google/uuid"
 )
 
-const MaxTweetLength = 280
+const (
+	MaxTweetLength = 280
+	// MaxTweetEdits is how many times a tweet can be edited within the edit
+	// window.
+	MaxTweetEdits = 5
+)
 
-var ErrTweetTooLong = errors.New("tweet exceeds 280 character limit")
+var (
+	ErrTweetTooLong       = errors.New("tweet exceeds 280 character limit")
+	ErrTweetNotFound      = errors.New("tweet not found")
+	ErrNotTweetAuthor     = errors.New("only the author can change a tweet")
+	ErrEditWindowExpired  = errors.New("the tweet can no longer be edited")
+	ErrTooManyTweetEdits  = errors.New("tweet edit limit reached")
+	ErrTweetTextUnchanged = errors.New("the edit does not change the tweet")
+	// ErrTweetAlreadyPublished reports a tweet whose ID is already taken.
+	ErrTweetAlreadyPublished = errors.New("tweet already published")
+)
 
+// Tweet is a published tweet. EditedAt is nil until the author edits it.
 type Tweet struct {
 	ID        string
 	UserID    string
 	Text      string
 	CreatedAt time.Time
+	EditedAt  *time.Time
+	MediaIDs  []string
+	Poll      *Poll
+	Mentions  []Mention
+	Hashtags  []Hashtag
+	Links     []Link
+}
+
+// TweetContent is what an author submits to publish a tweet.
+type TweetContent struct {
+	Text     string
+	MediaIDs []string
+	Poll     *PollSpec
+}
+
+// TweetRevision is one version of a tweet's text. CreatedAt is when that
+// version was published: the tweet creation time for the original text and
+// the edit time for later ones.
+type TweetRevision struct {
+	Text      string
+	CreatedAt time.Time
 }
 
 func NewTweet(userID, text string) (*Tweet, error) {
@@ -27,5 +63,86 @@ func NewTweet(userID, text string) (*Tweet, error) {
 		UserID:    userID,
 		Text:      text,
 		CreatedAt: time.Now(),
+		Mentions:  ParseMentions(text),
+		Hashtags:  ParseHashtags(text),
+		Links:     ParseLinks(text),
+	}, nil
+}
+
+// Edit returns a copy of the tweet with the new text and its mentions,
+// hashtags and links parsed again; the tweet itself is left untouched so callers keep
+// the previous version around.
+func (t *Tweet) Edit(text string, now time.Time) (*Tweet, error) {
+	if len(text) > MaxTweetLength {
+		return nil, ErrTweetTooLong
+	}
+	if text == t.Text {
+		return nil, ErrTweetTextUnchanged
+	}
+
+	editedAt := now
+	return &Tweet{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Text:      text,
+		CreatedAt: t.CreatedAt,
+		EditedAt:  &editedAt,
+		MediaIDs:  t.MediaIDs,
+		Poll:      t.Poll,
+		Mentions:  ParseMentions(text),
+		Hashtags:  ParseHashtags(text),
+		Links:     ParseLinks(text),
 	}, nil
 }
+
+// Revision returns the current version of the tweet's text.
+func (t *Tweet) Revision() TweetRevision {
+	if t.EditedAt != nil {
+		return TweetRevision{Text: t.Text, CreatedAt: *t.EditedAt}
+	}
+	return TweetRevision{Text: t.Text, CreatedAt: t.CreatedAt}
+}
+
+// MentionedHandles returns the distinct handles mentioned in the tweet.
+func (t *Tweet) MentionedHandles() []string {
+	seen := make(map[string]bool, len(t.Mentions))
+	var handles []string
+	for _, m := range t.Mentions {
+		if !seen[m.Handle] {
+			seen[m.Handle] = true
+			handles = append(handles, m.Handle)
+		}
+	}
+	return handles
+}
+
+// ResolveMentions keeps only the mentions whose handle belongs to one of the
+// given users and links them to that user's ID.
+func (t *Tweet) ResolveMentions(users []User) {
+	known := make(map[string]bool, len(users))
+	for _, u := range users {
+		known[u.ID] = true
+	}
+
+	resolved := t.Mentions[:0]
+	for _, m := range t.Mentions {
+		if known[m.Handle] {
+			m.UserID = m.Handle
+			resolved = append(resolved, m)
+		}
+	}
+	t.Mentions = resolved
+}
+
+// HashtagTags returns the distinct normalized hashtags used in the tweet.
+func (t *Tweet) HashtagTags() []string {
+	seen := make(map[string]bool, len(t.Hashtags))
+	var tags []string
+	for _, h := range t.Hashtags {
+		if !seen[h.Tag] {
+			seen[h.Tag] = true
+			tags = append(tags, h.Tag)
+		}
+	}
+	return tags
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -33,3 +34,35 @@ func TestNewTweet(t *testing.T) {
 		assert.Equal(t, ErrTweetTooLong, err)
 	})
 }
+
+func TestTweet_Edit(t *testing.T) {
+	original, err := NewTweet("user-123", "Hola @ana #golang")
+	assert.NoError(t, err)
+	now := original.CreatedAt.Add(time.Minute)
+
+	t.Run("Success: should return an edited copy with its entities parsed again", func(t *testing.T) {
+		edited, err := original.Edit("Hola @juan #go", now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, original.ID, edited.ID)
+		assert.Equal(t, original.CreatedAt, edited.CreatedAt)
+		assert.Equal(t, "Hola @juan #go", edited.Text)
+		assert.Equal(t, []string{"juan"}, edited.MentionedHandles())
+		assert.Equal(t, []string{"go"}, edited.HashtagTags())
+		assert.Equal(t, TweetRevision{Text: "Hola @juan #go", CreatedAt: now}, edited.Revision())
+
+		assert.Equal(t, "Hola @ana #golang", original.Text)
+		assert.Nil(t, original.EditedAt)
+		assert.Equal(t, TweetRevision{Text: "Hola @ana #golang", CreatedAt: original.CreatedAt}, original.Revision())
+	})
+
+	t.Run("Failure: should reject a text that is too long", func(t *testing.T) {
+		_, err := original.Edit(strings.Repeat("a", 281), now)
+		assert.Equal(t, ErrTweetTooLong, err)
+	})
+
+	t.Run("Failure: should reject an edit that keeps the same text", func(t *testing.T) {
+		_, err := original.Edit(original.Text, now)
+		assert.Equal(t, ErrTweetTextUnchanged, err)
+	})
+}
@@ -1,5 +1,70 @@
 package domain
 
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrUserNotFound          = errors.New("user not found")
+	ErrUserBlocked           = errors.New("one of the users has blocked the other")
+	ErrFollowRequestNotFound = errors.New("follow request not found")
+	ErrAccountSuspended      = errors.New("the account is suspended")
+	ErrAccountDeactivated    = errors.New("the account is deactivated")
+	ErrAccountNotSuspended   = errors.New("the account is not suspended")
+	ErrAccountErasurePending = errors.New("the account is waiting to be erased")
+)
+
+// UserStatus is the state of an account. Accounts are deactivated by their
+// owner and suspended by an admin; either way they cannot publish or follow
+// and their tweets are hidden from everyone else until they are active again.
+type UserStatus string
+
+const (
+	UserActive      UserStatus = "active"
+	UserDeactivated UserStatus = "deactivated"
+	UserSuspended   UserStatus = "suspended"
+)
+
+// User is an account. The tweets of a protected account are only visible to
+// its approved followers, and following it requires an approved request.
 type User struct {
-	ID string
+	ID        string
+	Protected bool
+	Status    UserStatus
+}
+
+// Active reports whether the account is neither deactivated nor suspended.
+func (u User) Active() bool {
+	return u.StatusError() == nil
+}
+
+// StatusError returns the error for acting as the user, nil if active.
+func (u User) StatusError() error {
+	switch u.Status {
+	case UserSuspended:
+		return ErrAccountSuspended
+	case UserDeactivated:
+		return ErrAccountDeactivated
+	}
+	return nil
+}
+
+type FollowStatus string
+
+const (
+	FollowStatusFollowing FollowStatus = "following"
+	FollowStatusPending   FollowStatus = "pending"
+)
+
+// FollowRequest is a pending request from RequesterID to follow the protected
+// account TargetID.
+type FollowRequest struct {
+	TargetID    string
+	RequesterID string
+	CreatedAt   time.Time
+}
+
+func NewFollowRequest(requesterID, targetID string) FollowRequest {
+	return FollowRequest{TargetID: targetID, RequesterID: requesterID, CreatedAt: time.Now()}
 }
@@ -2,33 +2,550 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/EstefiS/uala-challenge/internal/core/domain"
 )
 
 type UserRepository interface {
-	FollowTx(ctx context.Context, userID, userToFollowID string) error
+	// FollowTx reports whether the follow is new, rather than one userID
+	// already had.
+	FollowTx(ctx context.Context, userID, userToFollowID string) (bool, error)
 	GetFollowers(ctx context.Context, userID string) ([]string, error)
+	// GetFollowedUsers returns the candidates that userID follows.
+	GetFollowedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error)
+	GetUsers(ctx context.Context, userIDs []string) ([]domain.User, error)
+	SetProtected(ctx context.Context, userID string, protected bool) error
+	SetStatus(ctx context.Context, userID string, status domain.UserStatus) error
+	// SetStatusUnlessSuspended changes the status in the same statement that
+	// checks it, returning ErrAccountSuspended for suspended accounts. It
+	// does not activate accounts waiting to be erased, returning
+	// ErrAccountErasurePending for them.
+	SetStatusUnlessSuspended(ctx context.Context, userID string, status domain.UserStatus) error
+	// SetStatusIfSuspended is its counterpart, returning
+	// ErrAccountNotSuspended for accounts that are not suspended.
+	SetStatusIfSuspended(ctx context.Context, userID string, status domain.UserStatus) error
 }
 
+// FollowRequestRepository stores the pending requests to follow protected
+// accounts. AddFollowRequest reports whether the request was added, false if
+// it was already pending. Approving a request runs the same follow and
+// timeline backfill as FollowTx; both approving and deleting return
+// ErrFollowRequestNotFound when there is no such request.
+type FollowRequestRepository interface {
+	AddFollowRequest(ctx context.Context, request domain.FollowRequest) (bool, error)
+	GetFollowRequests(ctx context.Context, targetID string, limit int) ([]domain.FollowRequest, error)
+	ApproveFollowRequestTx(ctx context.Context, targetID, requesterID string) error
+	DeleteFollowRequest(ctx context.Context, targetID, requesterID string) error
+}
+
+// RelationshipRepository stores blocks and mutes. Blocking removes the follow
+// edges in both directions and the tweets each user got from the other in
+// their timelines; muting only hides the muted user's tweets when reading a
+// timeline.
+type RelationshipRepository interface {
+	BlockTx(ctx context.Context, userID, blockedUserID string) error
+	Unblock(ctx context.Context, userID, blockedUserID string) error
+	Mute(ctx context.Context, userID, mutedUserID string) error
+	Unmute(ctx context.Context, userID, mutedUserID string) error
+	// GetBlockedUsers returns the candidates that userID has blocked or has
+	// been blocked by.
+	GetBlockedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error)
+	// GetMutedUsers returns the candidates that userID has muted.
+	GetMutedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error)
+}
+
+// TweetRepository stores tweets. DeleteTx removes the tweet together with
+// everything derived from it (timeline entries, mentions, hashtags,
+// notifications and bookmarks); GetTweet returns ErrTweetNotFound for
+// unknown IDs. Both also find the tweets moved to the archive. EditTx replaces the text and entities of before with those of
+// after and keeps before's text as a revision; GetTweetRevisions returns the
+// previous versions oldest first. PublishDraftTx publishes the tweet and
+// deletes the draft it was written in within the same transaction, failing
+// with ErrDraftNotFound if the draft is already gone.
 type TweetRepository interface {
 	PublishTx(ctx context.Context, tweet *domain.Tweet) error
+	PublishDraftTx(ctx context.Context, draft *domain.Draft, tweet *domain.Tweet) error
+	GetTweet(ctx context.Context, tweetID string) (*domain.Tweet, error)
+	DeleteTx(ctx context.Context, tweet *domain.Tweet) error
+	EditTx(ctx context.Context, before, after *domain.Tweet) error
+	GetTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error)
 }
 
+// ScheduledTweetRepository stores tweets waiting to be published.
+// ClaimDueTweets hands each due tweet to a single caller until the lease
+// expires, even across replicas; claimed tweets can no longer be updated or
+// deleted by their author (ErrScheduledTweetPublished). A claim ends with
+// CompleteScheduledTweet once published, or ReleaseScheduledTweet to retry.
+type ScheduledTweetRepository interface {
+	AddScheduledTweet(ctx context.Context, tweet *domain.ScheduledTweet) error
+	GetScheduledTweet(ctx context.Context, tweetID string) (*domain.ScheduledTweet, error)
+	GetScheduledTweets(ctx context.Context, userID string) ([]domain.ScheduledTweet, error)
+	UpdateScheduledTweet(ctx context.Context, tweet *domain.ScheduledTweet) error
+	DeleteScheduledTweet(ctx context.Context, tweetID string) error
+	ClaimDueTweets(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.ScheduledTweet, error)
+	ReleaseScheduledTweet(ctx context.Context, tweetID string) error
+	CompleteScheduledTweet(ctx context.Context, tweetID string) error
+}
+
+// DraftRepository stores drafts, most recently updated first. Drafts are
+// published through TweetRepository.PublishDraftTx.
+type DraftRepository interface {
+	AddDraft(ctx context.Context, draft *domain.Draft) error
+	GetDraft(ctx context.Context, draftID string) (*domain.Draft, error)
+	GetDrafts(ctx context.Context, userID string) ([]domain.Draft, error)
+	UpdateDraft(ctx context.Context, draft *domain.Draft) error
+	DeleteDraft(ctx context.Context, userID, draftID string) error
+}
+
+// BookmarkRepository lists bookmarks newest bookmark first.
+type BookmarkRepository interface {
+	AddBookmark(ctx context.Context, userID, tweetID string, bookmarkedAt time.Time) error
+	RemoveBookmark(ctx context.Context, userID, tweetID string) error
+	GetBookmarks(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Bookmark, error)
+}
+
+// ListRepository stores lists and their members. GetListTimeline is computed
+// on read from the members' tweets, newest first, starting right after
+// cursor.
+type ListRepository interface {
+	CreateList(ctx context.Context, list *domain.List) error
+	GetList(ctx context.Context, listID string) (*domain.List, error)
+	GetLists(ctx context.Context, ownerID string) ([]domain.List, error)
+	UpdateList(ctx context.Context, list *domain.List) error
+	DeleteList(ctx context.Context, listID string) error
+	AddListMember(ctx context.Context, listID, userID string) error
+	RemoveListMember(ctx context.Context, listID, userID string) error
+	GetListMembers(ctx context.Context, listID string) ([]string, error)
+	GetMemberLists(ctx context.Context, userID string) ([]string, error)
+	GetListTimeline(ctx context.Context, listID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error)
+}
+
+// TimelineRepository returns up to limit tweets of a user's timeline newest
+// first, starting right after cursor (nil for the first page).
 type TimelineRepository interface {
-	Get(ctx context.Context, userID string, limit int) ([]domain.Tweet, error)
+	Get(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error)
+}
+
+type FilterRepository interface {
+	AddFilter(ctx context.Context, filter *domain.ContentFilter) error
+	DeleteFilter(ctx context.Context, userID, filterID string) error
+	GetFilters(ctx context.Context, userID string) ([]domain.ContentFilter, error)
+}
+
+type MentionRepository interface {
+	GetMentions(ctx context.Context, userID string, limit int) ([]domain.Tweet, error)
+}
+
+// HashtagRepository looks tweets up by hashtag. CountHashtags counts, per tag,
+// the tweets published in [since, until) by public, active accounts, since it
+// feeds the public trends. CountHashtagBuckets counts the same tweets per tag
+// and per bucket of bucketSize, aligned on the Unix epoch.
+type HashtagRepository interface {
+	GetHashtagTweets(ctx context.Context, tag string, limit int) ([]domain.Tweet, error)
+	CountHashtags(ctx context.Context, since, until time.Time) (map[string]int, error)
+	CountHashtagBuckets(ctx context.Context, since, until time.Time, bucketSize time.Duration) ([]domain.HashtagCount, error)
+}
+
+// TrendRepository stores the computed trends. RebuildHashtagCounts recomputes
+// from the stored tweets whatever hashtag counters the adapter keeps; it does
+// nothing for adapters that count the stored tweets directly.
+type TrendRepository interface {
+	SaveTrends(ctx context.Context, trends []domain.Trend) error
+	GetTrends(ctx context.Context) ([]domain.Trend, error)
+	RebuildHashtagCounts(ctx context.Context) error
+}
+
+// SuggestionRepository walks the follow graph. GetUserIDs pages through every
+// user ordered by ID, starting right after afterID.
+type SuggestionRepository interface {
+	GetUserIDs(ctx context.Context, afterID string, limit int) ([]string, error)
+	GetSuggestionCandidates(ctx context.Context, userID string, limit int) ([]domain.Suggestion, error)
+}
+
+// SuggestionCache keeps the ranked suggestions of each user between two
+// refreshes. GetSuggestions reports ok=false when none are stored.
+// AcquireRefreshLease reports whether the caller got the refresh lease, which
+// is held by a single caller across replicas until ttl elapses.
+type SuggestionCache interface {
+	SaveSuggestions(ctx context.Context, userID string, suggestions []domain.Suggestion) error
+	GetSuggestions(ctx context.Context, userID string) (suggestions []domain.Suggestion, ok bool, err error)
+	AcquireRefreshLease(ctx context.Context, ttl time.Duration) (bool, error)
+}
+
+type SearchRepository interface {
+	Search(ctx context.Context, query domain.SearchQuery, limit int) ([]domain.Tweet, error)
+}
+
+type NotificationRepository interface {
+	AddNotifications(ctx context.Context, notifications []domain.Notification) error
+	GetNotifications(ctx context.Context, userID string, limit int) ([]domain.Notification, error)
+	MarkNotificationsRead(ctx context.Context, userID string, notificationIDs []string) error
+}
+
+// MessageRepository stores direct message conversations. CreateConversation
+// returns the existing conversation when the same two users already have a
+// one-to-one one; conversations are listed by latest activity.
+type MessageRepository interface {
+	CreateConversation(ctx context.Context, conversation *domain.Conversation) (*domain.Conversation, error)
+	GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error)
+	GetConversations(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Conversation, error)
+	AddMessage(ctx context.Context, message *domain.Message) error
+	GetMessages(ctx context.Context, conversationID string, cursor *domain.Cursor, limit int) ([]domain.Message, error)
+	MarkConversationRead(ctx context.Context, conversationID, userID string, readAt time.Time) error
+}
+
+// EventBroker delivers events to the subscribers of their topic. Subscribe
+// first replays the retained events published after lastEventID and then
+// streams new ones; the returned channel is closed when ctx is done or when
+// the subscriber falls more than buffer events behind. DeleteHistory drops
+// the retained events of the topics; DeleteUserEvents only drops the ones
+// that involve the user, leaving the rest to be replayed. Event IDs increase within a topic, in
+// the order in which subscribers receive the events.
+type EventBroker interface {
+	Publish(ctx context.Context, events ...domain.Event) error
+	Subscribe(ctx context.Context, topic, lastEventID string, buffer int) (<-chan domain.Event, error)
+	DeleteHistory(ctx context.Context, topics ...string) error
+	DeleteUserEvents(ctx context.Context, userID string, topics ...string) error
+}
+
+// PollRepository keeps the votes of the polls stored with their tweets.
+// AddPollVote records a single vote per user and only while the poll is open,
+// failing with ErrPollNotFound, ErrPollClosed or ErrAlreadyVoted otherwise.
+// GetPollVote returns nil if the user has not voted.
+type PollRepository interface {
+	AddPollVote(ctx context.Context, tweetID, userID string, option int, now time.Time) error
+	GetPollTallies(ctx context.Context, tweetID string, options int) ([]int, error)
+	GetPollVote(ctx context.Context, tweetID, userID string) (*int, error)
+}
+
+// MediaRepository stores the metadata of uploaded media; the bytes live in
+// the BlobStore. GetMedia returns ErrMediaNotFound for unknown IDs.
+type MediaRepository interface {
+	AddMedia(ctx context.Context, media *domain.Media) error
+	GetMedia(ctx context.Context, mediaID string) (*domain.Media, error)
+}
+
+// BlobStore keeps opaque blobs under slash-separated keys. Get returns
+// ErrBlobNotFound for unknown keys.
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// LinkRepository stores the preview cards of the links found in tweets, one
+// per URL. ClaimPendingLinks hands up to limit links without a preview to a
+// single caller, even across replicas, until the lease expires; a claim ends
+// with SaveLinkPreview or with RetryLink, which makes the link due again at
+// retryAt.
+type LinkRepository interface {
+	ClaimPendingLinks(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.PendingLink, error)
+	SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error
+	RetryLink(ctx context.Context, url string, retryAt time.Time) error
+}
+
+// PageFetcher downloads the HTML of the web page at url. Implementations
+// decide which destinations are reachable and how much of a page is read.
+// Failures that retrying would not fix wrap ErrPageNotUnfurlable.
+type PageFetcher interface {
+	FetchPage(ctx context.Context, url string) ([]byte, error)
+}
+
+// ModerationPolicy reviews content before it is published.
+type ModerationPolicy interface {
+	Review(ctx context.Context, userID string, content domain.TweetContent) (domain.ModerationDecision, error)
+}
+
+// HeldTweetRepository stores the tweets waiting for review, oldest first.
+// TakeHeldTweet removes and returns a held tweet in one step, so only one
+// moderator can ever decide on it; it returns ErrHeldTweetNotFound for
+// unknown IDs.
+type HeldTweetRepository interface {
+	AddHeldTweet(ctx context.Context, tweet *domain.HeldTweet) error
+	GetHeldTweets(ctx context.Context, limit int) ([]domain.HeldTweet, error)
+	TakeHeldTweet(ctx context.Context, tweetID string) (*domain.HeldTweet, error)
+}
+
+// ReportRepository stores user reports. AddReport fails with
+// ErrAlreadyReported while the reporter has an unresolved report on the same
+// target; GetReport returns ErrReportNotFound for unknown IDs. GetReports
+// returns up to limit reports with the given status, oldest first.
+// UpdateReport fails with ErrReportResolved if the report was resolved in
+// the meantime, so a decision is never overwritten.
+type ReportRepository interface {
+	AddReport(ctx context.Context, report *domain.Report) error
+	GetReport(ctx context.Context, reportID string) (*domain.Report, error)
+	GetReports(ctx context.Context, status domain.ReportStatus, limit int) ([]domain.Report, error)
+	UpdateReport(ctx context.Context, report *domain.Report) error
+}
+
+// AccountDataRepository reads everything kept about a user for the data
+// export and erases it on request. GetUserTweets returns all the user's
+// tweets newest first and GetFollowing the users they follow.
+// GetNotifiedUsers returns the users notified of something the user did, and
+// GetFollowersOfFollowers the users who follow one of the user's followers,
+// i.e. those who may be suggested to follow them. GetErasureRequests returns up to limit users waiting to be erased, oldest
+// request first; EraseUserTx removes the account together with everything
+// that belongs to it, its erasure request included.
+type AccountDataRepository interface {
+	GetUserTweets(ctx context.Context, userID string) ([]domain.Tweet, error)
+	GetFollowing(ctx context.Context, userID string) ([]string, error)
+	GetNotifiedUsers(ctx context.Context, userID string) ([]string, error)
+	GetFollowersOfFollowers(ctx context.Context, userID string) ([]string, error)
+	GetUserMedia(ctx context.Context, userID string) ([]domain.Media, error)
+	RequestErasure(ctx context.Context, userID string, requestedAt time.Time) error
+	GetErasureRequests(ctx context.Context, limit int) ([]string, error)
+	EraseUserTx(ctx context.Context, userID string) error
+}
+
+// ProfileRepository returns up to limit tweets published by a user newest
+// first, starting right after cursor, archived tweets included.
+type ProfileRepository interface {
+	GetProfileTweets(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error)
+}
+
+// RetentionRepository removes old data one bounded batch per call, each in
+// its own short transaction, and returns how many rows it touched.
+// TrimTimelinesBefore drops up to limit timeline entries of tweets published
+// before cutoff; TrimTimelines keeps the newest keep entries of each of the
+// users' timelines. ArchiveTweets moves up to limit tweets published before
+// cutoff, oldest first, out of the live tables into the archive, together
+// with their entities; they are then only readable through ProfileRepository
+// and the data export. GetGrownTimelines returns up to limit users whose
+// timelines got entries since TrimTimelines last trimmed them.
+type RetentionRepository interface {
+	GetGrownTimelines(ctx context.Context, limit int) ([]string, error)
+	TrimTimelinesBefore(ctx context.Context, cutoff time.Time, limit int) (int, error)
+	TrimTimelines(ctx context.Context, userIDs []string, keep int) (int, error)
+	ArchiveTweets(ctx context.Context, cutoff time.Time, limit int) (int, error)
+}
+
+// PartitionRepository manages the monthly partitions of the tables in
+// domain.TweetsTable and domain.TimelinesTable. GetPartitions lists the
+// monthly partitions of a table. CreatePartition is a no-op for a partition
+// that already exists. DetachPartition detaches the partition from its table
+// and keeps it, rows and all, out of the way of the queries; with onlyIfEmpty
+// it leaves a partition that still has rows in place and reports false.
+// DropPartition drops a detached partition for good.
+type PartitionRepository interface {
+	GetPartitions(ctx context.Context, table string) ([]domain.Partition, error)
+	CreatePartition(ctx context.Context, partition domain.Partition) error
+	DetachPartition(ctx context.Context, partition domain.Partition, onlyIfEmpty bool) (bool, error)
+	DropPartition(ctx context.Context, partition domain.Partition) error
 }
 
 // ==========================
 
+// TweetService publishes tweets once the moderation policy allows them.
+// PublishTweet fails with a RejectionError for rejected content and with
+// ErrTweetHeldForReview when the tweet has been queued for a moderator;
+// PublishHeldTweet publishes a tweet a moderator approved.
+// PublishScheduledTweet publishes a scheduled tweet under its ID, as of the
+// time it actually goes out; if that tweet already exists it fails with
+// ErrTweetAlreadyPublished and returns it, so retries never publish twice.
+// PublishDraft publishes a draft and deletes it in one step; it fails with
+// ErrDraftNotFound if the draft was published or deleted meanwhile.
 type TweetService interface {
-	PublishTweet(ctx context.Context, userID, text string) (*domain.Tweet, error)
+	PublishTweet(ctx context.Context, userID string, content domain.TweetContent) (*domain.Tweet, error)
+	PublishHeldTweet(ctx context.Context, held *domain.HeldTweet) (*domain.Tweet, error)
+	PublishScheduledTweet(ctx context.Context, scheduled *domain.ScheduledTweet) (*domain.Tweet, error)
+	PublishDraft(ctx context.Context, draft *domain.Draft) (*domain.Tweet, error)
+	DeleteTweet(ctx context.Context, userID, tweetID string) error
+	EditTweet(ctx context.Context, userID, tweetID, text string) (*domain.Tweet, error)
+	GetTweetHistory(ctx context.Context, viewerID, tweetID string) ([]domain.TweetRevision, error)
+}
+
+// ScheduleService keeps tweets to be published later. PublishDueTweets is
+// meant to be run periodically by a background job on every replica.
+type ScheduleService interface {
+	ScheduleTweet(ctx context.Context, userID, text string, publishAt time.Time) (*domain.ScheduledTweet, error)
+	GetScheduledTweets(ctx context.Context, userID string) ([]domain.ScheduledTweet, error)
+	UpdateScheduledTweet(ctx context.Context, userID, tweetID, text string, publishAt time.Time) (*domain.ScheduledTweet, error)
+	CancelScheduledTweet(ctx context.Context, userID, tweetID string) error
+	PublishDueTweets(ctx context.Context) error
+}
+
+type DraftService interface {
+	CreateDraft(ctx context.Context, userID, text string) (*domain.Draft, error)
+	GetDrafts(ctx context.Context, userID string) ([]domain.Draft, error)
+	UpdateDraft(ctx context.Context, userID, draftID, text string) (*domain.Draft, error)
+	DeleteDraft(ctx context.Context, userID, draftID string) error
+	PublishDraft(ctx context.Context, userID, draftID string) (*domain.Tweet, error)
+}
+
+type BookmarkService interface {
+	BookmarkTweet(ctx context.Context, userID, tweetID string) error
+	RemoveBookmark(ctx context.Context, userID, tweetID string) error
+	GetBookmarks(ctx context.Context, userID, cursor string) (domain.TweetPage, error)
+}
+
+type SuggestionService interface {
+	GetSuggestions(ctx context.Context, userID string) ([]domain.Suggestion, error)
+	RefreshSuggestions(ctx context.Context) error
+}
+
+// ListService manages the lists of a user. Private lists behave as missing
+// for everyone but their owner.
+type ListService interface {
+	CreateList(ctx context.Context, userID, name, description string, private bool) (*domain.List, error)
+	GetLists(ctx context.Context, userID string) ([]domain.List, error)
+	GetList(ctx context.Context, userID, listID string) (*domain.List, error)
+	UpdateList(ctx context.Context, userID, listID, name, description string, private bool) (*domain.List, error)
+	DeleteList(ctx context.Context, userID, listID string) error
+	AddMember(ctx context.Context, userID, listID, memberID string) error
+	RemoveMember(ctx context.Context, userID, listID, memberID string) error
+	GetMembers(ctx context.Context, userID, listID string) ([]string, error)
+	GetListTimeline(ctx context.Context, userID, listID, cursor string) (domain.TweetPage, error)
 }
 
+// FollowService follows public accounts right away and sends a follow
+// request to protected ones, reporting which of the two happened.
 type FollowService interface {
-	FollowUser(ctx context.Context, currentUserID, userToFollowID string) error
+	FollowUser(ctx context.Context, currentUserID, userToFollowID string) (domain.FollowStatus, error)
+	GetFollowRequests(ctx context.Context, userID string) ([]domain.FollowRequest, error)
+	ApproveFollowRequest(ctx context.Context, userID, requesterID string) error
+	RejectFollowRequest(ctx context.Context, userID, requesterID string) error
+}
+
+type AccountService interface {
+	SetProtected(ctx context.Context, userID string, protected bool) error
+	Deactivate(ctx context.Context, userID string) error
+	Reactivate(ctx context.Context, userID string) error
+}
+
+// PrivacyService exports the data of a user and erases accounts on request.
+// RequestErasure hides the account right away and cannot be undone; the data
+// is erased by EraseAccounts, meant to be run periodically by a background
+// job.
+type PrivacyService interface {
+	ExportData(ctx context.Context, userID string) (*domain.DataExport, error)
+	RequestErasure(ctx context.Context, userID string) error
+	EraseAccounts(ctx context.Context) error
+}
+
+// ProfileService lists the tweets of a user, archived ones included, as
+// seen by the viewer.
+type ProfileService interface {
+	GetProfileTweets(ctx context.Context, viewerID, userID, cursor string) (domain.TweetPage, error)
+}
+
+// RetentionService trims timelines and archives old tweets according to the
+// configured policy. ApplyRetention is meant to be run periodically by a
+// background job.
+type RetentionService interface {
+	ApplyRetention(ctx context.Context) error
+}
+
+// PartitionService keeps the partitions of the coming months ready and
+// detaches the ones left behind by the retention policy. PreparePartitions
+// only creates the missing partitions and is meant to be run at startup;
+// MaintainPartitions also detaches, and is meant to be run periodically by a
+// background job.
+type PartitionService interface {
+	PreparePartitions(ctx context.Context) error
+	MaintainPartitions(ctx context.Context) error
+}
+
+type RelationshipService interface {
+	BlockUser(ctx context.Context, currentUserID, userToBlockID string) error
+	UnblockUser(ctx context.Context, currentUserID, userToUnblockID string) error
+	MuteUser(ctx context.Context, currentUserID, userToMuteID string) error
+	UnmuteUser(ctx context.Context, currentUserID, userToUnmuteID string) error
 }
 
+// TimelineService returns timeline pages with the user's content filters
+// applied; a page is only shorter than the page size when the timeline ends.
 type TimelineService interface {
-	GetUserTimeline(ctx context.Context, userID string) ([]domain.Tweet, error)
+	GetUserTimeline(ctx context.Context, userID, cursor string) (domain.TweetPage, error)
+}
+
+type FilterService interface {
+	AddFilter(ctx context.Context, userID, value string, expiresAt *time.Time) (*domain.ContentFilter, error)
+	RemoveFilter(ctx context.Context, userID, filterID string) error
+	GetFilters(ctx context.Context, userID string) ([]domain.ContentFilter, error)
+}
+
+type MentionService interface {
+	GetUserMentions(ctx context.Context, userID string) ([]domain.Tweet, error)
+}
+
+type HashtagService interface {
+	GetHashtagTweets(ctx context.Context, userID, tag string) ([]domain.Tweet, error)
+	GetTrends(ctx context.Context) ([]domain.Trend, error)
+	RefreshTrends(ctx context.Context) error
+}
+
+type SearchService interface {
+	Search(ctx context.Context, userID, rawQuery, cursor string) (domain.TweetPage, error)
+}
+
+// PollService lets users vote in the polls of the tweets they can see and
+// read the results once they are allowed to.
+type PollService interface {
+	Vote(ctx context.Context, userID, tweetID string, option int) (*domain.PollResults, error)
+	GetPollResults(ctx context.Context, viewerID, tweetID string) (*domain.PollResults, error)
+}
+
+// ModerationService holds the admin actions: the review queue of held
+// tweets, suspending accounts and removing tweets. Unsuspending makes the
+// account active, even if its owner had deactivated it before.
+type ModerationService interface {
+	GetHeldTweets(ctx context.Context) ([]domain.HeldTweet, error)
+	ApproveHeldTweet(ctx context.Context, tweetID string) (*domain.Tweet, error)
+	RejectHeldTweet(ctx context.Context, tweetID string) error
+	SuspendUser(ctx context.Context, adminID, userID string) error
+	UnsuspendUser(ctx context.Context, adminID, userID string) error
+	RemoveTweet(ctx context.Context, adminID, tweetID string) error
+}
+
+// ReportService lets users report tweets and accounts, and admins work
+// through the reports.
+type ReportService interface {
+	CreateReport(ctx context.Context, reporterID string, input domain.ReportInput) (*domain.Report, error)
+	GetReports(ctx context.Context, status domain.ReportStatus) ([]domain.Report, error)
+	TriageReport(ctx context.Context, adminID, reportID string) (*domain.Report, error)
+	ResolveReport(ctx context.Context, adminID, reportID string, resolution domain.ReportResolution, note string) (*domain.Report, error)
+}
+
+// LinkService turns the links of tweets into preview cards. UnfurlLinks is
+// meant to be run periodically by a background job.
+type LinkService interface {
+	UnfurlLinks(ctx context.Context) error
+}
+
+// MediaService turns uploaded images into media that can be attached to
+// tweets. GetMediaBlob returns the processed original, or its thumbnail, if
+// the viewer may see the owner's tweets, and ErrMediaNotFound otherwise.
+type MediaService interface {
+	UploadMedia(ctx context.Context, userID string, data []byte) (*domain.Media, error)
+	GetMediaBlob(ctx context.Context, viewerID, mediaID string, thumbnail bool) (*domain.MediaBlob, error)
+}
+
+// Notifier delivers notifications on a best-effort basis: failures are logged
+// by the implementation and never fail the action that triggered them.
+type Notifier interface {
+	Notify(ctx context.Context, notifications ...domain.Notification)
+}
+
+type NotificationService interface {
+	Notifier
+	GetNotifications(ctx context.Context, userID string) (domain.NotificationFeed, error)
+	MarkAsRead(ctx context.Context, userID string, notificationIDs []string) error
+}
+
+// MessageService exposes conversations only to their participants; any other
+// user gets ErrConversationNotFound.
+type MessageService interface {
+	StartConversation(ctx context.Context, userID string, participantIDs []string) (*domain.Conversation, error)
+	GetConversations(ctx context.Context, userID, cursor string) (domain.ConversationPage, error)
+	SendMessage(ctx context.Context, userID, conversationID, text string) (*domain.Message, error)
+	GetMessages(ctx context.Context, userID, conversationID, cursor string) (domain.MessagePage, error)
+	MarkConversationRead(ctx context.Context, userID, conversationID string) error
+}
+
+type StreamService interface {
+	SubscribeTimeline(ctx context.Context, userID, lastEventID string) (<-chan domain.Event, error)
+	SubscribeNotifications(ctx context.Context, userID, lastEventID string) (<-chan domain.Event, error)
+	SubscribeThread(ctx context.Context, userID, tweetID, lastEventID string) (<-chan domain.Event, error)
 }
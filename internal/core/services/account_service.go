@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+type accountService struct {
+	userRepo ports.UserRepository
+}
+
+func NewAccountService(userRepo ports.UserRepository) ports.AccountService {
+	return &accountService{userRepo: userRepo}
+}
+
+// SetProtected changes whether new followers need approval. Existing
+// followers keep following; pending requests stay pending until answered.
+func (s *accountService) SetProtected(ctx context.Context, userID string, protected bool) error {
+	return s.userRepo.SetProtected(ctx, userID, protected)
+}
+
+// Deactivate hides the user's tweets from everyone else and stops them from
+// publishing and following until they reactivate the account. Suspended
+// users stay suspended.
+func (s *accountService) Deactivate(ctx context.Context, userID string) error {
+	return s.userRepo.SetStatusUnlessSuspended(ctx, userID, domain.UserDeactivated)
+}
+
+// Reactivate makes a deactivated account active again and its tweets
+// visible where they were. Only an admin can lift a suspension, and an
+// account waiting to be erased cannot be reactivated.
+func (s *accountService) Reactivate(ctx context.Context, userID string) error {
+	return s.userRepo.SetStatusUnlessSuspended(ctx, userID, domain.UserActive)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccountService_Deactivate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success: should deactivate the account", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		accountService := NewAccountService(mockRepo)
+
+		mockRepo.On("SetStatusUnlessSuspended", ctx, "ana", domain.UserDeactivated).Return(nil)
+
+		err := accountService.Deactivate(ctx, "ana")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not let suspended users lift the suspension", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		accountService := NewAccountService(mockRepo)
+
+		mockRepo.On("SetStatusUnlessSuspended", ctx, "ana", mock.Anything).Return(domain.ErrAccountSuspended)
+
+		assert.Equal(t, domain.ErrAccountSuspended, accountService.Deactivate(ctx, "ana"))
+		assert.Equal(t, domain.ErrAccountSuspended, accountService.Reactivate(ctx, "ana"))
+		mockRepo.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const bookmarksPageSize = 20
+
+type bookmarkService struct {
+	bookmarkRepo ports.BookmarkRepository
+	tweetRepo    ports.TweetRepository
+	visibility   tweetVisibility
+	now          func() time.Time
+}
+
+func NewBookmarkService(bookmarkRepo ports.BookmarkRepository, tweetRepo ports.TweetRepository, userRepo ports.UserRepository, now func() time.Time) ports.BookmarkService {
+	return &bookmarkService{
+		bookmarkRepo: bookmarkRepo,
+		tweetRepo:    tweetRepo,
+		visibility:   tweetVisibility{userRepo: userRepo},
+		now:          now,
+	}
+}
+
+// BookmarkTweet only accepts tweets the user is allowed to see; the others
+// are reported as not found.
+func (s *bookmarkService) BookmarkTweet(ctx context.Context, userID, tweetID string) error {
+	tweet, err := s.tweetRepo.GetTweet(ctx, tweetID)
+	if err != nil {
+		return err
+	}
+	visible, err := s.visibility.filter(ctx, userID, []domain.Tweet{*tweet})
+	if err != nil {
+		return err
+	}
+	if len(visible) == 0 {
+		return domain.ErrTweetNotFound
+	}
+
+	return s.bookmarkRepo.AddBookmark(ctx, userID, tweetID, s.now())
+}
+
+func (s *bookmarkService) RemoveBookmark(ctx context.Context, userID, tweetID string) error {
+	return s.bookmarkRepo.RemoveBookmark(ctx, userID, tweetID)
+}
+
+func (s *bookmarkService) GetBookmarks(ctx context.Context, userID, cursor string) (domain.TweetPage, error) {
+	decoded, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+
+	bookmarks, err := s.bookmarkRepo.GetBookmarks(ctx, userID, decoded, bookmarksPageSize+1)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+
+	page := domain.NewBookmarkPage(bookmarks, bookmarksPageSize)
+	if page.Tweets, err = s.visibility.filter(ctx, userID, page.Tweets); err != nil {
+		return domain.TweetPage{}, err
+	}
+	return page, nil
+}
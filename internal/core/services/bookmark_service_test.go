@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookmarkService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	t.Run("Success: should bookmark a visible tweet at the current time", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		bookmarkService := NewBookmarkService(mockRepo, mockRepo, mockRepo, clock)
+
+		tweet := &domain.Tweet{ID: "tweet-1", UserID: "author"}
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"author"}).Return([]domain.User{{ID: "author"}}, nil)
+		mockRepo.On("AddBookmark", ctx, "reader", "tweet-1", now).Return(nil)
+
+		err := bookmarkService.BookmarkTweet(ctx, "reader", "tweet-1")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not bookmark a protected tweet the user cannot see", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		bookmarkService := NewBookmarkService(mockRepo, mockRepo, mockRepo, clock)
+
+		tweet := &domain.Tweet{ID: "tweet-1", UserID: "author"}
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"author"}).Return([]domain.User{{ID: "author", Protected: true}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, "reader", []string{"author"}).Return([]string{}, nil)
+
+		err := bookmarkService.BookmarkTweet(ctx, "reader", "tweet-1")
+
+		assert.Equal(t, domain.ErrTweetNotFound, err)
+		mockRepo.AssertNotCalled(t, "AddBookmark", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should page bookmarks by when they were saved", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		bookmarkService := NewBookmarkService(mockRepo, mockRepo, mockRepo, clock)
+
+		bookmarks := make([]domain.Bookmark, bookmarksPageSize+1)
+		for i := range bookmarks {
+			bookmarks[i] = domain.Bookmark{
+				Tweet:        domain.Tweet{ID: fmt.Sprintf("tweet-%d", i), UserID: "reader", CreatedAt: now.Add(-time.Hour)},
+				BookmarkedAt: now.Add(-time.Duration(i) * time.Minute),
+			}
+		}
+		mockRepo.On("GetBookmarks", ctx, "reader", (*domain.Cursor)(nil), bookmarksPageSize+1).Return(bookmarks, nil)
+
+		page, err := bookmarkService.GetBookmarks(ctx, "reader", "")
+
+		require.NoError(t, err)
+		assert.Len(t, page.Tweets, bookmarksPageSize)
+		cursor, err := domain.DecodeCursor(page.NextCursor)
+		require.NoError(t, err)
+		last := bookmarks[bookmarksPageSize-1]
+		assert.True(t, last.BookmarkedAt.Equal(cursor.CreatedAt))
+		assert.Equal(t, last.Tweet.ID, cursor.ID)
+	})
+}
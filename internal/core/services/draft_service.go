@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const maxDraftsPerUser = 100
+
+var ErrTooManyDrafts = errors.New("draft limit reached")
+
+type draftService struct {
+	draftRepo ports.DraftRepository
+	tweetSvc  ports.TweetService
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+func NewDraftService(draftRepo ports.DraftRepository, tweetSvc ports.TweetService, logger *slog.Logger, now func() time.Time) ports.DraftService {
+	return &draftService{
+		draftRepo: draftRepo,
+		tweetSvc:  tweetSvc,
+		logger:    logger.With("component", "DraftService"),
+		now:       now,
+	}
+}
+
+func (s *draftService) CreateDraft(ctx context.Context, userID, text string) (*domain.Draft, error) {
+	draft, err := domain.NewDraft(userID, text, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	drafts, err := s.draftRepo.GetDrafts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(drafts) >= maxDraftsPerUser {
+		return nil, ErrTooManyDrafts
+	}
+
+	if err := s.draftRepo.AddDraft(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (s *draftService) GetDrafts(ctx context.Context, userID string) ([]domain.Draft, error) {
+	return s.draftRepo.GetDrafts(ctx, userID)
+}
+
+func (s *draftService) UpdateDraft(ctx context.Context, userID, draftID, text string) (*domain.Draft, error) {
+	draft, err := s.ownedDraft(ctx, userID, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if err := draft.Update(text, s.now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.draftRepo.UpdateDraft(ctx, draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (s *draftService) DeleteDraft(ctx context.Context, userID, draftID string) error {
+	return s.draftRepo.DeleteDraft(ctx, userID, draftID)
+}
+
+// PublishDraft turns the draft into a tweet through the TweetService, which
+// deletes the draft in the same transaction as the tweet insert: publishing
+// it twice at the same time, e.g. from two devices, yields a single tweet,
+// and a draft whose tweet could not be published stays in place. A tweet
+// held for review leaves the drafts for good.
+func (s *draftService) PublishDraft(ctx context.Context, userID, draftID string) (*domain.Tweet, error) {
+	draft, err := s.ownedDraft(ctx, userID, draftID)
+	if err != nil {
+		return nil, err
+	}
+
+	tweet, err := s.tweetSvc.PublishDraft(ctx, draft)
+	if errors.Is(err, domain.ErrTweetHeldForReview) {
+		if err := s.draftRepo.DeleteDraft(ctx, userID, draftID); err != nil {
+			s.logger.Error("Failed to delete draft held for review", "error", err, "draftID", draftID, "userID", userID)
+		}
+	}
+	return tweet, err
+}
+
+// ownedDraft loads a draft of the user; other users' drafts are reported as
+// not found.
+func (s *draftService) ownedDraft(ctx context.Context, userID, draftID string) (*domain.Draft, error) {
+	draft, err := s.draftRepo.GetDraft(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if draft.UserID != userID {
+		return nil, domain.ErrDraftNotFound
+	}
+	return draft, nil
+}
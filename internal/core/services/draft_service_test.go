@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	clock := func() time.Time { return now }
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	newService := func(mockRepo *mocks.Repository) ports.DraftService {
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+		return NewDraftService(mockRepo, tweetService, discardLogger, clock)
+	}
+
+	t.Run("Success: should publish the draft as a tweet", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		draftService := newService(mockRepo)
+
+		draft := &domain.Draft{ID: "draft-1", UserID: "ana", Text: "Listo para publicar"}
+		mockRepo.On("GetDraft", ctx, "draft-1").Return(draft, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("PublishDraftTx", ctx, draft, mock.MatchedBy(func(tweet *domain.Tweet) bool {
+			return tweet.UserID == "ana" && tweet.Text == "Listo para publicar"
+		})).Return(nil)
+
+		tweet, err := draftService.PublishDraft(ctx, "ana", "draft-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Listo para publicar", tweet.Text)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "PublishTx", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should keep a draft that is too long to publish", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		draftService := newService(mockRepo)
+
+		draft := &domain.Draft{ID: "draft-1", UserID: "ana", Text: strings.Repeat("a", domain.MaxTweetLength+1)}
+		mockRepo.On("GetDraft", ctx, "draft-1").Return(draft, nil)
+
+		_, err := draftService.PublishDraft(ctx, "ana", "draft-1")
+
+		assert.Equal(t, domain.ErrTweetTooLong, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "PublishDraftTx", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "DeleteDraft", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should report a draft another request already published", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		draftService := newService(mockRepo)
+
+		draft := &domain.Draft{ID: "draft-1", UserID: "ana", Text: "Hola"}
+		mockRepo.On("GetDraft", ctx, "draft-1").Return(draft, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("PublishDraftTx", ctx, draft, mock.AnythingOfType("*domain.Tweet")).Return(domain.ErrDraftNotFound)
+
+		_, err := draftService.PublishDraft(ctx, "ana", "draft-1")
+
+		assert.Equal(t, domain.ErrDraftNotFound, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not publish other users' drafts", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		draftService := newService(mockRepo)
+
+		mockRepo.On("GetDraft", ctx, "draft-1").Return(&domain.Draft{ID: "draft-1", UserID: "ana", Text: "Hola"}, nil)
+
+		_, err := draftService.PublishDraft(ctx, "beto", "draft-1")
+
+		assert.Equal(t, domain.ErrDraftNotFound, err)
+		mockRepo.AssertNotCalled(t, "PublishDraftTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should not update other users' drafts", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		draftService := newService(mockRepo)
+
+		mockRepo.On("GetDraft", ctx, "draft-1").Return(&domain.Draft{ID: "draft-1", UserID: "ana"}, nil)
+
+		_, err := draftService.UpdateDraft(ctx, "beto", "draft-1", "Hola")
+
+		assert.Equal(t, domain.ErrDraftNotFound, err)
+		mockRepo.AssertNotCalled(t, "UpdateDraft", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const maxFiltersPerUser = 100
+
+var ErrTooManyFilters = errors.New("content filter limit reached")
+
+type filterService struct {
+	filterRepo ports.FilterRepository
+	now        func() time.Time
+}
+
+func NewFilterService(filterRepo ports.FilterRepository, now func() time.Time) ports.FilterService {
+	return &filterService{filterRepo: filterRepo, now: now}
+}
+
+func (s *filterService) AddFilter(ctx context.Context, userID, value string, expiresAt *time.Time) (*domain.ContentFilter, error) {
+	filter, err := domain.NewContentFilter(userID, value, expiresAt, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := s.GetFilters(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) >= maxFiltersPerUser {
+		return nil, ErrTooManyFilters
+	}
+
+	if err := s.filterRepo.AddFilter(ctx, filter); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func (s *filterService) RemoveFilter(ctx context.Context, userID, filterID string) error {
+	return s.filterRepo.DeleteFilter(ctx, userID, filterID)
+}
+
+// GetFilters returns the user's filters that have not expired yet.
+func (s *filterService) GetFilters(ctx context.Context, userID string) ([]domain.ContentFilter, error) {
+	filters, err := s.filterRepo.GetFilters(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	active := make([]domain.ContentFilter, 0, len(filters))
+	for _, f := range filters {
+		if f.ActiveAt(now) {
+			active = append(active, f)
+		}
+	}
+	return active, nil
+}
@@ -4,22 +4,87 @@ import (
 	"context"
 	"errors"
 
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/EstefiS/uala-challenge/internal/core/ports"
 )
 
+const followRequestsPageSize = 100
+
 var ErrSelfFollow = errors.New("a user cannot follow themselves")
 
 type followService struct {
-	userRepo ports.UserRepository
+	userRepo          ports.UserRepository
+	followRequestRepo ports.FollowRequestRepository
+	notifier          ports.Notifier
 }
 
-func NewFollowService(userRepo ports.UserRepository) ports.FollowService {
-	return &followService{userRepo: userRepo}
+func NewFollowService(userRepo ports.UserRepository, followRequestRepo ports.FollowRequestRepository, notifier ports.Notifier) ports.FollowService {
+	return &followService{userRepo: userRepo, followRequestRepo: followRequestRepo, notifier: notifier}
 }
 
-func (s *followService) FollowUser(ctx context.Context, currentUserID, userToFollowID string) error {
+// FollowUser fails for users whose account is not active.
+func (s *followService) FollowUser(ctx context.Context, currentUserID, userToFollowID string) (domain.FollowStatus, error) {
 	if currentUserID == userToFollowID {
-		return errors.New("a user cannot follow themselves")
+		return "", ErrSelfFollow
+	}
+	if err := checkActive(ctx, s.userRepo, currentUserID); err != nil {
+		return "", err
+	}
+
+	users, err := s.userRepo.GetUsers(ctx, []string{userToFollowID})
+	if err != nil {
+		return "", err
+	}
+	if len(users) == 1 && users[0].Protected {
+		return s.requestFollow(ctx, currentUserID, userToFollowID)
+	}
+
+	followed, err := s.userRepo.FollowTx(ctx, currentUserID, userToFollowID)
+	if err != nil {
+		return "", err
+	}
+
+	// Following someone again is a no-op and must not notify them twice.
+	if followed {
+		s.notifier.Notify(ctx, domain.NewNotification(userToFollowID, domain.NotificationFollow, currentUserID, ""))
+	}
+	return domain.FollowStatusFollowing, nil
+}
+
+func (s *followService) requestFollow(ctx context.Context, currentUserID, userToFollowID string) (domain.FollowStatus, error) {
+	followed, err := s.userRepo.GetFollowedUsers(ctx, currentUserID, []string{userToFollowID})
+	if err != nil {
+		return "", err
+	}
+	if len(followed) > 0 {
+		return domain.FollowStatusFollowing, nil
 	}
-	return s.userRepo.FollowTx(ctx, currentUserID, userToFollowID)
+
+	added, err := s.followRequestRepo.AddFollowRequest(ctx, domain.NewFollowRequest(currentUserID, userToFollowID))
+	if err != nil {
+		return "", err
+	}
+
+	// Repeating a pending request must not notify the target again.
+	if added {
+		s.notifier.Notify(ctx, domain.NewNotification(userToFollowID, domain.NotificationFollowRequest, currentUserID, ""))
+	}
+	return domain.FollowStatusPending, nil
+}
+
+func (s *followService) GetFollowRequests(ctx context.Context, userID string) ([]domain.FollowRequest, error) {
+	return s.followRequestRepo.GetFollowRequests(ctx, userID, followRequestsPageSize)
+}
+
+func (s *followService) ApproveFollowRequest(ctx context.Context, userID, requesterID string) error {
+	if err := s.followRequestRepo.ApproveFollowRequestTx(ctx, userID, requesterID); err != nil {
+		return err
+	}
+
+	s.notifier.Notify(ctx, domain.NewNotification(requesterID, domain.NotificationFollowAccepted, userID, ""))
+	return nil
+}
+
+func (s *followService) RejectFollowRequest(ctx context.Context, userID, requesterID string) error {
+	return s.followRequestRepo.DeleteFollowRequest(ctx, userID, requesterID)
 }
@@ -5,55 +5,178 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestFollowService_FollowUser(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("Success: should follow a user", func(t *testing.T) {
+	t.Run("Success: should follow a user and notify them", func(t *testing.T) {
 		mockRepo := new(mocks.Repository)
-		followService := NewFollowService(mockRepo)
+		mockNotifier := new(mocks.Notifier)
+		followService := NewFollowService(mockRepo, mockRepo, mockNotifier)
 
 		userID := "user-pepita"
 		userToFollowID := "user-pepito"
 
-		mockRepo.On("FollowTx", ctx, userID, userToFollowID).Return(nil)
+		mockRepo.On("GetUsers", ctx, []string{userID}).Return([]domain.User{{ID: userID}}, nil)
+		mockRepo.On("GetUsers", ctx, []string{userToFollowID}).Return([]domain.User{{ID: userToFollowID}}, nil)
+		mockRepo.On("FollowTx", ctx, userID, userToFollowID).Return(true, nil)
+		mockNotifier.On("Notify", ctx, mock.MatchedBy(func(ns []domain.Notification) bool {
+			return len(ns) == 1 && ns[0].RecipientID == userToFollowID && ns[0].ActorID == userID
+		})).Return()
 
-		err := followService.FollowUser(ctx, userID, userToFollowID)
+		status, err := followService.FollowUser(ctx, userID, userToFollowID)
 
 		assert.NoError(t, err)
+		assert.Equal(t, domain.FollowStatusFollowing, status)
 		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("Success: should not notify again when already following", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockNotifier := new(mocks.Notifier)
+		followService := NewFollowService(mockRepo, mockRepo, mockNotifier)
+
+		mockRepo.On("GetUsers", ctx, []string{"user-pepita"}).Return([]domain.User{{ID: "user-pepita"}}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-pepito"}).Return([]domain.User{{ID: "user-pepito"}}, nil)
+		mockRepo.On("FollowTx", ctx, "user-pepita", "user-pepito").Return(false, nil)
+
+		status, err := followService.FollowUser(ctx, "user-pepita", "user-pepito")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FollowStatusFollowing, status)
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should send a follow request to a protected account", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockNotifier := new(mocks.Notifier)
+		followService := NewFollowService(mockRepo, mockRepo, mockNotifier)
+
+		userID := "user-pepita"
+		userToFollowID := "user-pepito"
+
+		mockRepo.On("GetUsers", ctx, []string{userID}).Return([]domain.User{{ID: userID}}, nil)
+		mockRepo.On("GetUsers", ctx, []string{userToFollowID}).Return([]domain.User{{ID: userToFollowID, Protected: true}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, userID, []string{userToFollowID}).Return([]string{}, nil)
+		mockRepo.On("AddFollowRequest", ctx, mock.MatchedBy(func(fr domain.FollowRequest) bool {
+			return fr.RequesterID == userID && fr.TargetID == userToFollowID
+		})).Return(true, nil)
+		mockNotifier.On("Notify", ctx, mock.MatchedBy(func(ns []domain.Notification) bool {
+			return len(ns) == 1 && ns[0].Type == domain.NotificationFollowRequest
+		})).Return()
+
+		status, err := followService.FollowUser(ctx, userID, userToFollowID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FollowStatusPending, status)
+		mockRepo.AssertNotCalled(t, "FollowTx", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("Success: should not notify again when the request is already pending", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockNotifier := new(mocks.Notifier)
+		followService := NewFollowService(mockRepo, mockRepo, mockNotifier)
+
+		userID := "user-pepita"
+		userToFollowID := "user-pepito"
+
+		mockRepo.On("GetUsers", ctx, []string{userID}).Return([]domain.User{{ID: userID}}, nil)
+		mockRepo.On("GetUsers", ctx, []string{userToFollowID}).Return([]domain.User{{ID: userToFollowID, Protected: true}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, userID, []string{userToFollowID}).Return([]string{}, nil)
+		mockRepo.On("AddFollowRequest", ctx, mock.Anything).Return(false, nil)
+
+		status, err := followService.FollowUser(ctx, userID, userToFollowID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FollowStatusPending, status)
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
 	})
 
 	t.Run("Failure: should not allow self-follow", func(t *testing.T) {
 		mockRepo := new(mocks.Repository)
-		followService := NewFollowService(mockRepo)
+		followService := NewFollowService(mockRepo, mockRepo, new(mocks.Notifier))
 
 		userID := "user-pepita"
 
-		err := followService.FollowUser(ctx, userID, userID)
+		_, err := followService.FollowUser(ctx, userID, userID)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "a user cannot follow themselves")
 		mockRepo.AssertNotCalled(t, "FollowTx")
 	})
 
+	t.Run("Failure: should not let deactivated users follow", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		followService := NewFollowService(mockRepo, mockRepo, new(mocks.Notifier))
+
+		mockRepo.On("GetUsers", ctx, []string{"user-pepita"}).Return([]domain.User{{ID: "user-pepita", Status: domain.UserDeactivated}}, nil)
+
+		_, err := followService.FollowUser(ctx, "user-pepita", "user-pepito")
+
+		assert.Equal(t, domain.ErrAccountDeactivated, err)
+		mockRepo.AssertNotCalled(t, "FollowTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("Failure: repository returns an error", func(t *testing.T) {
 		mockRepo := new(mocks.Repository)
-		followService := NewFollowService(mockRepo)
+		followService := NewFollowService(mockRepo, mockRepo, new(mocks.Notifier))
 
 		userID := "user-pepita"
 		userToFollowID := "user-pepito"
 		expectedError := errors.New("db connection error")
 
-		mockRepo.On("FollowTx", ctx, userID, userToFollowID).Return(expectedError)
+		mockRepo.On("GetUsers", ctx, []string{userID}).Return([]domain.User{{ID: userID}}, nil)
+		mockRepo.On("GetUsers", ctx, []string{userToFollowID}).Return([]domain.User{}, nil)
+		mockRepo.On("FollowTx", ctx, userID, userToFollowID).Return(false, expectedError)
 
-		err := followService.FollowUser(ctx, userID, userToFollowID)
+		_, err := followService.FollowUser(ctx, userID, userToFollowID)
 
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestFollowService_ApproveFollowRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success: should follow and notify the requester", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockNotifier := new(mocks.Notifier)
+		followService := NewFollowService(mockRepo, mockRepo, mockNotifier)
+
+		mockRepo.On("ApproveFollowRequestTx", ctx, "user-pepito", "user-pepita").Return(nil)
+		mockNotifier.On("Notify", ctx, mock.MatchedBy(func(ns []domain.Notification) bool {
+			return len(ns) == 1 && ns[0].RecipientID == "user-pepita" && ns[0].Type == domain.NotificationFollowAccepted
+		})).Return()
+
+		err := followService.ApproveFollowRequest(ctx, "user-pepito", "user-pepita")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not notify when there is no request", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockNotifier := new(mocks.Notifier)
+		followService := NewFollowService(mockRepo, mockRepo, mockNotifier)
+
+		mockRepo.On("ApproveFollowRequestTx", ctx, "user-pepito", "user-pepita").Return(domain.ErrFollowRequestNotFound)
+
+		err := followService.ApproveFollowRequest(ctx, "user-pepito", "user-pepita")
+
+		assert.Equal(t, domain.ErrFollowRequestNotFound, err)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const (
+	maxTrends        = 10
+	minTrendingCount = 2
+)
+
+type TrendConfig struct {
+	Window         time.Duration
+	BaselineWindow time.Duration
+}
+
+type hashtagService struct {
+	hashtagRepo ports.HashtagRepository
+	trendRepo   ports.TrendRepository
+	visibility  tweetVisibility
+	cfg         TrendConfig
+	now         func() time.Time
+}
+
+func NewHashtagService(hashtagRepo ports.HashtagRepository, trendRepo ports.TrendRepository, userRepo ports.UserRepository, cfg TrendConfig, now func() time.Time) ports.HashtagService {
+	return &hashtagService{hashtagRepo: hashtagRepo, trendRepo: trendRepo, visibility: tweetVisibility{userRepo: userRepo}, cfg: cfg, now: now}
+}
+
+func (s *hashtagService) GetHashtagTweets(ctx context.Context, userID, tag string) ([]domain.Tweet, error) {
+	tweets, err := s.hashtagRepo.GetHashtagTweets(ctx, domain.NormalizeHashtag(tag), 50)
+	if err != nil {
+		return nil, err
+	}
+	return s.visibility.filter(ctx, userID, tweets)
+}
+
+func (s *hashtagService) GetTrends(ctx context.Context) ([]domain.Trend, error) {
+	return s.trendRepo.GetTrends(ctx)
+}
+
+// RefreshTrends compares hashtag usage in the current window against the
+// window immediately before it and stores the hashtags that are rising the
+// fastest. It is meant to be run periodically by a background job, which
+// also brings the hashtag counters back in line with the stored tweets.
+func (s *hashtagService) RefreshTrends(ctx context.Context) error {
+	if err := s.trendRepo.RebuildHashtagCounts(ctx); err != nil {
+		return err
+	}
+
+	now := s.now()
+	windowStart := now.Add(-s.cfg.Window)
+
+	current, err := s.hashtagRepo.CountHashtags(ctx, windowStart, now)
+	if err != nil {
+		return err
+	}
+	baseline, err := s.hashtagRepo.CountHashtags(ctx, windowStart.Add(-s.cfg.BaselineWindow), windowStart)
+	if err != nil {
+		return err
+	}
+
+	var trends []domain.Trend
+	for tag, count := range current {
+		if count < minTrendingCount {
+			continue
+		}
+		score := domain.TrendScore(count, baseline[tag], s.cfg.Window, s.cfg.BaselineWindow)
+		if score <= 0 {
+			continue
+		}
+		trends = append(trends, domain.Trend{Tag: tag, Count: count, Baseline: baseline[tag], Score: score})
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].Score != trends[j].Score {
+			return trends[i].Score > trends[j].Score
+		}
+		return trends[i].Tag < trends[j].Tag
+	})
+	if len(trends) > maxTrends {
+		trends = trends[:maxTrends]
+	}
+
+	return s.trendRepo.SaveTrends(ctx, trends)
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHashtagService_RefreshTrends(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	cfg := TrendConfig{Window: time.Hour, BaselineWindow: 24 * time.Hour}
+	clock := func() time.Time { return now }
+
+	t.Run("Success: should rank rising hashtags above steady ones", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		hashtagService := NewHashtagService(mockRepo, mockRepo, mockRepo, cfg, clock)
+
+		windowStart := now.Add(-time.Hour)
+		mockRepo.On("RebuildHashtagCounts", ctx).Return(nil)
+		mockRepo.On("CountHashtags", ctx, windowStart, now).
+			Return(map[string]int{"golang": 5, "lunes": 10, "solo": 1}, nil)
+		mockRepo.On("CountHashtags", ctx, windowStart.Add(-24*time.Hour), windowStart).
+			Return(map[string]int{"lunes": 240}, nil)
+
+		var saved []domain.Trend
+		mockRepo.On("SaveTrends", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).([]domain.Trend)
+		}).Return(nil)
+
+		err := hashtagService.RefreshTrends(ctx)
+
+		assert.NoError(t, err)
+		assert.Len(t, saved, 1)
+		assert.Equal(t, "golang", saved[0].Tag)
+		assert.Equal(t, 5, saved[0].Count)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: repository returns an error", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		hashtagService := NewHashtagService(mockRepo, mockRepo, mockRepo, cfg, clock)
+
+		expectedError := errors.New("db connection error")
+		mockRepo.On("RebuildHashtagCounts", ctx).Return(nil)
+		mockRepo.On("CountHashtags", ctx, mock.Anything, mock.Anything).Return(nil, expectedError)
+
+		err := hashtagService.RefreshTrends(ctx)
+
+		assert.Equal(t, expectedError, err)
+		mockRepo.AssertNotCalled(t, "SaveTrends", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHashtagService_GetHashtagTweets(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success: should normalize the requested tag", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		hashtagService := NewHashtagService(mockRepo, mockRepo, mockRepo, TrendConfig{}, time.Now)
+
+		expected := []domain.Tweet{{ID: "tweet-1", UserID: "user-2", Text: "Hola #GoLang"}}
+		mockRepo.On("GetHashtagTweets", ctx, "golang", 50).Return(expected, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-2"}).Return([]domain.User{{ID: "user-2"}}, nil)
+
+		tweets, err := hashtagService.GetHashtagTweets(ctx, "user-1", "#GoLang")
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, tweets)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should hide tweets of protected accounts the user does not follow", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		hashtagService := NewHashtagService(mockRepo, mockRepo, mockRepo, TrendConfig{}, time.Now)
+
+		stored := []domain.Tweet{
+			{ID: "tweet-3", UserID: "privada", Text: "#golang"},
+			{ID: "tweet-2", UserID: "user-1", Text: "#golang"},
+			{ID: "tweet-1", UserID: "publica", Text: "#golang"},
+		}
+		mockRepo.On("GetHashtagTweets", ctx, "golang", 50).Return(stored, nil)
+		mockRepo.On("GetUsers", ctx, []string{"privada", "publica"}).
+			Return([]domain.User{{ID: "privada", Protected: true}, {ID: "publica"}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, "user-1", []string{"privada"}).Return([]string{}, nil)
+
+		tweets, err := hashtagService.GetHashtagTweets(ctx, "user-1", "golang")
+
+		assert.NoError(t, err)
+		assert.Equal(t, stored[1:], tweets)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should hide tweets of deactivated and suspended accounts", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		hashtagService := NewHashtagService(mockRepo, mockRepo, mockRepo, TrendConfig{}, time.Now)
+
+		stored := []domain.Tweet{
+			{ID: "tweet-3", UserID: "suspendida", Text: "#golang"},
+			{ID: "tweet-2", UserID: "desactivada", Text: "#golang"},
+			{ID: "tweet-1", UserID: "activa", Text: "#golang"},
+		}
+		mockRepo.On("GetHashtagTweets", ctx, "golang", 50).Return(stored, nil)
+		mockRepo.On("GetUsers", ctx, []string{"suspendida", "desactivada", "activa"}).Return([]domain.User{
+			{ID: "suspendida", Status: domain.UserSuspended},
+			{ID: "desactivada", Status: domain.UserDeactivated},
+			{ID: "activa", Status: domain.UserActive},
+		}, nil)
+
+		tweets, err := hashtagService.GetHashtagTweets(ctx, "user-1", "golang")
+
+		assert.NoError(t, err)
+		assert.Equal(t, stored[2:], tweets)
+	})
+}
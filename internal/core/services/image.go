@@ -0,0 +1,308 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+)
+
+const jpegQuality = 90
+
+// processedImage is an upload decoded and encoded again. Only pixels survive
+// the round trip, so EXIF data, GPS coordinates included, and any other
+// metadata of the original are dropped. The EXIF orientation is applied to
+// the pixels first, so that photos are stored the way they are meant to be
+// seen.
+type processedImage struct {
+	contentType string
+	width       int
+	height      int
+	original    []byte
+	thumbnail   []byte
+}
+
+// processImage sniffs the upload's type from its content rather than trusting
+// the client, rejects images too large to decode safely and generates the
+// thumbnail. The limits are checked on the headers, before any pixel is
+// decoded.
+func processImage(data []byte) (*processedImage, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+	default:
+		return nil, domain.ErrUnsupportedMediaType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrUnsupportedMediaType
+	}
+	if config.Width > domain.MaxMediaDimension || config.Height > domain.MaxMediaDimension {
+		return nil, domain.ErrMediaTooLarge
+	}
+	pixels := config.Width * config.Height
+	if contentType == "image/gif" {
+		var frames int
+		frames, pixels, err = scanGIF(data)
+		if err != nil {
+			return nil, domain.ErrUnsupportedMediaType
+		}
+		if frames > domain.MaxGIFFrames {
+			return nil, domain.ErrMediaTooLarge
+		}
+	}
+	if pixels > domain.MaxMediaPixels {
+		return nil, domain.ErrMediaTooLarge
+	}
+
+	var (
+		original bytes.Buffer
+		frame    image.Image
+	)
+	switch contentType {
+	case "image/jpeg":
+		orientation := jpegOrientation(data)
+		frame, err = jpeg.Decode(bytes.NewReader(data))
+		if err == nil {
+			frame = orient(frame, orientation)
+			err = jpeg.Encode(&original, frame, &jpeg.Options{Quality: jpegQuality})
+		}
+	case "image/png":
+		frame, err = png.Decode(bytes.NewReader(data))
+		if err == nil {
+			err = png.Encode(&original, frame)
+		}
+	case "image/gif":
+		var animation *gif.GIF
+		animation, err = gif.DecodeAll(bytes.NewReader(data))
+		if err == nil {
+			frame = animation.Image[0]
+			err = gif.EncodeAll(&original, animation)
+		}
+	}
+	if err != nil {
+		return nil, domain.ErrUnsupportedMediaType
+	}
+
+	var thumbnail bytes.Buffer
+	scaled := scaleDown(frame)
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&thumbnail, scaled, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(&thumbnail, scaled)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &processedImage{
+		contentType: contentType,
+		width:       frame.Bounds().Dx(),
+		height:      frame.Bounds().Dy(),
+		original:    original.Bytes(),
+		thumbnail:   thumbnail.Bytes(),
+	}, nil
+}
+
+// exifOrientationTag is the EXIF tag that tells how the stored pixels must be
+// turned for display, from 1 (as stored) to 8.
+const exifOrientationTag = 0x0112
+
+// jpegOrientation walks the segments of a JPEG up to its image data and
+// returns the orientation of its EXIF segment, or 1 if it has none.
+func jpegOrientation(data []byte) int {
+	pos := 2
+	for pos+4 <= len(data) && data[pos] == 0xFF {
+		marker := data[pos+1]
+		if marker == 0xDA { // Start of scan: only image data follows.
+			break
+		}
+		// The length counts its own two bytes, so anything shorter is malformed.
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:]))
+		if end < pos+4 || end > len(data) {
+			break
+		}
+		if marker == 0xE1 {
+			if orientation := exifOrientation(data[pos+4 : end]); orientation != 0 {
+				return orientation
+			}
+		}
+		pos = end
+	}
+	return 1
+}
+
+// exifOrientation reads the orientation tag from the first IFD of an APP1
+// segment, or returns 0 if the segment is not EXIF or has no valid one.
+func exifOrientation(segment []byte) int {
+	tiff, ok := bytes.CutPrefix(segment, []byte("Exif\x00\x00"))
+	if !ok || len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	for i := range int(order.Uint16(tiff[ifd:])) {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// orient turns img as an EXIF orientation says: 2 to 4 mirror or rotate it
+// by 180 degrees, and 5 to 8 also swap its width and height.
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 {
+		return img
+	}
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	oriented := image.NewRGBA(image.Rect(0, 0, width, height))
+	if orientation >= 5 {
+		oriented = image.NewRGBA(image.Rect(0, 0, height, width))
+	}
+
+	for y := range height {
+		for x := range width {
+			var dx, dy int
+			switch orientation {
+			case 2: // Mirrored horizontally.
+				dx, dy = width-1-x, y
+			case 3: // Rotated by 180 degrees.
+				dx, dy = width-1-x, height-1-y
+			case 4: // Mirrored vertically.
+				dx, dy = x, height-1-y
+			case 5: // Transposed.
+				dx, dy = y, x
+			case 6: // Rotated by 90 degrees clockwise.
+				dx, dy = height-1-y, x
+			case 7: // Transversed.
+				dx, dy = height-1-y, width-1-x
+			case 8: // Rotated by 90 degrees counterclockwise.
+				dx, dy = y, width-1-x
+			}
+			oriented.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return oriented
+}
+
+var errMalformedGIF = errors.New("malformed GIF")
+
+// scanGIF walks the blocks of a GIF without decoding them and returns its
+// number of frames and the pixels they add up to, which is what gif.DecodeAll
+// would allocate.
+func scanGIF(data []byte) (frames, pixels int, err error) {
+	const headerSize = 13
+	if len(data) < headerSize {
+		return 0, 0, errMalformedGIF
+	}
+	pos := headerSize + colorTableSize(data[10])
+
+	// skipSubBlocks returns the position after the sub-blocks at pos, or -1
+	// if the data ends first.
+	skipSubBlocks := func(pos int) int {
+		for pos < len(data) {
+			size := int(data[pos])
+			pos++
+			if size == 0 {
+				return pos
+			}
+			pos += size
+		}
+		return -1
+	}
+
+	for pos < len(data) {
+		switch data[pos] {
+		case 0x21: // Extension: introducer, label and sub-blocks.
+			pos = skipSubBlocks(pos + 2)
+		case 0x2C: // Image descriptor, local color table, LZW code size and sub-blocks.
+			if pos+10 > len(data) {
+				return 0, 0, errMalformedGIF
+			}
+			width := int(binary.LittleEndian.Uint16(data[pos+5:]))
+			height := int(binary.LittleEndian.Uint16(data[pos+7:]))
+			frames++
+			pixels += width * height
+			pos = skipSubBlocks(pos + 10 + colorTableSize(data[pos+9]) + 1)
+		case 0x3B: // Trailer.
+			return frames, pixels, nil
+		default:
+			return 0, 0, errMalformedGIF
+		}
+		if pos < 0 {
+			return 0, 0, errMalformedGIF
+		}
+	}
+	return 0, 0, errMalformedGIF
+}
+
+// colorTableSize is the size in bytes of the color table announced by the
+// packed field of a GIF screen or image descriptor.
+func colorTableSize(fields byte) int {
+	if fields&0x80 == 0 {
+		return 0
+	}
+	return 3 << ((fields & 0x07) + 1)
+}
+
+// thumbnailSamples is how many source pixels per axis are averaged into each
+// thumbnail pixel, which bounds the work for large images.
+const thumbnailSamples = 4
+
+// scaleDown fits img into the thumbnail box, averaging a grid of the source
+// pixels covered by each thumbnail pixel.
+func scaleDown(img image.Image) image.Image {
+	bounds := img.Bounds()
+	width, height := domain.ThumbnailSize(bounds.Dx(), bounds.Dy())
+	scaled := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := range height {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := range width {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			stepX, stepY := max(1, (x1-x0)/thumbnailSamples), max(1, (y1-y0)/thumbnailSamples)
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy += stepY {
+				for sx := x0; sx < x1; sx += stepX {
+					c := color.NRGBA64Model.Convert(img.At(sx, sy)).(color.NRGBA64)
+					r, g, b, a = r+uint64(c.R), g+uint64(c.G), b+uint64(c.B), a+uint64(c.A)
+					n++
+				}
+			}
+			scaled.Set(x, y, color.NRGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return scaled
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const (
+	// unfurlBatchSize bounds how many pages one run of UnfurlLinks fetches, so
+	// a burst of links is spread over several runs.
+	unfurlBatchSize = 20
+	// unfurlClaimLease is how long a replica owns the links it claimed. A
+	// claim left behind by a crashed replica is retried once it expires.
+	unfurlClaimLease = 5 * time.Minute
+	// maxUnfurlAttempts is how many times a page that keeps failing is
+	// fetched before the link is given up on.
+	maxUnfurlAttempts = 5
+	unfurlRetryDelay  = 5 * time.Minute
+)
+
+type linkService struct {
+	linkRepo ports.LinkRepository
+	fetcher  ports.PageFetcher
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+func NewLinkService(linkRepo ports.LinkRepository, fetcher ports.PageFetcher, logger *slog.Logger, now func() time.Time) ports.LinkService {
+	return &linkService{
+		linkRepo: linkRepo,
+		fetcher:  fetcher,
+		logger:   logger.With("component", "LinkService"),
+		now:      now,
+	}
+}
+
+// UnfurlLinks fetches the pages of the links it claims and stores their
+// cards. A failure that may be temporary, such as a timeout or a server
+// error, is retried later with a growing delay; pages that can never be
+// unfurled, keep failing or have no title are stored as empty previews, so
+// a broken link is not fetched on every run.
+func (s *linkService) UnfurlLinks(ctx context.Context) error {
+	links, err := s.linkRepo.ClaimPendingLinks(ctx, s.now(), unfurlClaimLease, unfurlBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		url := link.URL
+		page, err := s.fetcher.FetchPage(ctx, url)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err != nil && !errors.Is(err, domain.ErrPageNotUnfurlable) && link.Attempts < maxUnfurlAttempts {
+			retryAt := s.now().Add(unfurlRetryDelay << (link.Attempts - 1))
+			s.logger.Info("Could not unfurl link, will retry", "url", url, "error", err, "attempts", link.Attempts, "retryAt", retryAt)
+			if err := s.linkRepo.RetryLink(ctx, url, retryAt); err != nil {
+				return err
+			}
+			continue
+		}
+
+		preview := domain.NewLinkPreview(url, "", "", "", "", s.now())
+		if err != nil {
+			s.logger.Info("Could not unfurl link", "url", url, "error", err, "attempts", link.Attempts)
+		} else {
+			meta := parseOpenGraph(url, page)
+			preview = domain.NewLinkPreview(url, meta.title, meta.description, meta.imageURL, meta.siteName, s.now())
+		}
+
+		if err := s.linkRepo.SaveLinkPreview(ctx, preview); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLinkService_UnfurlLinks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success: should store the card of each pending link", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		mockFetcher := new(mocks.PageFetcher)
+		linkService := NewLinkService(mockRepo, mockFetcher, discardLogger, clock)
+
+		page := `<html><head>
+			<meta property="og:title" content="Go 1.24 &amp; más">
+			<meta property="og:description" content="Novedades">
+			<meta property="og:image" content="/images/gopher.png">
+			<meta property="og:site_name" content="The Go Blog">
+		</head></html>`
+
+		// Mocking
+		mockRepo.On("ClaimPendingLinks", ctx, now, unfurlClaimLease, unfurlBatchSize).Return([]domain.PendingLink{{URL: "https://go.dev/blog/go1.24", Attempts: 1}}, nil)
+		mockFetcher.On("FetchPage", ctx, "https://go.dev/blog/go1.24").Return([]byte(page), nil)
+		mockRepo.On("SaveLinkPreview", ctx, &domain.LinkPreview{
+			URL:         "https://go.dev/blog/go1.24",
+			Title:       "Go 1.24 & más",
+			Description: "Novedades",
+			ImageURL:    "https://go.dev/images/gopher.png",
+			SiteName:    "The Go Blog",
+			FetchedAt:   now,
+		}).Return(nil)
+
+		// Execute
+		err := linkService.UnfurlLinks(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should record links that can never be unfurled so they are not retried", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockFetcher := new(mocks.PageFetcher)
+		linkService := NewLinkService(mockRepo, mockFetcher, discardLogger, clock)
+
+		mockRepo.On("ClaimPendingLinks", ctx, now, unfurlClaimLease, unfurlBatchSize).Return([]domain.PendingLink{{URL: "http://10.0.0.1/admin", Attempts: 1}}, nil)
+		mockFetcher.On("FetchPage", ctx, "http://10.0.0.1/admin").Return(nil, fmt.Errorf("%w: destination not allowed", domain.ErrPageNotUnfurlable))
+		mockRepo.On("SaveLinkPreview", ctx, mock.MatchedBy(func(p *domain.LinkPreview) bool {
+			return p.URL == "http://10.0.0.1/admin" && !p.Unfurled()
+		})).Return(nil)
+
+		err := linkService.UnfurlLinks(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "RetryLink", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should retry links that fail for a while with a growing delay", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockFetcher := new(mocks.PageFetcher)
+		linkService := NewLinkService(mockRepo, mockFetcher, discardLogger, clock)
+
+		mockRepo.On("ClaimPendingLinks", ctx, now, unfurlClaimLease, unfurlBatchSize).Return([]domain.PendingLink{{URL: "https://example.com", Attempts: 3}}, nil)
+		mockFetcher.On("FetchPage", ctx, "https://example.com").Return(nil, errors.New("unexpected status 503"))
+		mockRepo.On("RetryLink", ctx, "https://example.com", now.Add(4*unfurlRetryDelay)).Return(nil)
+
+		err := linkService.UnfurlLinks(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "SaveLinkPreview", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should give up on links that keep failing", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockFetcher := new(mocks.PageFetcher)
+		linkService := NewLinkService(mockRepo, mockFetcher, discardLogger, clock)
+
+		mockRepo.On("ClaimPendingLinks", ctx, now, unfurlClaimLease, unfurlBatchSize).Return([]domain.PendingLink{{URL: "https://example.com", Attempts: maxUnfurlAttempts}}, nil)
+		mockFetcher.On("FetchPage", ctx, "https://example.com").Return(nil, errors.New("timeout"))
+		mockRepo.On("SaveLinkPreview", ctx, mock.MatchedBy(func(p *domain.LinkPreview) bool {
+			return p.URL == "https://example.com" && !p.Unfurled()
+		})).Return(nil)
+
+		err := linkService.UnfurlLinks(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "RetryLink", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestParseOpenGraph(t *testing.T) {
+	t.Run("Success: should fall back to the title and description tags", func(t *testing.T) {
+		page := `<HTML><HEAD><TITLE>Página
+			de prueba</TITLE><META NAME='description' CONTENT='Una descripción'></HEAD></HTML>`
+
+		meta := parseOpenGraph("https://example.com", []byte(page))
+
+		assert.Equal(t, "Página\n\t\t\tde prueba", meta.title)
+		assert.Equal(t, "Una descripción", meta.description)
+	})
+
+	t.Run("Success: should drop images that are not http or https", func(t *testing.T) {
+		page := `<meta property="og:title" content="Hola"><meta property="og:image" content="javascript:alert(1)">`
+
+		meta := parseOpenGraph("https://example.com", []byte(page))
+
+		assert.Empty(t, meta.imageURL)
+	})
+}
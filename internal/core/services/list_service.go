@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const (
+	maxListsPerUser      = 1000
+	maxListMembers       = 5000
+	listTimelinePageSize = 50
+)
+
+var (
+	ErrTooManyLists = errors.New("list limit reached")
+	ErrListFull     = errors.New("list member limit reached")
+)
+
+type listService struct {
+	listRepo         ports.ListRepository
+	relationshipRepo ports.RelationshipRepository
+	visibility       tweetVisibility
+}
+
+func NewListService(listRepo ports.ListRepository, relationshipRepo ports.RelationshipRepository, userRepo ports.UserRepository) ports.ListService {
+	return &listService{
+		listRepo:         listRepo,
+		relationshipRepo: relationshipRepo,
+		visibility:       tweetVisibility{userRepo: userRepo},
+	}
+}
+
+func (s *listService) CreateList(ctx context.Context, userID, name, description string, private bool) (*domain.List, error) {
+	list, err := domain.NewList(userID, name, description, private)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := s.listRepo.GetLists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lists) >= maxListsPerUser {
+		return nil, ErrTooManyLists
+	}
+
+	if err := s.listRepo.CreateList(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *listService) GetLists(ctx context.Context, userID string) ([]domain.List, error) {
+	return s.listRepo.GetLists(ctx, userID)
+}
+
+func (s *listService) GetList(ctx context.Context, userID, listID string) (*domain.List, error) {
+	list, err := s.listRepo.GetList(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	if !list.VisibleTo(userID) {
+		return nil, domain.ErrListNotFound
+	}
+	return list, nil
+}
+
+func (s *listService) UpdateList(ctx context.Context, userID, listID, name, description string, private bool) (*domain.List, error) {
+	list, err := s.ownedList(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	if err := list.Update(name, description, private); err != nil {
+		return nil, err
+	}
+
+	if err := s.listRepo.UpdateList(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *listService) DeleteList(ctx context.Context, userID, listID string) error {
+	if _, err := s.ownedList(ctx, userID, listID); err != nil {
+		return err
+	}
+	return s.listRepo.DeleteList(ctx, listID)
+}
+
+// AddMember refuses accounts that have a block with the owner in either
+// direction, as a list would otherwise bypass the block.
+func (s *listService) AddMember(ctx context.Context, userID, listID, memberID string) error {
+	if _, err := s.ownedList(ctx, userID, listID); err != nil {
+		return err
+	}
+
+	blocked, err := s.relationshipRepo.GetBlockedUsers(ctx, userID, []string{memberID})
+	if err != nil {
+		return err
+	}
+	if len(blocked) > 0 {
+		return domain.ErrUserBlocked
+	}
+
+	members, err := s.listRepo.GetListMembers(ctx, listID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(members, memberID) {
+		return nil
+	}
+	if len(members) >= maxListMembers {
+		return ErrListFull
+	}
+
+	return s.listRepo.AddListMember(ctx, listID, memberID)
+}
+
+func (s *listService) RemoveMember(ctx context.Context, userID, listID, memberID string) error {
+	if _, err := s.ownedList(ctx, userID, listID); err != nil {
+		return err
+	}
+	return s.listRepo.RemoveListMember(ctx, listID, memberID)
+}
+
+func (s *listService) GetMembers(ctx context.Context, userID, listID string) ([]string, error) {
+	if _, err := s.GetList(ctx, userID, listID); err != nil {
+		return nil, err
+	}
+	return s.listRepo.GetListMembers(ctx, listID)
+}
+
+// GetListTimeline returns the members' tweets the user is allowed to see.
+// Tweets hidden by visibility rules, and those of members with a block with
+// the user in either direction, are dropped after paging, so a page can come
+// back shorter than the page size.
+func (s *listService) GetListTimeline(ctx context.Context, userID, listID, cursor string) (domain.TweetPage, error) {
+	decoded, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+	if _, err := s.GetList(ctx, userID, listID); err != nil {
+		return domain.TweetPage{}, err
+	}
+
+	tweets, err := s.listRepo.GetListTimeline(ctx, listID, decoded, listTimelinePageSize+1)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+
+	page := domain.NewTweetPage(tweets, listTimelinePageSize)
+	if page.Tweets, err = s.visibility.filter(ctx, userID, page.Tweets); err != nil {
+		return domain.TweetPage{}, err
+	}
+	if page.Tweets, err = s.withoutBlocked(ctx, userID, page.Tweets); err != nil {
+		return domain.TweetPage{}, err
+	}
+	return page, nil
+}
+
+// withoutBlocked drops the tweets of the authors that have a block with the
+// user. A list may hold members the owner blocked, or was blocked by, after
+// adding them, and a public list is read by anyone.
+func (s *listService) withoutBlocked(ctx context.Context, userID string, tweets []domain.Tweet) ([]domain.Tweet, error) {
+	var authorIDs []string
+	for _, tweet := range tweets {
+		if tweet.UserID != userID && !slices.Contains(authorIDs, tweet.UserID) {
+			authorIDs = append(authorIDs, tweet.UserID)
+		}
+	}
+	if len(authorIDs) == 0 {
+		return tweets, nil
+	}
+
+	blocked, err := s.relationshipRepo.GetBlockedUsers(ctx, userID, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	hidden := make(map[string]bool, len(blocked))
+	for _, id := range blocked {
+		hidden[id] = true
+	}
+	return without(tweets, hidden), nil
+}
+
+// ownedList loads a list the user is allowed to change. Lists the user
+// cannot even see are reported as not found.
+func (s *listService) ownedList(ctx context.Context, userID, listID string) (*domain.List, error) {
+	list, err := s.GetList(ctx, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	if list.OwnerID != userID {
+		return nil, domain.ErrNotListOwner
+	}
+	return list, nil
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListService(t *testing.T) {
+	ctx := context.Background()
+
+	publicList := &domain.List{ID: "list-1", OwnerID: "ana", Name: "Periodistas"}
+	privateList := &domain.List{ID: "list-2", OwnerID: "ana", Name: "Secreta", Private: true}
+
+	t.Run("Success: should add a member to the owner's list", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		listService := NewListService(mockRepo, mockRepo, mockRepo)
+
+		mockRepo.On("GetList", ctx, "list-1").Return(publicList, nil)
+		mockRepo.On("GetBlockedUsers", ctx, "ana", []string{"beto"}).Return([]string{}, nil)
+		mockRepo.On("GetListMembers", ctx, "list-1").Return([]string{"carla"}, nil)
+		mockRepo.On("AddListMember", ctx, "list-1", "beto").Return(nil)
+
+		err := listService.AddMember(ctx, "ana", "list-1", "beto")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not let other users change the list", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		listService := NewListService(mockRepo, mockRepo, mockRepo)
+
+		mockRepo.On("GetList", ctx, "list-1").Return(publicList, nil)
+
+		err := listService.AddMember(ctx, "beto", "list-1", "beto")
+
+		assert.Equal(t, domain.ErrNotListOwner, err)
+		mockRepo.AssertNotCalled(t, "AddListMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should not add a member with a block in place", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		listService := NewListService(mockRepo, mockRepo, mockRepo)
+
+		mockRepo.On("GetList", ctx, "list-1").Return(publicList, nil)
+		mockRepo.On("GetBlockedUsers", ctx, "ana", []string{"beto"}).Return([]string{"beto"}, nil)
+
+		err := listService.AddMember(ctx, "ana", "list-1", "beto")
+
+		assert.Equal(t, domain.ErrUserBlocked, err)
+		mockRepo.AssertNotCalled(t, "AddListMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should hide private lists from other users", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		listService := NewListService(mockRepo, mockRepo, mockRepo)
+
+		mockRepo.On("GetList", ctx, "list-2").Return(privateList, nil)
+
+		_, err := listService.GetListTimeline(ctx, "beto", "list-2", "")
+
+		assert.Equal(t, domain.ErrListNotFound, err)
+		mockRepo.AssertNotCalled(t, "GetListTimeline", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should drop protected members' tweets the viewer cannot see", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		listService := NewListService(mockRepo, mockRepo, mockRepo)
+
+		now := time.Now()
+		tweets := []domain.Tweet{
+			{ID: "tweet-2", UserID: "carla", CreatedAt: now},
+			{ID: "tweet-1", UserID: "dario", CreatedAt: now.Add(-time.Minute)},
+		}
+		mockRepo.On("GetList", ctx, "list-1").Return(publicList, nil)
+		mockRepo.On("GetListTimeline", ctx, "list-1", (*domain.Cursor)(nil), listTimelinePageSize+1).Return(tweets, nil)
+		mockRepo.On("GetUsers", ctx, []string{"carla", "dario"}).Return([]domain.User{{ID: "carla"}, {ID: "dario", Protected: true}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, "beto", []string{"dario"}).Return([]string{}, nil)
+		mockRepo.On("GetBlockedUsers", ctx, "beto", []string{"carla"}).Return([]string{}, nil)
+
+		page, err := listService.GetListTimeline(ctx, "beto", "list-1", "")
+
+		require.NoError(t, err)
+		require.Len(t, page.Tweets, 1)
+		assert.Equal(t, "tweet-2", page.Tweets[0].ID)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Success: should drop the tweets of members with a block with the viewer", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		listService := NewListService(mockRepo, mockRepo, mockRepo)
+
+		now := time.Now()
+		tweets := []domain.Tweet{
+			{ID: "tweet-3", UserID: "carla", CreatedAt: now},
+			{ID: "tweet-2", UserID: "dario", CreatedAt: now.Add(-time.Minute)},
+			{ID: "tweet-1", UserID: "carla", CreatedAt: now.Add(-2 * time.Minute)},
+		}
+		mockRepo.On("GetList", ctx, "list-1").Return(publicList, nil)
+		mockRepo.On("GetListTimeline", ctx, "list-1", (*domain.Cursor)(nil), listTimelinePageSize+1).Return(tweets, nil)
+		mockRepo.On("GetUsers", ctx, []string{"carla", "dario"}).Return([]domain.User{{ID: "carla"}, {ID: "dario"}}, nil)
+		mockRepo.On("GetBlockedUsers", ctx, "beto", []string{"carla", "dario"}).Return([]string{"carla"}, nil)
+
+		page, err := listService.GetListTimeline(ctx, "beto", "list-1", "")
+
+		require.NoError(t, err)
+		require.Len(t, page.Tweets, 1)
+		assert.Equal(t, "tweet-2", page.Tweets[0].ID)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+type mediaService struct {
+	mediaRepo  ports.MediaRepository
+	blobs      ports.BlobStore
+	visibility tweetVisibility
+	now        func() time.Time
+}
+
+func NewMediaService(mediaRepo ports.MediaRepository, blobs ports.BlobStore, userRepo ports.UserRepository, now func() time.Time) ports.MediaService {
+	return &mediaService{mediaRepo: mediaRepo, blobs: blobs, visibility: tweetVisibility{userRepo: userRepo}, now: now}
+}
+
+// UploadMedia stores the processed image and its thumbnail before recording
+// the media, so media returned to clients always have their blobs.
+func (s *mediaService) UploadMedia(ctx context.Context, userID string, data []byte) (*domain.Media, error) {
+	if len(data) > domain.MaxMediaSize {
+		return nil, domain.ErrMediaTooLarge
+	}
+	processed, err := processImage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	media := domain.NewMedia(userID, processed.contentType, processed.width, processed.height, len(processed.original), s.now())
+	if err := s.blobs.Put(ctx, media.BlobKey(), media.ContentType, processed.original); err != nil {
+		return nil, err
+	}
+	if err := s.blobs.Put(ctx, media.ThumbnailKey(), media.ThumbnailContentType(), processed.thumbnail); err != nil {
+		return nil, err
+	}
+
+	if err := s.mediaRepo.AddMedia(ctx, media); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// GetMediaBlob applies the visibility of the owner's tweets, since media are
+// only ever attached to them.
+func (s *mediaService) GetMediaBlob(ctx context.Context, viewerID, mediaID string, thumbnail bool) (*domain.MediaBlob, error) {
+	media, err := s.mediaRepo.GetMedia(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	visible, public, err := s.visibility.canSee(ctx, viewerID, media.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, domain.ErrMediaNotFound
+	}
+
+	key, contentType := media.BlobKey(), media.ContentType
+	if thumbnail {
+		key, contentType = media.ThumbnailKey(), media.ThumbnailContentType()
+	}
+	data, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MediaBlob{Data: data, ContentType: contentType, Private: !public}, nil
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// jpegWithExif encodes a width x height JPEG and inserts an EXIF segment
+// carrying a fake GPS position right after the start-of-image marker.
+func jpegWithExif(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+
+	payload := append([]byte("Exif\x00\x00"), []byte("GPS -34.6037,-58.3816")...)
+	segment := append([]byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}, payload...)
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+// rotatedJPEG encodes a width x height JPEG, red on its left half and blue on
+// its right half, tagged with EXIF orientation 6: to be rotated by 90 degrees
+// clockwise for display, as phones store portrait photos.
+func rotatedJPEG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			if x < width/2 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+
+	// A big-endian TIFF header and an IFD with a single SHORT entry.
+	payload := []byte("Exif\x00\x00MM\x00\x2A\x00\x00\x00\x08" +
+		"\x00\x01" + "\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00" + "\x00\x00\x00\x00")
+	segment := append([]byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}, payload...)
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+// animatedGIF encodes frames blank width x height frames.
+func animatedGIF(t *testing.T, width, height, frames int) []byte {
+	animation := &gif.GIF{}
+	for range frames {
+		animation.Image = append(animation.Image, image.NewPaletted(image.Rect(0, 0, width, height), palette.Plan9))
+		animation.Delay = append(animation.Delay, 10)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, animation))
+	return buf.Bytes()
+}
+
+func TestMediaService_UploadMedia(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("Success: should strip EXIF data and store the image with its thumbnail", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		upload := jpegWithExif(t, 640, 480)
+		require.True(t, bytes.Contains(upload, []byte("GPS")))
+
+		var original, thumbnail []byte
+		mockBlobs.On("Put", ctx, mock.AnythingOfType("string"), "image/jpeg", mock.Anything).Run(func(args mock.Arguments) {
+			if strings.HasSuffix(args.String(1), "/original") {
+				original = args.Get(3).([]byte)
+			} else {
+				thumbnail = args.Get(3).([]byte)
+			}
+		}).Return(nil)
+		mockRepo.On("AddMedia", ctx, mock.AnythingOfType("*domain.Media")).Return(nil)
+
+		// Execute
+		media, err := mediaService.UploadMedia(ctx, "user-1", upload)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", media.UserID)
+		assert.Equal(t, "image/jpeg", media.ContentType)
+		assert.Equal(t, 640, media.Width)
+		assert.Equal(t, 480, media.Height)
+		assert.Equal(t, now, media.CreatedAt)
+
+		assert.False(t, bytes.Contains(original, []byte("Exif")))
+		assert.False(t, bytes.Contains(original, []byte("GPS")))
+		thumb, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+		require.NoError(t, err)
+		assert.Equal(t, 320, thumb.Width)
+		assert.Equal(t, 240, thumb.Height)
+		mockRepo.AssertExpectations(t)
+		mockBlobs.AssertExpectations(t)
+	})
+
+	t.Run("Success: should apply the EXIF orientation to the image and its thumbnail", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		var original, thumbnail []byte
+		mockBlobs.On("Put", ctx, mock.AnythingOfType("string"), "image/jpeg", mock.Anything).Run(func(args mock.Arguments) {
+			if strings.HasSuffix(args.String(1), "/original") {
+				original = args.Get(3).([]byte)
+			} else {
+				thumbnail = args.Get(3).([]byte)
+			}
+		}).Return(nil)
+		mockRepo.On("AddMedia", ctx, mock.AnythingOfType("*domain.Media")).Return(nil)
+
+		media, err := mediaService.UploadMedia(ctx, "user-1", rotatedJPEG(t, 800, 400))
+
+		require.NoError(t, err)
+		assert.Equal(t, 400, media.Width)
+		assert.Equal(t, 800, media.Height)
+
+		img, err := jpeg.Decode(bytes.NewReader(original))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 400, 800), img.Bounds())
+		// The left half of the stored pixels is the top half once rotated.
+		top, bottom := color.RGBAModel.Convert(img.At(200, 100)).(color.RGBA), color.RGBAModel.Convert(img.At(200, 700)).(color.RGBA)
+		assert.Greater(t, top.R, top.B)
+		assert.Greater(t, bottom.B, bottom.R)
+
+		thumb, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+		require.NoError(t, err)
+		assert.Less(t, thumb.Width, thumb.Height)
+	})
+
+	t.Run("Success: should keep PNG thumbnails as PNG", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 100, 50))))
+		mockBlobs.On("Put", ctx, mock.AnythingOfType("string"), "image/png", mock.Anything).Return(nil).Twice()
+		mockRepo.On("AddMedia", ctx, mock.AnythingOfType("*domain.Media")).Return(nil)
+
+		media, err := mediaService.UploadMedia(ctx, "user-1", buf.Bytes())
+
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", media.ThumbnailContentType())
+		mockBlobs.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should reject files that are not images, whatever their name", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		_, err := mediaService.UploadMedia(ctx, "user-1", []byte("<html><script>alert(1)</script></html>"))
+
+		assert.Equal(t, domain.ErrUnsupportedMediaType, err)
+		mockBlobs.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should keep every frame of an animated GIF", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		mockBlobs.On("Put", ctx, mock.AnythingOfType("string"), "image/gif", mock.MatchedBy(func(data []byte) bool {
+			animation, err := gif.DecodeAll(bytes.NewReader(data))
+			return err == nil && len(animation.Image) == 3
+		})).Return(nil).Once()
+		mockBlobs.On("Put", ctx, mock.AnythingOfType("string"), "image/png", mock.Anything).Return(nil).Once()
+		mockRepo.On("AddMedia", ctx, mock.AnythingOfType("*domain.Media")).Return(nil)
+
+		_, err := mediaService.UploadMedia(ctx, "user-1", animatedGIF(t, 40, 30, 3))
+
+		assert.NoError(t, err)
+		mockBlobs.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should reject images too large to decode before decoding them", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		var wide bytes.Buffer
+		require.NoError(t, png.Encode(&wide, image.NewGray(image.Rect(0, 0, domain.MaxMediaDimension+1, 1))))
+		uploads := map[string][]byte{
+			"too wide":        wide.Bytes(),
+			"too many frames": animatedGIF(t, 1, 1, domain.MaxGIFFrames+1),
+			"too many pixels": animatedGIF(t, 1024, 1024, domain.MaxMediaPixels/(1024*1024)+1),
+		}
+		for name, data := range uploads {
+			_, err := mediaService.UploadMedia(ctx, "user-1", data)
+			assert.Equal(t, domain.ErrMediaTooLarge, err, name)
+		}
+		mockBlobs.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should reject truncated GIFs", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		data := animatedGIF(t, 40, 30, 2)
+
+		_, err := mediaService.UploadMedia(ctx, "user-1", data[:len(data)-20])
+
+		assert.Equal(t, domain.ErrUnsupportedMediaType, err)
+	})
+
+	t.Run("Failure: should reject JPEGs with a malformed segment length", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		var buf bytes.Buffer
+		require.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 20, 20)), nil))
+		// A JFIF header, which makes image.DecodeConfig stop at the frame
+		// header, and right after the frame header an APP1 segment declaring
+		// a length of 1.
+		jfif := []byte{0xFF, 0xE0, 0x00, 0x10, 'J', 'F', 'I', 'F', 0x00, 0x01, 0x01, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00}
+		data := append(append(append([]byte{}, buf.Bytes()[:2]...), jfif...), buf.Bytes()[2:]...)
+		sof := bytes.Index(data, []byte{0xFF, 0xC0})
+		require.Positive(t, sof)
+		end := sof + 2 + int(data[sof+2])<<8 + int(data[sof+3])
+		malformed := append(append(append([]byte{}, data[:end]...), 0xFF, 0xE1, 0x00, 0x01), data[end:]...)
+
+		_, err := mediaService.UploadMedia(ctx, "user-1", malformed)
+
+		assert.Equal(t, domain.ErrUnsupportedMediaType, err)
+		mockBlobs.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should reject uploads over the size limit", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		_, err := mediaService.UploadMedia(ctx, "user-1", make([]byte, domain.MaxMediaSize+1))
+
+		assert.Equal(t, domain.ErrMediaTooLarge, err)
+	})
+}
+
+func TestMediaService_GetMediaBlob(t *testing.T) {
+	ctx := context.Background()
+	clock := func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) }
+	media := &domain.Media{ID: "media-1", UserID: "user-owner", ContentType: "image/png"}
+
+	t.Run("Success: should serve the thumbnail of a public account's media to anyone", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		// Mocking
+		mockRepo.On("GetMedia", ctx, "media-1").Return(media, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-owner"}).Return([]domain.User{{ID: "user-owner"}}, nil)
+		mockBlobs.On("Get", ctx, "media-1/thumbnail").Return([]byte("thumb"), nil)
+
+		// Execute
+		blob, err := mediaService.GetMediaBlob(ctx, "user-stranger", "media-1", true)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &domain.MediaBlob{Data: []byte("thumb"), ContentType: "image/png"}, blob)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should serve a protected account's media to their followers as private", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		mockRepo.On("GetMedia", ctx, "media-1").Return(media, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-owner"}).Return([]domain.User{{ID: "user-owner", Protected: true}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, "user-follower", []string{"user-owner"}).Return([]string{"user-owner"}, nil)
+		mockBlobs.On("Get", ctx, "media-1/original").Return([]byte("image"), nil)
+
+		blob, err := mediaService.GetMediaBlob(ctx, "user-follower", "media-1", false)
+
+		require.NoError(t, err)
+		assert.True(t, blob.Private)
+		assert.Equal(t, []byte("image"), blob.Data)
+	})
+
+	t.Run("Failure: should hide a protected account's media from other users", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mediaService := NewMediaService(mockRepo, mockBlobs, mockRepo, clock)
+
+		mockRepo.On("GetMedia", ctx, "media-1").Return(media, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-owner"}).Return([]domain.User{{ID: "user-owner", Protected: true}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, "user-stranger", []string{"user-owner"}).Return([]string{}, nil)
+
+		_, err := mediaService.GetMediaBlob(ctx, "user-stranger", "media-1", false)
+
+		assert.Equal(t, domain.ErrMediaNotFound, err)
+		mockBlobs.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+}
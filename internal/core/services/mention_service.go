@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+type mentionService struct {
+	mentionRepo ports.MentionRepository
+	visibility  tweetVisibility
+}
+
+func NewMentionService(mentionRepo ports.MentionRepository, userRepo ports.UserRepository) ports.MentionService {
+	return &mentionService{mentionRepo: mentionRepo, visibility: tweetVisibility{userRepo: userRepo}}
+}
+
+func (s *mentionService) GetUserMentions(ctx context.Context, userID string) ([]domain.Tweet, error) {
+	tweets, err := s.mentionRepo.GetMentions(ctx, userID, 50)
+	if err != nil {
+		return nil, err
+	}
+	return s.visibility.filter(ctx, userID, tweets)
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const (
+	conversationsPageSize = 20
+	messagesPageSize      = 50
+)
+
+type messageService struct {
+	messageRepo      ports.MessageRepository
+	relationshipRepo ports.RelationshipRepository
+	now              func() time.Time
+}
+
+func NewMessageService(messageRepo ports.MessageRepository, relationshipRepo ports.RelationshipRepository, now func() time.Time) ports.MessageService {
+	return &messageService{messageRepo: messageRepo, relationshipRepo: relationshipRepo, now: now}
+}
+
+// StartConversation refuses to put together users when either of them has
+// blocked the other.
+func (s *messageService) StartConversation(ctx context.Context, userID string, participantIDs []string) (*domain.Conversation, error) {
+	conversation, err := domain.NewConversation(userID, participantIDs, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNotBlocked(ctx, userID, conversation); err != nil {
+		return nil, err
+	}
+	return s.messageRepo.CreateConversation(ctx, conversation)
+}
+
+func (s *messageService) GetConversations(ctx context.Context, userID, cursor string) (domain.ConversationPage, error) {
+	decoded, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return domain.ConversationPage{}, err
+	}
+
+	conversations, err := s.messageRepo.GetConversations(ctx, userID, decoded, conversationsPageSize+1)
+	if err != nil {
+		return domain.ConversationPage{}, err
+	}
+	return domain.NewConversationPage(conversations, conversationsPageSize), nil
+}
+
+// SendMessage is rejected with ErrUserBlocked while the sender and any other
+// participant have a block between them, also in group conversations.
+func (s *messageService) SendMessage(ctx context.Context, userID, conversationID, text string) (*domain.Message, error) {
+	conversation, err := s.participantConversation(ctx, userID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := domain.NewMessage(conversation.ID, userID, text, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNotBlocked(ctx, userID, conversation); err != nil {
+		return nil, err
+	}
+
+	if err := s.messageRepo.AddMessage(ctx, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (s *messageService) GetMessages(ctx context.Context, userID, conversationID, cursor string) (domain.MessagePage, error) {
+	decoded, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return domain.MessagePage{}, err
+	}
+	if _, err := s.participantConversation(ctx, userID, conversationID); err != nil {
+		return domain.MessagePage{}, err
+	}
+
+	messages, err := s.messageRepo.GetMessages(ctx, conversationID, decoded, messagesPageSize+1)
+	if err != nil {
+		return domain.MessagePage{}, err
+	}
+	return domain.NewMessagePage(messages, messagesPageSize), nil
+}
+
+func (s *messageService) MarkConversationRead(ctx context.Context, userID, conversationID string) error {
+	if _, err := s.participantConversation(ctx, userID, conversationID); err != nil {
+		return err
+	}
+	return s.messageRepo.MarkConversationRead(ctx, conversationID, userID, s.now())
+}
+
+func (s *messageService) participantConversation(ctx context.Context, userID, conversationID string) (*domain.Conversation, error) {
+	conversation, err := s.messageRepo.GetConversation(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if !conversation.HasParticipant(userID) {
+		return nil, domain.ErrConversationNotFound
+	}
+	return conversation, nil
+}
+
+func (s *messageService) checkNotBlocked(ctx context.Context, userID string, conversation *domain.Conversation) error {
+	blocked, err := s.relationshipRepo.GetBlockedUsers(ctx, userID, conversation.OtherParticipantIDs(userID))
+	if err != nil {
+		return err
+	}
+	if len(blocked) > 0 {
+		return domain.ErrUserBlocked
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	conversation, err := domain.NewConversation("ana", []string{"beto"}, now)
+	require.NoError(t, err)
+
+	t.Run("Failure: should not start a conversation with a blocked user", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		messageService := NewMessageService(mockRepo, mockRepo, clock)
+
+		mockRepo.On("GetBlockedUsers", ctx, "ana", []string{"beto"}).Return([]string{"beto"}, nil)
+
+		_, err := messageService.StartConversation(ctx, "ana", []string{"beto"})
+
+		assert.Equal(t, domain.ErrUserBlocked, err)
+		mockRepo.AssertNotCalled(t, "CreateConversation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should send a message to the conversation", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		messageService := NewMessageService(mockRepo, mockRepo, clock)
+
+		mockRepo.On("GetConversation", ctx, conversation.ID).Return(conversation, nil)
+		mockRepo.On("GetBlockedUsers", ctx, "beto", []string{"ana"}).Return([]string{}, nil)
+		mockRepo.On("AddMessage", ctx, mock.MatchedBy(func(m *domain.Message) bool {
+			return m.ConversationID == conversation.ID && m.SenderID == "beto" && m.Text == "hola"
+		})).Return(nil)
+
+		message, err := messageService.SendMessage(ctx, "beto", conversation.ID, "hola")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hola", message.Text)
+		assert.Equal(t, now, message.CreatedAt)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should hide conversations from non participants", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		messageService := NewMessageService(mockRepo, mockRepo, clock)
+
+		mockRepo.On("GetConversation", ctx, conversation.ID).Return(conversation, nil)
+
+		_, err := messageService.GetMessages(ctx, "carla", conversation.ID, "")
+		assert.Equal(t, domain.ErrConversationNotFound, err)
+
+		err = messageService.MarkConversationRead(ctx, "carla", conversation.ID)
+		assert.Equal(t, domain.ErrConversationNotFound, err)
+
+		mockRepo.AssertNotCalled(t, "GetMessages", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "MarkConversationRead", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should mark the conversation as read at the current time", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		messageService := NewMessageService(mockRepo, mockRepo, clock)
+
+		mockRepo.On("GetConversation", ctx, conversation.ID).Return(conversation, nil)
+		mockRepo.On("MarkConversationRead", ctx, conversation.ID, "beto", now).Return(nil)
+
+		err := messageService.MarkConversationRead(ctx, "beto", conversation.ID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type BlobStore struct {
+	mock.Mock
+}
+
+func (m *BlobStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	args := m.Called(ctx, key, contentType, data)
+	return args.Error(0)
+}
+
+func (m *BlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	args := m.Called(ctx, key)
+	if data, ok := args.Get(0).([]byte); ok {
+		return data, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *BlobStore) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type EventBroker struct {
+	mock.Mock
+}
+
+func (m *EventBroker) Publish(ctx context.Context, events ...domain.Event) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *EventBroker) Subscribe(ctx context.Context, topic, lastEventID string, buffer int) (<-chan domain.Event, error) {
+	args := m.Called(ctx, topic, lastEventID, buffer)
+	if events, ok := args.Get(0).(<-chan domain.Event); ok {
+		return events, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *EventBroker) DeleteHistory(ctx context.Context, topics ...string) error {
+	args := m.Called(ctx, topics)
+	return args.Error(0)
+}
+
+func (m *EventBroker) DeleteUserEvents(ctx context.Context, userID string, topics ...string) error {
+	args := m.Called(ctx, userID, topics)
+	return args.Error(0)
+}
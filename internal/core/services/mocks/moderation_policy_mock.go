@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type ModerationPolicy struct {
+	mock.Mock
+}
+
+func (m *ModerationPolicy) Review(ctx context.Context, userID string, content domain.TweetContent) (domain.ModerationDecision, error) {
+	args := m.Called(ctx, userID, content)
+	return args.Get(0).(domain.ModerationDecision), args.Error(1)
+}
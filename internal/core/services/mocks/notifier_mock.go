@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type Notifier struct {
+	mock.Mock
+}
+
+func (m *Notifier) Notify(ctx context.Context, notifications ...domain.Notification) {
+	m.Called(ctx, notifications)
+}
@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type PageFetcher struct {
+	mock.Mock
+}
+
+func (m *PageFetcher) FetchPage(ctx context.Context, url string) ([]byte, error) {
+	args := m.Called(ctx, url)
+	if page, ok := args.Get(0).([]byte); ok {
+		return page, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/stretchr/testify/mock"
@@ -11,9 +12,9 @@ type Repository struct {
 	mock.Mock
 }
 
-func (m *Repository) FollowTx(ctx context.Context, userID, userToFollowID string) error {
+func (m *Repository) FollowTx(ctx context.Context, userID, userToFollowID string) (bool, error) {
 	args := m.Called(ctx, userID, userToFollowID)
-	return args.Error(0)
+	return args.Bool(0), args.Error(1)
 }
 
 func (m *Repository) GetFollowers(ctx context.Context, userID string) ([]string, error) {
@@ -29,10 +30,678 @@ func (m *Repository) PublishTx(ctx context.Context, tweet *domain.Tweet) error {
 	return args.Error(0)
 }
 
-func (m *Repository) Get(ctx context.Context, userID string, limit int) ([]domain.Tweet, error) {
-	args := m.Called(ctx, userID, limit)
+func (m *Repository) PublishDraftTx(ctx context.Context, draft *domain.Draft, tweet *domain.Tweet) error {
+	args := m.Called(ctx, draft, tweet)
+	return args.Error(0)
+}
+
+func (m *Repository) GetTweet(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	args := m.Called(ctx, tweetID)
+	if tweet, ok := args.Get(0).(*domain.Tweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) DeleteTx(ctx context.Context, tweet *domain.Tweet) error {
+	args := m.Called(ctx, tweet)
+	return args.Error(0)
+}
+
+func (m *Repository) AddPollVote(ctx context.Context, tweetID, userID string, option int, now time.Time) error {
+	args := m.Called(ctx, tweetID, userID, option, now)
+	return args.Error(0)
+}
+
+func (m *Repository) GetPollTallies(ctx context.Context, tweetID string, options int) ([]int, error) {
+	args := m.Called(ctx, tweetID, options)
+	if tallies, ok := args.Get(0).([]int); ok {
+		return tallies, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetPollVote(ctx context.Context, tweetID, userID string) (*int, error) {
+	args := m.Called(ctx, tweetID, userID)
+	if option, ok := args.Get(0).(*int); ok {
+		return option, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) ClaimPendingLinks(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.PendingLink, error) {
+	args := m.Called(ctx, now, lease, limit)
+	if links, ok := args.Get(0).([]domain.PendingLink); ok {
+		return links, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) SaveLinkPreview(ctx context.Context, preview *domain.LinkPreview) error {
+	args := m.Called(ctx, preview)
+	return args.Error(0)
+}
+
+func (m *Repository) RetryLink(ctx context.Context, url string, retryAt time.Time) error {
+	args := m.Called(ctx, url, retryAt)
+	return args.Error(0)
+}
+
+func (m *Repository) AddHeldTweet(ctx context.Context, tweet *domain.HeldTweet) error {
+	args := m.Called(ctx, tweet)
+	return args.Error(0)
+}
+
+func (m *Repository) GetHeldTweets(ctx context.Context, limit int) ([]domain.HeldTweet, error) {
+	args := m.Called(ctx, limit)
+	if tweets, ok := args.Get(0).([]domain.HeldTweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) TakeHeldTweet(ctx context.Context, tweetID string) (*domain.HeldTweet, error) {
+	args := m.Called(ctx, tweetID)
+	if tweet, ok := args.Get(0).(*domain.HeldTweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) AddReport(ctx context.Context, report *domain.Report) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (m *Repository) GetReport(ctx context.Context, reportID string) (*domain.Report, error) {
+	args := m.Called(ctx, reportID)
+	if report, ok := args.Get(0).(*domain.Report); ok {
+		return report, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetReports(ctx context.Context, status domain.ReportStatus, limit int) ([]domain.Report, error) {
+	args := m.Called(ctx, status, limit)
+	if reports, ok := args.Get(0).([]domain.Report); ok {
+		return reports, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) UpdateReport(ctx context.Context, report *domain.Report) error {
+	args := m.Called(ctx, report)
+	return args.Error(0)
+}
+
+func (m *Repository) AddMedia(ctx context.Context, media *domain.Media) error {
+	args := m.Called(ctx, media)
+	return args.Error(0)
+}
+
+func (m *Repository) GetMedia(ctx context.Context, mediaID string) (*domain.Media, error) {
+	args := m.Called(ctx, mediaID)
+	if media, ok := args.Get(0).(*domain.Media); ok {
+		return media, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) EditTx(ctx context.Context, before, after *domain.Tweet) error {
+	args := m.Called(ctx, before, after)
+	return args.Error(0)
+}
+
+func (m *Repository) GetTweetRevisions(ctx context.Context, tweetID string) ([]domain.TweetRevision, error) {
+	args := m.Called(ctx, tweetID)
+	if revisions, ok := args.Get(0).([]domain.TweetRevision); ok {
+		return revisions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) Get(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	args := m.Called(ctx, userID, cursor, limit)
 	if timeline, ok := args.Get(0).([]domain.Tweet); ok {
 		return timeline, args.Error(1)
 	}
 	return nil, args.Error(1)
 }
+
+func (m *Repository) GetUsers(ctx context.Context, userIDs []string) ([]domain.User, error) {
+	args := m.Called(ctx, userIDs)
+	if users, ok := args.Get(0).([]domain.User); ok {
+		return users, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetMentions(ctx context.Context, userID string, limit int) ([]domain.Tweet, error) {
+	args := m.Called(ctx, userID, limit)
+	if tweets, ok := args.Get(0).([]domain.Tweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetHashtagTweets(ctx context.Context, tag string, limit int) ([]domain.Tweet, error) {
+	args := m.Called(ctx, tag, limit)
+	if tweets, ok := args.Get(0).([]domain.Tweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) CountHashtagBuckets(ctx context.Context, since, until time.Time, bucketSize time.Duration) ([]domain.HashtagCount, error) {
+	args := m.Called(ctx, since, until, bucketSize)
+	if counts, ok := args.Get(0).([]domain.HashtagCount); ok {
+		return counts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) RebuildHashtagCounts(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *Repository) CountHashtags(ctx context.Context, since, until time.Time) (map[string]int, error) {
+	args := m.Called(ctx, since, until)
+	if counts, ok := args.Get(0).(map[string]int); ok {
+		return counts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) SaveTrends(ctx context.Context, trends []domain.Trend) error {
+	args := m.Called(ctx, trends)
+	return args.Error(0)
+}
+
+func (m *Repository) GetTrends(ctx context.Context) ([]domain.Trend, error) {
+	args := m.Called(ctx)
+	if trends, ok := args.Get(0).([]domain.Trend); ok {
+		return trends, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) Search(ctx context.Context, query domain.SearchQuery, limit int) ([]domain.Tweet, error) {
+	args := m.Called(ctx, query, limit)
+	if tweets, ok := args.Get(0).([]domain.Tweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) AddNotifications(ctx context.Context, notifications []domain.Notification) error {
+	args := m.Called(ctx, notifications)
+	return args.Error(0)
+}
+
+func (m *Repository) GetNotifications(ctx context.Context, userID string, limit int) ([]domain.Notification, error) {
+	args := m.Called(ctx, userID, limit)
+	if notifications, ok := args.Get(0).([]domain.Notification); ok {
+		return notifications, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) MarkNotificationsRead(ctx context.Context, userID string, notificationIDs []string) error {
+	args := m.Called(ctx, userID, notificationIDs)
+	return args.Error(0)
+}
+
+func (m *Repository) BlockTx(ctx context.Context, userID, blockedUserID string) error {
+	args := m.Called(ctx, userID, blockedUserID)
+	return args.Error(0)
+}
+
+func (m *Repository) Unblock(ctx context.Context, userID, blockedUserID string) error {
+	args := m.Called(ctx, userID, blockedUserID)
+	return args.Error(0)
+}
+
+func (m *Repository) Mute(ctx context.Context, userID, mutedUserID string) error {
+	args := m.Called(ctx, userID, mutedUserID)
+	return args.Error(0)
+}
+
+func (m *Repository) Unmute(ctx context.Context, userID, mutedUserID string) error {
+	args := m.Called(ctx, userID, mutedUserID)
+	return args.Error(0)
+}
+
+func (m *Repository) AddFilter(ctx context.Context, filter *domain.ContentFilter) error {
+	args := m.Called(ctx, filter)
+	return args.Error(0)
+}
+
+func (m *Repository) DeleteFilter(ctx context.Context, userID, filterID string) error {
+	args := m.Called(ctx, userID, filterID)
+	return args.Error(0)
+}
+
+func (m *Repository) GetFilters(ctx context.Context, userID string) ([]domain.ContentFilter, error) {
+	args := m.Called(ctx, userID)
+	if filters, ok := args.Get(0).([]domain.ContentFilter); ok {
+		return filters, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetFollowedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error) {
+	args := m.Called(ctx, userID, candidateIDs)
+	if followed, ok := args.Get(0).([]string); ok {
+		return followed, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) SetProtected(ctx context.Context, userID string, protected bool) error {
+	args := m.Called(ctx, userID, protected)
+	return args.Error(0)
+}
+
+func (m *Repository) SetStatus(ctx context.Context, userID string, status domain.UserStatus) error {
+	args := m.Called(ctx, userID, status)
+	return args.Error(0)
+}
+
+func (m *Repository) SetStatusUnlessSuspended(ctx context.Context, userID string, status domain.UserStatus) error {
+	args := m.Called(ctx, userID, status)
+	return args.Error(0)
+}
+
+func (m *Repository) SetStatusIfSuspended(ctx context.Context, userID string, status domain.UserStatus) error {
+	args := m.Called(ctx, userID, status)
+	return args.Error(0)
+}
+
+func (m *Repository) AddFollowRequest(ctx context.Context, request domain.FollowRequest) (bool, error) {
+	args := m.Called(ctx, request)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Repository) GetFollowRequests(ctx context.Context, targetID string, limit int) ([]domain.FollowRequest, error) {
+	args := m.Called(ctx, targetID, limit)
+	if requests, ok := args.Get(0).([]domain.FollowRequest); ok {
+		return requests, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) ApproveFollowRequestTx(ctx context.Context, targetID, requesterID string) error {
+	args := m.Called(ctx, targetID, requesterID)
+	return args.Error(0)
+}
+
+func (m *Repository) DeleteFollowRequest(ctx context.Context, targetID, requesterID string) error {
+	args := m.Called(ctx, targetID, requesterID)
+	return args.Error(0)
+}
+
+func (m *Repository) GetBlockedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error) {
+	args := m.Called(ctx, userID, candidateIDs)
+	if blocked, ok := args.Get(0).([]string); ok {
+		return blocked, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetMutedUsers(ctx context.Context, userID string, candidateIDs []string) ([]string, error) {
+	args := m.Called(ctx, userID, candidateIDs)
+	if muted, ok := args.Get(0).([]string); ok {
+		return muted, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) CreateConversation(ctx context.Context, conversation *domain.Conversation) (*domain.Conversation, error) {
+	args := m.Called(ctx, conversation)
+	if created, ok := args.Get(0).(*domain.Conversation); ok {
+		return created, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetConversation(ctx context.Context, conversationID string) (*domain.Conversation, error) {
+	args := m.Called(ctx, conversationID)
+	if conversation, ok := args.Get(0).(*domain.Conversation); ok {
+		return conversation, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetConversations(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Conversation, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if conversations, ok := args.Get(0).([]domain.Conversation); ok {
+		return conversations, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) AddMessage(ctx context.Context, message *domain.Message) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+func (m *Repository) GetMessages(ctx context.Context, conversationID string, cursor *domain.Cursor, limit int) ([]domain.Message, error) {
+	args := m.Called(ctx, conversationID, cursor, limit)
+	if messages, ok := args.Get(0).([]domain.Message); ok {
+		return messages, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) MarkConversationRead(ctx context.Context, conversationID, userID string, readAt time.Time) error {
+	args := m.Called(ctx, conversationID, userID, readAt)
+	return args.Error(0)
+}
+
+func (m *Repository) AddBookmark(ctx context.Context, userID, tweetID string, bookmarkedAt time.Time) error {
+	args := m.Called(ctx, userID, tweetID, bookmarkedAt)
+	return args.Error(0)
+}
+
+func (m *Repository) RemoveBookmark(ctx context.Context, userID, tweetID string) error {
+	args := m.Called(ctx, userID, tweetID)
+	return args.Error(0)
+}
+
+func (m *Repository) GetBookmarks(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Bookmark, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if bookmarks, ok := args.Get(0).([]domain.Bookmark); ok {
+		return bookmarks, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) CreateList(ctx context.Context, list *domain.List) error {
+	args := m.Called(ctx, list)
+	return args.Error(0)
+}
+
+func (m *Repository) GetList(ctx context.Context, listID string) (*domain.List, error) {
+	args := m.Called(ctx, listID)
+	if list, ok := args.Get(0).(*domain.List); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetLists(ctx context.Context, ownerID string) ([]domain.List, error) {
+	args := m.Called(ctx, ownerID)
+	if lists, ok := args.Get(0).([]domain.List); ok {
+		return lists, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) UpdateList(ctx context.Context, list *domain.List) error {
+	args := m.Called(ctx, list)
+	return args.Error(0)
+}
+
+func (m *Repository) DeleteList(ctx context.Context, listID string) error {
+	args := m.Called(ctx, listID)
+	return args.Error(0)
+}
+
+func (m *Repository) AddListMember(ctx context.Context, listID, userID string) error {
+	args := m.Called(ctx, listID, userID)
+	return args.Error(0)
+}
+
+func (m *Repository) RemoveListMember(ctx context.Context, listID, userID string) error {
+	args := m.Called(ctx, listID, userID)
+	return args.Error(0)
+}
+
+func (m *Repository) GetListMembers(ctx context.Context, listID string) ([]string, error) {
+	args := m.Called(ctx, listID)
+	if members, ok := args.Get(0).([]string); ok {
+		return members, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetMemberLists(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if listIDs, ok := args.Get(0).([]string); ok {
+		return listIDs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetListTimeline(ctx context.Context, listID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	args := m.Called(ctx, listID, cursor, limit)
+	if tweets, ok := args.Get(0).([]domain.Tweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetUserIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	args := m.Called(ctx, afterID, limit)
+	if userIDs, ok := args.Get(0).([]string); ok {
+		return userIDs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetSuggestionCandidates(ctx context.Context, userID string, limit int) ([]domain.Suggestion, error) {
+	args := m.Called(ctx, userID, limit)
+	if suggestions, ok := args.Get(0).([]domain.Suggestion); ok {
+		return suggestions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) SaveSuggestions(ctx context.Context, userID string, suggestions []domain.Suggestion) error {
+	args := m.Called(ctx, userID, suggestions)
+	return args.Error(0)
+}
+
+func (m *Repository) GetSuggestions(ctx context.Context, userID string) ([]domain.Suggestion, bool, error) {
+	args := m.Called(ctx, userID)
+	suggestions, _ := args.Get(0).([]domain.Suggestion)
+	return suggestions, args.Bool(1), args.Error(2)
+}
+
+func (m *Repository) AcquireRefreshLease(ctx context.Context, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Repository) AddScheduledTweet(ctx context.Context, tweet *domain.ScheduledTweet) error {
+	args := m.Called(ctx, tweet)
+	return args.Error(0)
+}
+
+func (m *Repository) GetScheduledTweet(ctx context.Context, tweetID string) (*domain.ScheduledTweet, error) {
+	args := m.Called(ctx, tweetID)
+	if tweet, ok := args.Get(0).(*domain.ScheduledTweet); ok {
+		return tweet, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetScheduledTweets(ctx context.Context, userID string) ([]domain.ScheduledTweet, error) {
+	args := m.Called(ctx, userID)
+	if tweets, ok := args.Get(0).([]domain.ScheduledTweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) UpdateScheduledTweet(ctx context.Context, tweet *domain.ScheduledTweet) error {
+	args := m.Called(ctx, tweet)
+	return args.Error(0)
+}
+
+func (m *Repository) DeleteScheduledTweet(ctx context.Context, tweetID string) error {
+	args := m.Called(ctx, tweetID)
+	return args.Error(0)
+}
+
+func (m *Repository) ClaimDueTweets(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.ScheduledTweet, error) {
+	args := m.Called(ctx, now, lease, limit)
+	if tweets, ok := args.Get(0).([]domain.ScheduledTweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) ReleaseScheduledTweet(ctx context.Context, tweetID string) error {
+	args := m.Called(ctx, tweetID)
+	return args.Error(0)
+}
+
+func (m *Repository) CompleteScheduledTweet(ctx context.Context, tweetID string) error {
+	args := m.Called(ctx, tweetID)
+	return args.Error(0)
+}
+
+func (m *Repository) AddDraft(ctx context.Context, draft *domain.Draft) error {
+	args := m.Called(ctx, draft)
+	return args.Error(0)
+}
+
+func (m *Repository) GetDraft(ctx context.Context, draftID string) (*domain.Draft, error) {
+	args := m.Called(ctx, draftID)
+	if draft, ok := args.Get(0).(*domain.Draft); ok {
+		return draft, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetDrafts(ctx context.Context, userID string) ([]domain.Draft, error) {
+	args := m.Called(ctx, userID)
+	if drafts, ok := args.Get(0).([]domain.Draft); ok {
+		return drafts, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) UpdateDraft(ctx context.Context, draft *domain.Draft) error {
+	args := m.Called(ctx, draft)
+	return args.Error(0)
+}
+
+func (m *Repository) DeleteDraft(ctx context.Context, userID, draftID string) error {
+	args := m.Called(ctx, userID, draftID)
+	return args.Error(0)
+}
+
+func (m *Repository) GetUserTweets(ctx context.Context, userID string) ([]domain.Tweet, error) {
+	args := m.Called(ctx, userID)
+	if tweets, ok := args.Get(0).([]domain.Tweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetFollowing(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if following, ok := args.Get(0).([]string); ok {
+		return following, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetNotifiedUsers(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if userIDs, ok := args.Get(0).([]string); ok {
+		return userIDs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetFollowersOfFollowers(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if userIDs, ok := args.Get(0).([]string); ok {
+		return userIDs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) GetUserMedia(ctx context.Context, userID string) ([]domain.Media, error) {
+	args := m.Called(ctx, userID)
+	if media, ok := args.Get(0).([]domain.Media); ok {
+		return media, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) RequestErasure(ctx context.Context, userID string, requestedAt time.Time) error {
+	args := m.Called(ctx, userID, requestedAt)
+	return args.Error(0)
+}
+
+func (m *Repository) GetErasureRequests(ctx context.Context, limit int) ([]string, error) {
+	args := m.Called(ctx, limit)
+	if userIDs, ok := args.Get(0).([]string); ok {
+		return userIDs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) EraseUserTx(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *Repository) GetProfileTweets(ctx context.Context, userID string, cursor *domain.Cursor, limit int) ([]domain.Tweet, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if tweets, ok := args.Get(0).([]domain.Tweet); ok {
+		return tweets, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) TrimTimelinesBefore(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	args := m.Called(ctx, cutoff, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *Repository) GetGrownTimelines(ctx context.Context, limit int) ([]string, error) {
+	args := m.Called(ctx, limit)
+	if userIDs, ok := args.Get(0).([]string); ok {
+		return userIDs, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) TrimTimelines(ctx context.Context, userIDs []string, keep int) (int, error) {
+	args := m.Called(ctx, userIDs, keep)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *Repository) ArchiveTweets(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	args := m.Called(ctx, cutoff, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *Repository) GetPartitions(ctx context.Context, table string) ([]domain.Partition, error) {
+	args := m.Called(ctx, table)
+	if partitions, ok := args.Get(0).([]domain.Partition); ok {
+		return partitions, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *Repository) CreatePartition(ctx context.Context, partition domain.Partition) error {
+	args := m.Called(ctx, partition)
+	return args.Error(0)
+}
+
+func (m *Repository) DropPartition(ctx context.Context, partition domain.Partition) error {
+	args := m.Called(ctx, partition)
+	return args.Error(0)
+}
+
+func (m *Repository) DetachPartition(ctx context.Context, partition domain.Partition, onlyIfEmpty bool) (bool, error) {
+	args := m.Called(ctx, partition, onlyIfEmpty)
+	return args.Bool(0), args.Error(1)
+}
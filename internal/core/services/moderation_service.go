@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+// heldTweetsLimit bounds the review queue returned at once.
+const heldTweetsLimit = 100
+
+type moderationService struct {
+	heldRepo  ports.HeldTweetRepository
+	tweetRepo ports.TweetRepository
+	userRepo  ports.UserRepository
+	tweetSvc  ports.TweetService
+	logger    *slog.Logger
+}
+
+func NewModerationService(
+	heldRepo ports.HeldTweetRepository,
+	tweetRepo ports.TweetRepository,
+	userRepo ports.UserRepository,
+	tweetSvc ports.TweetService,
+	logger *slog.Logger,
+) ports.ModerationService {
+	return &moderationService{
+		heldRepo:  heldRepo,
+		tweetRepo: tweetRepo,
+		userRepo:  userRepo,
+		tweetSvc:  tweetSvc,
+		logger:    logger.With("component", "ModerationService"),
+	}
+}
+
+func (s *moderationService) GetHeldTweets(ctx context.Context) ([]domain.HeldTweet, error) {
+	return s.heldRepo.GetHeldTweets(ctx, heldTweetsLimit)
+}
+
+// ApproveHeldTweet publishes the held tweet, which is fanned out like any
+// new tweet. The tweet is taken out of the queue first, so two moderators
+// approving it at once yield a single tweet; if publishing fails it goes
+// back to the queue.
+func (s *moderationService) ApproveHeldTweet(ctx context.Context, tweetID string) (*domain.Tweet, error) {
+	held, err := s.heldRepo.TakeHeldTweet(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+
+	tweet, err := s.tweetSvc.PublishHeldTweet(ctx, held)
+	if err != nil {
+		if restoreErr := s.heldRepo.AddHeldTweet(ctx, held); restoreErr != nil {
+			s.logger.Error("Failed to restore held tweet after a failed publish", "error", restoreErr, "heldTweetID", held.ID, "userID", held.UserID)
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Approved held tweet", "heldTweetID", held.ID, "tweetID", tweet.ID, "userID", held.UserID)
+	return tweet, nil
+}
+
+func (s *moderationService) RejectHeldTweet(ctx context.Context, tweetID string) error {
+	held, err := s.heldRepo.TakeHeldTweet(ctx, tweetID)
+	if err != nil {
+		return err
+	}
+	s.logger.Info("Rejected held tweet", "heldTweetID", held.ID, "userID", held.UserID, "reason", held.Reason)
+	return nil
+}
+
+// SuspendUser stops the user from publishing until unsuspended.
+func (s *moderationService) SuspendUser(ctx context.Context, adminID, userID string) error {
+	if err := s.userRepo.SetStatus(ctx, userID, domain.UserSuspended); err != nil {
+		return err
+	}
+	s.logger.Info("Suspended user", "adminID", adminID, "userID", userID)
+	return nil
+}
+
+// UnsuspendUser only reactivates suspended accounts, so that it does not undo
+// a deactivation by the owner or by an erasure request.
+func (s *moderationService) UnsuspendUser(ctx context.Context, adminID, userID string) error {
+	if err := s.userRepo.SetStatusIfSuspended(ctx, userID, domain.UserActive); err != nil {
+		return err
+	}
+	s.logger.Info("Unsuspended user", "adminID", adminID, "userID", userID)
+	return nil
+}
+
+// RemoveTweet deletes any user's tweet. It goes through DeleteTx like a
+// deletion by the author, so the tweet leaves timelines and caches the same
+// way.
+func (s *moderationService) RemoveTweet(ctx context.Context, adminID, tweetID string) error {
+	tweet, err := s.tweetRepo.GetTweet(ctx, tweetID)
+	if err != nil {
+		return err
+	}
+	if err := s.tweetRepo.DeleteTx(ctx, tweet); err != nil {
+		return err
+	}
+	s.logger.Info("Removed tweet", "adminID", adminID, "tweetID", tweet.ID, "userID", tweet.UserID)
+	return nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerationService(t *testing.T) {
+	ctx := context.Background()
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	held := &domain.HeldTweet{ID: "held-1", UserID: "ana", Content: domain.TweetContent{Text: "Revisame"}, Reason: "SUSPICIOUS"}
+
+	newService := func(mockRepo *mocks.Repository) ports.ModerationService {
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+		return NewModerationService(mockRepo, mockRepo, mockRepo, tweetService, discardLogger)
+	}
+
+	t.Run("Success: should publish an approved tweet", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		moderationService := newService(mockRepo)
+
+		mockRepo.On("TakeHeldTweet", ctx, "held-1").Return(held, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("PublishTx", ctx, mock.MatchedBy(func(tweet *domain.Tweet) bool {
+			return tweet.UserID == "ana" && tweet.Text == "Revisame"
+		})).Return(nil)
+
+		tweet, err := moderationService.ApproveHeldTweet(ctx, "held-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Revisame", tweet.Text)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should put the tweet back in the queue when publishing fails", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		moderationService := newService(mockRepo)
+
+		expectedError := errors.New("database is down")
+		mockRepo.On("TakeHeldTweet", ctx, "held-1").Return(held, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("PublishTx", ctx, mock.AnythingOfType("*domain.Tweet")).Return(expectedError)
+		mockRepo.On("AddHeldTweet", ctx, held).Return(nil)
+
+		_, err := moderationService.ApproveHeldTweet(ctx, "held-1")
+
+		assert.Equal(t, expectedError, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should report tweets another moderator already decided on", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		moderationService := newService(mockRepo)
+
+		mockRepo.On("TakeHeldTweet", ctx, "held-1").Return(nil, domain.ErrHeldTweetNotFound)
+
+		err := moderationService.RejectHeldTweet(ctx, "held-1")
+
+		assert.Equal(t, domain.ErrHeldTweetNotFound, err)
+	})
+
+	t.Run("Success: should remove any user's tweet the way its author would", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		moderationService := newService(mockRepo)
+
+		tweet := &domain.Tweet{ID: "tweet-1", UserID: "beto"}
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("DeleteTx", ctx, tweet).Return(nil)
+
+		err := moderationService.RemoveTweet(ctx, "admin-1", "tweet-1")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should suspend the user", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		moderationService := newService(mockRepo)
+
+		mockRepo.On("SetStatus", ctx, "beto", domain.UserSuspended).Return(nil)
+
+		err := moderationService.SuspendUser(ctx, "admin-1", "beto")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should only lift suspensions", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		moderationService := newService(mockRepo)
+
+		mockRepo.On("SetStatusIfSuspended", ctx, "beto", domain.UserActive).Return(domain.ErrAccountNotSuspended)
+
+		err := moderationService.UnsuspendUser(ctx, "admin-1", "beto")
+
+		assert.ErrorIs(t, err, domain.ErrAccountNotSuspended)
+		mockRepo.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const notificationFeedSize = 100
+
+type notificationService struct {
+	notificationRepo ports.NotificationRepository
+	broker           ports.EventBroker
+	logger           *slog.Logger
+}
+
+func NewNotificationService(notificationRepo ports.NotificationRepository, broker ports.EventBroker, logger *slog.Logger) ports.NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		broker:           broker,
+		logger:           logger.With("component", "NotificationService"),
+	}
+}
+
+func (s *notificationService) Notify(ctx context.Context, notifications ...domain.Notification) {
+	if len(notifications) == 0 {
+		return
+	}
+	if err := s.notificationRepo.AddNotifications(ctx, notifications); err != nil {
+		s.logger.Error("Failed to store notifications", "error", err, "count", len(notifications))
+		return
+	}
+
+	events := make([]domain.Event, len(notifications))
+	for i := range notifications {
+		events[i] = domain.Event{
+			Topic:        domain.NotificationsTopic(notifications[i].RecipientID),
+			Type:         domain.EventNotification,
+			Notification: &notifications[i],
+		}
+	}
+	if err := s.broker.Publish(ctx, events...); err != nil {
+		s.logger.Error("Failed to publish notification events", "error", err, "count", len(notifications))
+	}
+}
+
+func (s *notificationService) GetNotifications(ctx context.Context, userID string) (domain.NotificationFeed, error) {
+	notifications, err := s.notificationRepo.GetNotifications(ctx, userID, notificationFeedSize)
+	if err != nil {
+		return domain.NotificationFeed{}, err
+	}
+	return domain.AggregateNotifications(notifications), nil
+}
+
+func (s *notificationService) MarkAsRead(ctx context.Context, userID string, notificationIDs []string) error {
+	return s.notificationRepo.MarkNotificationsRead(ctx, userID, notificationIDs)
+}
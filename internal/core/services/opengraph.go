@@ -0,0 +1,82 @@
+package services
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	metaTagPattern   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributePattern = regexp.MustCompile(`(?s)([a-zA-Z:_-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	titleTagPattern  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+)
+
+// pageMetadata is what a page says about itself for link previews.
+type pageMetadata struct {
+	title       string
+	description string
+	imageURL    string
+	siteName    string
+}
+
+// parseOpenGraph reads the Open Graph tags of an HTML page, falling back to
+// the Twitter card tags, the <title> element and the description meta tag.
+// Relative image URLs are resolved against pageURL; images that are not
+// http or https are dropped.
+func parseOpenGraph(pageURL string, page []byte) pageMetadata {
+	properties := make(map[string]string)
+	for _, tag := range metaTagPattern.FindAll(page, -1) {
+		attributes := make(map[string]string)
+		for _, match := range attributePattern.FindAllSubmatch(tag, -1) {
+			value := string(match[2]) + string(match[3]) + string(match[4])
+			attributes[strings.ToLower(string(match[1]))] = html.UnescapeString(value)
+		}
+
+		key := attributes["property"]
+		if key == "" {
+			key = attributes["name"]
+		}
+		key = strings.ToLower(key)
+		if _, seen := properties[key]; key != "" && !seen {
+			properties[key] = attributes["content"]
+		}
+	}
+
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if value := strings.TrimSpace(properties[key]); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	meta := pageMetadata{
+		title:       first("og:title", "twitter:title"),
+		description: first("og:description", "twitter:description", "description"),
+		siteName:    first("og:site_name"),
+	}
+	if meta.title == "" {
+		if match := titleTagPattern.FindSubmatch(page); match != nil {
+			meta.title = html.UnescapeString(string(match[1]))
+		}
+	}
+	if image := first("og:image", "og:image:url", "twitter:image"); image != "" {
+		meta.imageURL = resolveURL(pageURL, image)
+	}
+	return meta
+}
+
+func resolveURL(base, ref string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ""
+	}
+	resolved, err := baseURL.Parse(ref)
+	if err != nil || (resolved.Scheme != "http" && resolved.Scheme != "https") {
+		return ""
+	}
+	return resolved.String()
+}
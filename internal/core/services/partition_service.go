@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+// PartitionConfig is the partitioning policy. TimelineMaxAge and ArchiveAfter
+// are the ones of RetentionConfig: a month is only detached once retention
+// has no use for its rows.
+type PartitionConfig struct {
+	// MonthsAhead is how many months after the current one get a partition
+	// in advance.
+	MonthsAhead int
+	// TimelineMaxAge detaches the timeline months older than this, rows and
+	// all, since timeline entries are only copies of the tweets.
+	TimelineMaxAge time.Duration
+	// ArchiveAfter detaches the months older than this once archiving has
+	// moved every tweet out of them.
+	ArchiveAfter time.Duration
+	// DropDetached drops the partitions once detached. Otherwise they are
+	// kept, for an operator to back up or drop.
+	DropDetached bool
+}
+
+type partitionService struct {
+	partitionRepo ports.PartitionRepository
+	cfg           PartitionConfig
+	logger        *slog.Logger
+	now           func() time.Time
+}
+
+func NewPartitionService(partitionRepo ports.PartitionRepository, cfg PartitionConfig, logger *slog.Logger, now func() time.Time) ports.PartitionService {
+	return &partitionService{
+		partitionRepo: partitionRepo,
+		cfg:           cfg,
+		logger:        logger.With("component", "PartitionService"),
+		now:           now,
+	}
+}
+
+// PreparePartitions fails fast, since tweets cannot be published into a month
+// without a partition.
+func (s *partitionService) PreparePartitions(ctx context.Context) error {
+	now := s.now()
+	for _, table := range []string{domain.TweetsTable, domain.TimelinesTable} {
+		if err := s.createPartitions(ctx, table, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaintainPartitions prepares the partitions first. Detaching is best effort:
+// a month that cannot be detached is retried on the next run.
+func (s *partitionService) MaintainPartitions(ctx context.Context) error {
+	if err := s.PreparePartitions(ctx); err != nil {
+		return err
+	}
+
+	now := s.now()
+
+	var errs []error
+	if s.cfg.ArchiveAfter > 0 {
+		// The timeline entries of archived tweets are deleted with them, so
+		// both months are empty by the time archiving is done with them.
+		cutoff := now.Add(-s.cfg.ArchiveAfter)
+		errs = append(errs, s.detachPartitions(ctx, domain.TimelinesTable, cutoff, true))
+		errs = append(errs, s.detachPartitions(ctx, domain.TweetsTable, cutoff, true))
+	}
+	if s.cfg.TimelineMaxAge > 0 {
+		errs = append(errs, s.detachPartitions(ctx, domain.TimelinesTable, now.Add(-s.cfg.TimelineMaxAge), false))
+	}
+	return errors.Join(errs...)
+}
+
+func (s *partitionService) createPartitions(ctx context.Context, table string, now time.Time) error {
+	existing, err := s.partitionRepo.GetPartitions(ctx, table)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(existing))
+	for _, p := range existing {
+		names[p.Name()] = true
+	}
+
+	// A month whose rows already landed in the default partition is skipped:
+	// its tweets keep going there until an operator moves them, as the README
+	// describes, and failing would keep the server from ever booting again.
+	p := domain.MonthlyPartition(table, now)
+	for range s.cfg.MonthsAhead + 1 {
+		if !names[p.Name()] {
+			err := s.partitionRepo.CreatePartition(ctx, p)
+			switch {
+			case errors.Is(err, domain.ErrPartitionRowsInDefault):
+				s.logger.Error("Partition needs its rows moved out of the default partition by hand", "error", err, "partition", p.Name())
+			case err != nil:
+				return err
+			default:
+				s.logger.Info("Created partition", "partition", p.Name())
+			}
+		}
+		p = p.Next()
+	}
+	return nil
+}
+
+// detachPartitions detaches the partitions of table that end before cutoff.
+func (s *partitionService) detachPartitions(ctx context.Context, table string, cutoff time.Time, onlyIfEmpty bool) error {
+	partitions, err := s.partitionRepo.GetPartitions(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, p := range partitions {
+		if p.To.After(cutoff) {
+			continue
+		}
+		detached, err := s.partitionRepo.DetachPartition(ctx, p, onlyIfEmpty)
+		if err != nil {
+			s.logger.Error("Failed to detach partition", "error", err, "partition", p.Name())
+			errs = append(errs, err)
+			continue
+		}
+		if !detached {
+			continue
+		}
+		s.logger.Info("Detached partition", "partition", p.Name())
+		if s.cfg.DropDetached {
+			if err := s.partitionRepo.DropPartition(ctx, p); err != nil {
+				s.logger.Error("Failed to drop partition", "error", err, "partition", p.Name())
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPartitionService_MaintainPartitions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	month := func(table string, m time.Month) domain.Partition {
+		return domain.MonthlyPartition(table, time.Date(2025, m, 1, 0, 0, 0, 0, time.UTC))
+	}
+
+	t.Run("Success: should create the missing partitions of the current and coming months", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		partitionService := NewPartitionService(mockRepo, PartitionConfig{MonthsAhead: 2}, discardLogger, clock)
+
+		// Mocking
+		mockRepo.On("GetPartitions", ctx, domain.TweetsTable).Return([]domain.Partition{month(domain.TweetsTable, time.June)}, nil)
+		mockRepo.On("CreatePartition", ctx, month(domain.TweetsTable, time.July)).Return(nil).Once()
+		mockRepo.On("CreatePartition", ctx, month(domain.TweetsTable, time.August)).Return(nil).Once()
+		mockRepo.On("GetPartitions", ctx, domain.TimelinesTable).Return([]domain.Partition{
+			month(domain.TimelinesTable, time.June), month(domain.TimelinesTable, time.July), month(domain.TimelinesTable, time.August),
+		}, nil)
+
+		// Execute
+		err := partitionService.MaintainPartitions(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "DetachPartition", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should detach archived months once empty and timeline months past their age", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		partitionService := NewPartitionService(mockRepo, PartitionConfig{
+			TimelineMaxAge: 60 * 24 * time.Hour,
+			ArchiveAfter:   120 * 24 * time.Hour,
+		}, discardLogger, clock)
+
+		tweetMonths := []domain.Partition{month(domain.TweetsTable, time.January), month(domain.TweetsTable, time.June)}
+		timelineMonths := []domain.Partition{
+			month(domain.TimelinesTable, time.January), month(domain.TimelinesTable, time.March), month(domain.TimelinesTable, time.June),
+		}
+		mockRepo.On("GetPartitions", ctx, domain.TweetsTable).Return(tweetMonths, nil)
+		mockRepo.On("GetPartitions", ctx, domain.TimelinesTable).Return(timelineMonths, nil)
+		mockRepo.On("DetachPartition", ctx, month(domain.TimelinesTable, time.January), true).Return(true, nil).Once()
+		mockRepo.On("DetachPartition", ctx, month(domain.TweetsTable, time.January), true).Return(false, nil).Once()
+		mockRepo.On("DetachPartition", ctx, month(domain.TimelinesTable, time.January), false).Return(true, nil).Once()
+		mockRepo.On("DetachPartition", ctx, month(domain.TimelinesTable, time.March), false).Return(true, nil).Once()
+
+		err := partitionService.MaintainPartitions(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "DetachPartition", ctx, month(domain.TimelinesTable, time.June), mock.Anything)
+		mockRepo.AssertNotCalled(t, "DropPartition", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should drop the detached months when asked to", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		partitionService := NewPartitionService(mockRepo, PartitionConfig{
+			TimelineMaxAge: 60 * 24 * time.Hour,
+			DropDetached:   true,
+		}, discardLogger, clock)
+
+		mockRepo.On("GetPartitions", ctx, domain.TweetsTable).Return([]domain.Partition{month(domain.TweetsTable, time.June)}, nil)
+		mockRepo.On("GetPartitions", ctx, domain.TimelinesTable).Return([]domain.Partition{
+			month(domain.TimelinesTable, time.February), month(domain.TimelinesTable, time.March), month(domain.TimelinesTable, time.June),
+		}, nil)
+		mockRepo.On("DetachPartition", ctx, month(domain.TimelinesTable, time.February), false).Return(true, nil).Once()
+		mockRepo.On("DetachPartition", ctx, month(domain.TimelinesTable, time.March), false).Return(false, nil).Once()
+		mockRepo.On("DropPartition", ctx, month(domain.TimelinesTable, time.February)).Return(nil).Once()
+
+		err := partitionService.MaintainPartitions(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "DropPartition", ctx, month(domain.TimelinesTable, time.March))
+	})
+
+	t.Run("Failure: should keep detaching after a month fails", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		partitionService := NewPartitionService(mockRepo, PartitionConfig{TimelineMaxAge: 30 * 24 * time.Hour}, discardLogger, clock)
+
+		lockErr := errors.New("lock timeout")
+		mockRepo.On("GetPartitions", ctx, domain.TweetsTable).Return([]domain.Partition{month(domain.TweetsTable, time.June)}, nil)
+		mockRepo.On("GetPartitions", ctx, domain.TimelinesTable).Return([]domain.Partition{
+			month(domain.TimelinesTable, time.March), month(domain.TimelinesTable, time.April), month(domain.TimelinesTable, time.June),
+		}, nil)
+		mockRepo.On("DetachPartition", ctx, month(domain.TimelinesTable, time.March), false).Return(false, lockErr)
+		mockRepo.On("DetachPartition", ctx, month(domain.TimelinesTable, time.April), false).Return(true, nil)
+
+		err := partitionService.MaintainPartitions(ctx)
+
+		assert.ErrorIs(t, err, lockErr)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should skip a month whose rows are in the default partition", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		partitionService := NewPartitionService(mockRepo, PartitionConfig{MonthsAhead: 1}, discardLogger, clock)
+
+		mockRepo.On("GetPartitions", ctx, domain.TweetsTable).Return(nil, nil)
+		mockRepo.On("CreatePartition", ctx, month(domain.TweetsTable, time.June)).Return(fmt.Errorf("error creating partition: %w", domain.ErrPartitionRowsInDefault)).Once()
+		mockRepo.On("CreatePartition", ctx, month(domain.TweetsTable, time.July)).Return(nil).Once()
+		mockRepo.On("GetPartitions", ctx, domain.TimelinesTable).Return([]domain.Partition{
+			month(domain.TimelinesTable, time.June), month(domain.TimelinesTable, time.July),
+		}, nil)
+
+		err := partitionService.MaintainPartitions(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should stop when a partition cannot be created", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		partitionService := NewPartitionService(mockRepo, PartitionConfig{ArchiveAfter: time.Hour}, discardLogger, clock)
+
+		dbErr := errors.New("permission denied")
+		mockRepo.On("GetPartitions", ctx, domain.TweetsTable).Return(nil, nil)
+		mockRepo.On("CreatePartition", ctx, month(domain.TweetsTable, time.June)).Return(dbErr)
+
+		err := partitionService.MaintainPartitions(ctx)
+
+		assert.ErrorIs(t, err, dbErr)
+		mockRepo.AssertNotCalled(t, "DetachPartition", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPartitionService_PreparePartitions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success: should create the missing partitions without detaching old ones", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		partitionService := NewPartitionService(mockRepo, PartitionConfig{TimelineMaxAge: 30 * 24 * time.Hour}, discardLogger, clock)
+
+		june := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+		// Mocking
+		mockRepo.On("GetPartitions", ctx, domain.TweetsTable).Return([]domain.Partition{domain.MonthlyPartition(domain.TweetsTable, june)}, nil)
+		mockRepo.On("GetPartitions", ctx, domain.TimelinesTable).Return([]domain.Partition{
+			domain.MonthlyPartition(domain.TimelinesTable, june.AddDate(0, -3, 0)), domain.MonthlyPartition(domain.TimelinesTable, june),
+		}, nil)
+
+		// Execute
+		err := partitionService.PreparePartitions(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "DetachPartition", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
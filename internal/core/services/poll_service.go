@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+type pollService struct {
+	pollRepo   ports.PollRepository
+	tweetRepo  ports.TweetRepository
+	visibility tweetVisibility
+	now        func() time.Time
+}
+
+func NewPollService(pollRepo ports.PollRepository, tweetRepo ports.TweetRepository, userRepo ports.UserRepository, now func() time.Time) ports.PollService {
+	return &pollService{
+		pollRepo:   pollRepo,
+		tweetRepo:  tweetRepo,
+		visibility: tweetVisibility{userRepo: userRepo},
+		now:        now,
+	}
+}
+
+// Vote records the user's vote and returns the results, which the user can
+// now see.
+func (s *pollService) Vote(ctx context.Context, userID, tweetID string, option int) (*domain.PollResults, error) {
+	poll, err := s.visiblePoll(ctx, userID, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if option < 0 || option >= len(poll.Options) {
+		return nil, domain.ErrInvalidPollOption
+	}
+
+	if err := s.pollRepo.AddPollVote(ctx, tweetID, userID, option, s.now()); err != nil {
+		return nil, err
+	}
+	return s.results(ctx, tweetID, poll, &option)
+}
+
+// GetPollResults counts the votes on every read, so the tallies are always
+// live; they are left out until the viewer has voted or the poll has closed.
+func (s *pollService) GetPollResults(ctx context.Context, viewerID, tweetID string) (*domain.PollResults, error) {
+	poll, err := s.visiblePoll(ctx, viewerID, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	votedOption, err := s.pollRepo.GetPollVote(ctx, tweetID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.results(ctx, tweetID, poll, votedOption)
+}
+
+func (s *pollService) results(ctx context.Context, tweetID string, poll *domain.Poll, votedOption *int) (*domain.PollResults, error) {
+	tallies, err := s.pollRepo.GetPollTallies(ctx, tweetID, len(poll.Options))
+	if err != nil {
+		return nil, err
+	}
+	results := domain.NewPollResults(poll, tallies, votedOption, s.now())
+	return &results, nil
+}
+
+// visiblePoll loads the poll of a tweet the viewer is allowed to see. Tweets
+// without a poll, or hidden from the viewer, are reported as ErrPollNotFound.
+func (s *pollService) visiblePoll(ctx context.Context, viewerID, tweetID string) (*domain.Poll, error) {
+	tweet, err := s.tweetRepo.GetTweet(ctx, tweetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTweetNotFound) {
+			return nil, domain.ErrPollNotFound
+		}
+		return nil, err
+	}
+	if tweet.Poll == nil {
+		return nil, domain.ErrPollNotFound
+	}
+
+	visible, err := s.visibility.filter(ctx, viewerID, []domain.Tweet{*tweet})
+	if err != nil {
+		return nil, err
+	}
+	if len(visible) == 0 {
+		return nil, domain.ErrPollNotFound
+	}
+	return tweet.Poll, nil
+}
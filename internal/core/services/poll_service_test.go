@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	tweet := &domain.Tweet{
+		ID:     "tweet-1",
+		UserID: "ana",
+		Text:   "¿Mate o café?",
+		Poll:   &domain.Poll{Options: []string{"Mate", "Café"}, EndsAt: now.Add(time.Hour)},
+	}
+
+	t.Run("Success: should record the vote and return the results", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		pollService := NewPollService(mockRepo, mockRepo, mockRepo, clock)
+
+		// Mocking
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("AddPollVote", ctx, "tweet-1", "juan", 0, now).Return(nil)
+		mockRepo.On("GetPollTallies", ctx, "tweet-1", 2).Return([]int{5, 2}, nil)
+
+		// Execute
+		results, err := pollService.Vote(ctx, "juan", "tweet-1", 0)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []int{5, 2}, results.Votes)
+		assert.Equal(t, 0, *results.VotedOption)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should hide the running results from users who have not voted", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		pollService := NewPollService(mockRepo, mockRepo, mockRepo, clock)
+
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("GetPollVote", ctx, "tweet-1", "juan").Return(nil, nil)
+		mockRepo.On("GetPollTallies", ctx, "tweet-1", 2).Return([]int{5, 2}, nil)
+
+		results, err := pollService.GetPollResults(ctx, "juan", "tweet-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Mate", "Café"}, results.Options)
+		assert.Nil(t, results.Votes)
+	})
+
+	t.Run("Failure: should reject an option the poll does not have", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		pollService := NewPollService(mockRepo, mockRepo, mockRepo, clock)
+
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+
+		_, err := pollService.Vote(ctx, "juan", "tweet-1", 2)
+
+		assert.Equal(t, domain.ErrInvalidPollOption, err)
+		mockRepo.AssertNotCalled(t, "AddPollVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should not count a second vote from the same user", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		pollService := NewPollService(mockRepo, mockRepo, mockRepo, clock)
+
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("AddPollVote", ctx, "tweet-1", "juan", 1, now).Return(domain.ErrAlreadyVoted)
+
+		_, err := pollService.Vote(ctx, "juan", "tweet-1", 1)
+
+		assert.Equal(t, domain.ErrAlreadyVoted, err)
+		mockRepo.AssertNotCalled(t, "GetPollTallies", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should report tweets without a poll as not found", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		pollService := NewPollService(mockRepo, mockRepo, mockRepo, clock)
+
+		mockRepo.On("GetTweet", ctx, "tweet-2").Return(&domain.Tweet{ID: "tweet-2", UserID: "ana"}, nil)
+
+		_, err := pollService.GetPollResults(ctx, "juan", "tweet-2")
+
+		assert.Equal(t, domain.ErrPollNotFound, err)
+	})
+}
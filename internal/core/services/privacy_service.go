@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+// erasureBatchSize bounds how many accounts one run of EraseAccounts erases.
+const erasureBatchSize = 20
+
+type privacyService struct {
+	accountRepo ports.AccountDataRepository
+	userRepo    ports.UserRepository
+	blobs       ports.BlobStore
+	broker      ports.EventBroker
+	logger      *slog.Logger
+	now         func() time.Time
+}
+
+func NewPrivacyService(
+	accountRepo ports.AccountDataRepository,
+	userRepo ports.UserRepository,
+	blobs ports.BlobStore,
+	broker ports.EventBroker,
+	logger *slog.Logger,
+	now func() time.Time,
+) ports.PrivacyService {
+	return &privacyService{
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		blobs:       blobs,
+		broker:      broker,
+		logger:      logger.With("component", "PrivacyService"),
+		now:         now,
+	}
+}
+
+func (s *privacyService) ExportData(ctx context.Context, userID string) (*domain.DataExport, error) {
+	users, err := s.userRepo.GetUsers(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	user := domain.User{ID: userID, Status: domain.UserActive}
+	if len(users) > 0 {
+		user = users[0]
+	}
+
+	tweets, err := s.accountRepo.GetUserTweets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	following, err := s.accountRepo.GetFollowing(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	followers, err := s.userRepo.GetFollowers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DataExport{
+		User:       user,
+		Tweets:     tweets,
+		Following:  following,
+		Followers:  followers,
+		ExportedAt: s.now(),
+	}, nil
+}
+
+// RequestErasure deactivates the account, so its tweets are hidden until it
+// is erased, and queues it for erasure. A suspension is kept as it is.
+func (s *privacyService) RequestErasure(ctx context.Context, userID string) error {
+	users, err := s.userRepo.GetUsers(ctx, []string{userID})
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 || users[0].Active() {
+		if err := s.userRepo.SetStatus(ctx, userID, domain.UserDeactivated); err != nil {
+			return err
+		}
+	}
+	return s.accountRepo.RequestErasure(ctx, userID, s.now())
+}
+
+// EraseAccounts erases the accounts waiting for it. An account that cannot be
+// erased keeps its request and is retried on the next run.
+func (s *privacyService) EraseAccounts(ctx context.Context) error {
+	userIDs, err := s.accountRepo.GetErasureRequests(ctx, erasureBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userID := range userIDs {
+		if err := s.erase(ctx, userID); err != nil {
+			s.logger.Error("Failed to erase account", "error", err, "userID", userID)
+			errs = append(errs, err)
+			continue
+		}
+		s.logger.Info("Erased account", "userID", userID)
+	}
+	return errors.Join(errs...)
+}
+
+// erase removes what lives outside the database first, the uploaded media
+// and the retained stream events, while the rows pointing at it still exist:
+// a failure halfway leaves the request in place and the next run starts over.
+// The user's own topics and the threads of their tweets are dropped whole.
+// Only the user's events are removed from their followers' timelines and from
+// the notifications of the users they notified, so that the rest of those
+// streams can still be resumed.
+func (s *privacyService) erase(ctx context.Context, userID string) error {
+	media, err := s.accountRepo.GetUserMedia(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, m := range media {
+		if err := s.blobs.Delete(ctx, m.BlobKey()); err != nil {
+			return err
+		}
+		if err := s.blobs.Delete(ctx, m.ThumbnailKey()); err != nil {
+			return err
+		}
+	}
+
+	tweets, err := s.accountRepo.GetUserTweets(ctx, userID)
+	if err != nil {
+		return err
+	}
+	followers, err := s.userRepo.GetFollowers(ctx, userID)
+	if err != nil {
+		return err
+	}
+	notified, err := s.accountRepo.GetNotifiedUsers(ctx, userID)
+	if err != nil {
+		return err
+	}
+	topics := []string{domain.TimelineTopic(userID), domain.NotificationsTopic(userID)}
+	for _, tweet := range tweets {
+		topics = append(topics, domain.ThreadTopic(tweet.ID))
+	}
+	if err := s.broker.DeleteHistory(ctx, topics...); err != nil {
+		return err
+	}
+	var audience []string
+	for _, followerID := range followers {
+		audience = append(audience, domain.TimelineTopic(followerID))
+	}
+	for _, recipientID := range notified {
+		audience = append(audience, domain.NotificationsTopic(recipientID))
+	}
+	if err := s.broker.DeleteUserEvents(ctx, userID, audience...); err != nil {
+		return err
+	}
+
+	return s.accountRepo.EraseUserTx(ctx, userID)
+}
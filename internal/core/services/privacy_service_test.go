@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivacyService_ExportData(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success: should gather the profile, tweets and follow graph of the user", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		privacyService := NewPrivacyService(mockRepo, mockRepo, new(mocks.BlobStore), new(mocks.EventBroker), discardLogger, func() time.Time { return now })
+
+		tweets := []domain.Tweet{{ID: "t2", UserID: "ana"}, {ID: "t1", UserID: "ana"}}
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana", Protected: true, Status: domain.UserActive}}, nil)
+		mockRepo.On("GetUserTweets", ctx, "ana").Return(tweets, nil)
+		mockRepo.On("GetFollowing", ctx, "ana").Return([]string{"beto"}, nil)
+		mockRepo.On("GetFollowers", ctx, "ana").Return([]string{"carla", "dani"}, nil)
+
+		export, err := privacyService.ExportData(ctx, "ana")
+
+		require.NoError(t, err)
+		assert.True(t, export.User.Protected)
+		assert.Equal(t, tweets, export.Tweets)
+		assert.Equal(t, []string{"beto"}, export.Following)
+		assert.Equal(t, []string{"carla", "dani"}, export.Followers)
+		assert.Equal(t, now, export.ExportedAt)
+	})
+}
+
+func TestPrivacyService_RequestErasure(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success: should deactivate the account and queue it for erasure", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		privacyService := NewPrivacyService(mockRepo, mockRepo, new(mocks.BlobStore), new(mocks.EventBroker), discardLogger, func() time.Time { return now })
+
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana", Status: domain.UserActive}}, nil)
+		mockRepo.On("SetStatus", ctx, "ana", domain.UserDeactivated).Return(nil)
+		mockRepo.On("RequestErasure", ctx, "ana", now).Return(nil)
+
+		err := privacyService.RequestErasure(ctx, "ana")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should keep a suspension in place", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		privacyService := NewPrivacyService(mockRepo, mockRepo, new(mocks.BlobStore), new(mocks.EventBroker), discardLogger, func() time.Time { return now })
+
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana", Status: domain.UserSuspended}}, nil)
+		mockRepo.On("RequestErasure", ctx, "ana", now).Return(nil)
+
+		err := privacyService.RequestErasure(ctx, "ana")
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "SetStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPrivacyService_EraseAccounts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success: should delete the media and stream history, the notified users' included, before erasing the user", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mockBroker := new(mocks.EventBroker)
+		privacyService := NewPrivacyService(mockRepo, mockRepo, mockBlobs, mockBroker, discardLogger, func() time.Time { return now })
+
+		media := domain.Media{ID: "m1", UserID: "ana"}
+
+		// Mocking
+		mockRepo.On("GetErasureRequests", ctx, erasureBatchSize).Return([]string{"ana"}, nil)
+		mockRepo.On("GetUserMedia", ctx, "ana").Return([]domain.Media{media}, nil)
+		mockBlobs.On("Delete", ctx, media.BlobKey()).Return(nil)
+		mockBlobs.On("Delete", ctx, media.ThumbnailKey()).Return(nil)
+		mockRepo.On("GetUserTweets", ctx, "ana").Return([]domain.Tweet{{ID: "t1", UserID: "ana"}}, nil)
+		mockRepo.On("GetFollowers", ctx, "ana").Return([]string{"beto"}, nil)
+		mockRepo.On("GetNotifiedUsers", ctx, "ana").Return([]string{"beto", "carla"}, nil)
+		mockBroker.On("DeleteHistory", ctx, []string{"timeline:ana", "notifications:ana", "thread:t1"}).Return(nil)
+		mockBroker.On("DeleteUserEvents", ctx, "ana", []string{"timeline:beto", "notifications:beto", "notifications:carla"}).Return(nil)
+		mockRepo.On("EraseUserTx", ctx, "ana").Return(nil)
+
+		// Execute
+		err := privacyService.EraseAccounts(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockBlobs.AssertExpectations(t)
+		mockBroker.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should keep the request when the media cannot be deleted", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockBlobs := new(mocks.BlobStore)
+		mockBroker := new(mocks.EventBroker)
+		privacyService := NewPrivacyService(mockRepo, mockRepo, mockBlobs, mockBroker, discardLogger, func() time.Time { return now })
+
+		media := domain.Media{ID: "m1", UserID: "ana"}
+		storeErr := errors.New("store unavailable")
+		mockRepo.On("GetErasureRequests", ctx, erasureBatchSize).Return([]string{"ana", "beto"}, nil)
+		mockRepo.On("GetUserMedia", ctx, "ana").Return([]domain.Media{media}, nil)
+		mockBlobs.On("Delete", ctx, media.BlobKey()).Return(storeErr)
+		mockRepo.On("GetUserMedia", ctx, "beto").Return(nil, nil)
+		mockRepo.On("GetUserTweets", ctx, "beto").Return(nil, nil)
+		mockRepo.On("GetFollowers", ctx, "beto").Return(nil, nil)
+		mockRepo.On("GetNotifiedUsers", ctx, "beto").Return(nil, nil)
+		mockBroker.On("DeleteHistory", ctx, []string{"timeline:beto", "notifications:beto"}).Return(nil)
+		mockBroker.On("DeleteUserEvents", ctx, "beto", []string(nil)).Return(nil)
+		mockRepo.On("EraseUserTx", ctx, "beto").Return(nil)
+
+		err := privacyService.EraseAccounts(ctx)
+
+		assert.ErrorIs(t, err, storeErr)
+		mockRepo.AssertNotCalled(t, "EraseUserTx", ctx, "ana")
+		mockRepo.AssertCalled(t, "EraseUserTx", ctx, "beto")
+	})
+}
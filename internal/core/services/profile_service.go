@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const profilePageSize = 20
+
+type profileService struct {
+	profileRepo      ports.ProfileRepository
+	relationshipRepo ports.RelationshipRepository
+	visibility       tweetVisibility
+}
+
+func NewProfileService(profileRepo ports.ProfileRepository, relationshipRepo ports.RelationshipRepository, userRepo ports.UserRepository) ports.ProfileService {
+	return &profileService{
+		profileRepo:      profileRepo,
+		relationshipRepo: relationshipRepo,
+		visibility:       tweetVisibility{userRepo: userRepo},
+	}
+}
+
+// GetProfileTweets fails with ErrUserBlocked if either user blocked the
+// other. The tweets of protected and inactive accounts are hidden the same
+// way as everywhere else, which leaves such profiles empty.
+func (s *profileService) GetProfileTweets(ctx context.Context, viewerID, userID, cursor string) (domain.TweetPage, error) {
+	decoded, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+
+	if viewerID != userID {
+		blocked, err := s.relationshipRepo.GetBlockedUsers(ctx, viewerID, []string{userID})
+		if err != nil {
+			return domain.TweetPage{}, err
+		}
+		if len(blocked) > 0 {
+			return domain.TweetPage{}, domain.ErrUserBlocked
+		}
+	}
+
+	tweets, err := s.profileRepo.GetProfileTweets(ctx, userID, decoded, profilePageSize+1)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+
+	page := domain.NewTweetPage(tweets, profilePageSize)
+	if page.Tweets, err = s.visibility.filter(ctx, viewerID, page.Tweets); err != nil {
+		return domain.TweetPage{}, err
+	}
+	if len(page.Tweets) == 0 {
+		page.NextCursor = ""
+	}
+	return page, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileService_GetProfileTweets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success: should return a page of the user's tweets with a cursor for the next one", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		profileService := NewProfileService(mockRepo, mockRepo, mockRepo)
+
+		tweets := make([]domain.Tweet, profilePageSize+1)
+		for i := range tweets {
+			tweets[i] = domain.Tweet{ID: string(rune('z' - i)), UserID: "ana", CreatedAt: now.Add(-time.Duration(i) * time.Hour)}
+		}
+		mockRepo.On("GetBlockedUsers", ctx, "beto", []string{"ana"}).Return(nil, nil)
+		mockRepo.On("GetProfileTweets", ctx, "ana", (*domain.Cursor)(nil), profilePageSize+1).Return(tweets, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana", Status: domain.UserActive}}, nil)
+
+		page, err := profileService.GetProfileTweets(ctx, "beto", "ana", "")
+
+		require.NoError(t, err)
+		assert.Len(t, page.Tweets, profilePageSize)
+		assert.Equal(t, domain.CursorAfter(tweets[profilePageSize-1]).Encode(), page.NextCursor)
+	})
+
+	t.Run("Success: should hide the tweets of a protected account from non-followers", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		profileService := NewProfileService(mockRepo, mockRepo, mockRepo)
+
+		tweets := []domain.Tweet{{ID: "t1", UserID: "ana", CreatedAt: now}}
+		mockRepo.On("GetBlockedUsers", ctx, "beto", []string{"ana"}).Return(nil, nil)
+		mockRepo.On("GetProfileTweets", ctx, "ana", (*domain.Cursor)(nil), profilePageSize+1).Return(tweets, nil)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana", Protected: true, Status: domain.UserActive}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, "beto", []string{"ana"}).Return(nil, nil)
+
+		page, err := profileService.GetProfileTweets(ctx, "beto", "ana", "")
+
+		require.NoError(t, err)
+		assert.Empty(t, page.Tweets)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Failure: should reject viewers blocked by the user", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		profileService := NewProfileService(mockRepo, mockRepo, mockRepo)
+
+		mockRepo.On("GetBlockedUsers", ctx, "beto", []string{"ana"}).Return([]string{"ana"}, nil)
+
+		_, err := profileService.GetProfileTweets(ctx, "beto", "ana", "")
+
+		assert.Equal(t, domain.ErrUserBlocked, err)
+		mockRepo.AssertNotCalled(t, "GetProfileTweets", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
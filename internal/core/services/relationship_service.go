@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+var (
+	ErrSelfBlock = errors.New("a user cannot block themselves")
+	ErrSelfMute  = errors.New("a user cannot mute themselves")
+)
+
+type relationshipService struct {
+	relationshipRepo ports.RelationshipRepository
+}
+
+func NewRelationshipService(relationshipRepo ports.RelationshipRepository) ports.RelationshipService {
+	return &relationshipService{relationshipRepo: relationshipRepo}
+}
+
+func (s *relationshipService) BlockUser(ctx context.Context, currentUserID, userToBlockID string) error {
+	if currentUserID == userToBlockID {
+		return ErrSelfBlock
+	}
+	return s.relationshipRepo.BlockTx(ctx, currentUserID, userToBlockID)
+}
+
+func (s *relationshipService) UnblockUser(ctx context.Context, currentUserID, userToUnblockID string) error {
+	return s.relationshipRepo.Unblock(ctx, currentUserID, userToUnblockID)
+}
+
+func (s *relationshipService) MuteUser(ctx context.Context, currentUserID, userToMuteID string) error {
+	if currentUserID == userToMuteID {
+		return ErrSelfMute
+	}
+	return s.relationshipRepo.Mute(ctx, currentUserID, userToMuteID)
+}
+
+func (s *relationshipService) UnmuteUser(ctx context.Context, currentUserID, userToUnmuteID string) error {
+	return s.relationshipRepo.Unmute(ctx, currentUserID, userToUnmuteID)
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRelationshipService_BlockUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success: should block a user", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		relationshipService := NewRelationshipService(mockRepo)
+
+		mockRepo.On("BlockTx", ctx, "user-pepita", "user-pepito").Return(nil)
+
+		err := relationshipService.BlockUser(ctx, "user-pepita", "user-pepito")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not allow self-block", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		relationshipService := NewRelationshipService(mockRepo)
+
+		err := relationshipService.BlockUser(ctx, "user-pepita", "user-pepita")
+
+		assert.Equal(t, ErrSelfBlock, err)
+		mockRepo.AssertNotCalled(t, "BlockTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRelationshipService_MuteUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success: should mute a user", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		relationshipService := NewRelationshipService(mockRepo)
+
+		mockRepo.On("Mute", ctx, "user-pepita", "user-pepito").Return(nil)
+
+		err := relationshipService.MuteUser(ctx, "user-pepita", "user-pepito")
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not allow self-mute", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		relationshipService := NewRelationshipService(mockRepo)
+
+		err := relationshipService.MuteUser(ctx, "user-pepita", "user-pepita")
+
+		assert.Equal(t, ErrSelfMute, err)
+		mockRepo.AssertNotCalled(t, "Mute", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
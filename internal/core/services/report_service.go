@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+// reportsLimit bounds the reports returned at once to an admin.
+const reportsLimit = 100
+
+type reportService struct {
+	reportRepo ports.ReportRepository
+	tweetRepo  ports.TweetRepository
+	userRepo   ports.UserRepository
+	visibility tweetVisibility
+	logger     *slog.Logger
+	now        func() time.Time
+}
+
+func NewReportService(
+	reportRepo ports.ReportRepository,
+	tweetRepo ports.TweetRepository,
+	userRepo ports.UserRepository,
+	logger *slog.Logger,
+	now func() time.Time,
+) ports.ReportService {
+	return &reportService{
+		reportRepo: reportRepo,
+		tweetRepo:  tweetRepo,
+		userRepo:   userRepo,
+		visibility: tweetVisibility{userRepo: userRepo},
+		logger:     logger.With("component", "ReportService"),
+		now:        now,
+	}
+}
+
+// CreateReport files a report on a tweet the reporter can see, or on an
+// existing account. Tweets the reporter is not allowed to see are reported
+// as not found.
+func (s *reportService) CreateReport(ctx context.Context, reporterID string, input domain.ReportInput) (*domain.Report, error) {
+	var reportedUserID string
+	switch input.TargetType {
+	case domain.ReportTargetTweet:
+		tweet, err := s.tweetRepo.GetTweet(ctx, input.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		visible, err := s.visibility.filter(ctx, reporterID, []domain.Tweet{*tweet})
+		if err != nil {
+			return nil, err
+		}
+		if len(visible) == 0 {
+			return nil, domain.ErrTweetNotFound
+		}
+		reportedUserID = tweet.UserID
+	case domain.ReportTargetUser:
+		users, err := s.userRepo.GetUsers(ctx, []string{input.TargetID})
+		if err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, domain.ErrUserNotFound
+		}
+		reportedUserID = input.TargetID
+	default:
+		return nil, domain.ErrInvalidReportTarget
+	}
+
+	report, err := domain.NewReport(reporterID, reportedUserID, input, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reportRepo.AddReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *reportService) GetReports(ctx context.Context, status domain.ReportStatus) ([]domain.Report, error) {
+	return s.reportRepo.GetReports(ctx, status, reportsLimit)
+}
+
+func (s *reportService) TriageReport(ctx context.Context, adminID, reportID string) (*domain.Report, error) {
+	report, err := s.reportRepo.GetReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if err := report.Triage(adminID, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.reportRepo.UpdateReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ResolveReport records the admin's decision. Acting on the report, e.g.
+// suspending the account or removing the tweet, is up to the admin through
+// the ModerationService.
+func (s *reportService) ResolveReport(ctx context.Context, adminID, reportID string, resolution domain.ReportResolution, note string) (*domain.Report, error) {
+	report, err := s.reportRepo.GetReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if err := report.Resolve(adminID, resolution, note, s.now()); err != nil {
+		return nil, err
+	}
+	if err := s.reportRepo.UpdateReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Resolved report", "reportID", report.ID, "adminID", adminID, "resolution", resolution)
+	return report, nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportService_CreateReport(t *testing.T) {
+	ctx := context.Background()
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	tweet := &domain.Tweet{ID: "tweet-1", UserID: "beto", Text: "Comprá seguidores"}
+
+	t.Run("Success: should report the author of the tweet", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		reportService := NewReportService(mockRepo, mockRepo, mockRepo, discardLogger, clock)
+
+		// Mocking
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"beto"}).Return([]domain.User{{ID: "beto"}}, nil)
+		mockRepo.On("AddReport", ctx, mock.MatchedBy(func(report *domain.Report) bool {
+			return report.ReporterID == "ana" && report.ReportedUserID == "beto" && report.TargetID == "tweet-1"
+		})).Return(nil)
+
+		// Execute
+		report, err := reportService.CreateReport(ctx, "ana", domain.ReportInput{
+			TargetType: domain.ReportTargetTweet,
+			TargetID:   "tweet-1",
+			Reason:     domain.ReportReasonSpam,
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReportOpen, report.Status)
+		assert.Equal(t, now, report.CreatedAt)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not report tweets the reporter cannot see", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		reportService := NewReportService(mockRepo, mockRepo, mockRepo, discardLogger, clock)
+
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"beto"}).Return([]domain.User{{ID: "beto", Protected: true}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, "ana", []string{"beto"}).Return([]string{}, nil)
+
+		_, err := reportService.CreateReport(ctx, "ana", domain.ReportInput{TargetType: domain.ReportTargetTweet, TargetID: "tweet-1", Reason: domain.ReportReasonSpam})
+
+		assert.Equal(t, domain.ErrTweetNotFound, err)
+		mockRepo.AssertNotCalled(t, "AddReport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should not report unknown users", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		reportService := NewReportService(mockRepo, mockRepo, mockRepo, discardLogger, clock)
+
+		mockRepo.On("GetUsers", ctx, []string{"nadie"}).Return([]domain.User{}, nil)
+
+		_, err := reportService.CreateReport(ctx, "ana", domain.ReportInput{TargetType: domain.ReportTargetUser, TargetID: "nadie", Reason: domain.ReportReasonHarassment})
+
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+}
+
+func TestReportService_ResolveReport(t *testing.T) {
+	ctx := context.Background()
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("Success: should store the decision", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		reportService := NewReportService(mockRepo, mockRepo, mockRepo, discardLogger, clock)
+
+		mockRepo.On("GetReport", ctx, "report-1").Return(&domain.Report{ID: "report-1", Status: domain.ReportTriaged}, nil)
+		mockRepo.On("UpdateReport", ctx, mock.MatchedBy(func(report *domain.Report) bool {
+			return report.Status == domain.ReportResolved && report.ModeratorID == "admin-1" && report.Resolution == domain.ReportDismissed
+		})).Return(nil)
+
+		report, err := reportService.ResolveReport(ctx, "admin-1", "report-1", domain.ReportDismissed, "No infringe las reglas")
+
+		require.NoError(t, err)
+		assert.Equal(t, "No infringe las reglas", report.Note)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not overwrite a decision taken in the meantime", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		reportService := NewReportService(mockRepo, mockRepo, mockRepo, discardLogger, clock)
+
+		mockRepo.On("GetReport", ctx, "report-1").Return(&domain.Report{ID: "report-1", Status: domain.ReportOpen}, nil)
+		mockRepo.On("UpdateReport", ctx, mock.AnythingOfType("*domain.Report")).Return(domain.ErrReportResolved)
+
+		_, err := reportService.ResolveReport(ctx, "admin-1", "report-1", domain.ReportActioned, "")
+
+		assert.Equal(t, domain.ErrReportResolved, err)
+	})
+}
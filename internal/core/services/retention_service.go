@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const (
+	// retentionBatchSize bounds the rows touched by each statement.
+	retentionBatchSize = 500
+	// retentionUsersPerBatch bounds the timelines trimmed by each statement.
+	// Only timelines that grew are trimmed, and they only ever lose a few
+	// entries per run.
+	retentionUsersPerBatch = 100
+	// maxRetentionBatches bounds the batches of each step in a single run; a
+	// backlog is worked off over the following runs.
+	maxRetentionBatches = 100
+)
+
+// RetentionConfig is the retention policy. A zero value turns the
+// corresponding step off.
+type RetentionConfig struct {
+	// TimelineMaxEntries is how many entries each timeline keeps.
+	TimelineMaxEntries int
+	// TimelineMaxAge drops the timeline entries of older tweets.
+	TimelineMaxAge time.Duration
+	// ArchiveAfter moves tweets older than this to the archive.
+	ArchiveAfter time.Duration
+}
+
+type retentionService struct {
+	retentionRepo ports.RetentionRepository
+	cfg           RetentionConfig
+	logger        *slog.Logger
+	now           func() time.Time
+}
+
+func NewRetentionService(retentionRepo ports.RetentionRepository, cfg RetentionConfig, logger *slog.Logger, now func() time.Time) ports.RetentionService {
+	return &retentionService{
+		retentionRepo: retentionRepo,
+		cfg:           cfg,
+		logger:        logger.With("component", "RetentionService"),
+		now:           now,
+	}
+}
+
+// ApplyRetention archives first: archived tweets leave the timelines too, so
+// the trimming that follows has less to do. Several replicas may run it at
+// once; they only compete for the same rows.
+func (s *retentionService) ApplyRetention(ctx context.Context) error {
+	now := s.now()
+
+	if s.cfg.ArchiveAfter > 0 {
+		cutoff := now.Add(-s.cfg.ArchiveAfter)
+		archived, err := s.drain(ctx, func(limit int) (int, error) {
+			return s.retentionRepo.ArchiveTweets(ctx, cutoff, limit)
+		})
+		if err != nil {
+			return err
+		}
+		s.logger.Info("Archived tweets", "count", archived, "cutoff", cutoff)
+	}
+
+	if s.cfg.TimelineMaxAge > 0 {
+		cutoff := now.Add(-s.cfg.TimelineMaxAge)
+		trimmed, err := s.drain(ctx, func(limit int) (int, error) {
+			return s.retentionRepo.TrimTimelinesBefore(ctx, cutoff, limit)
+		})
+		if err != nil {
+			return err
+		}
+		s.logger.Info("Trimmed timeline entries by age", "count", trimmed, "cutoff", cutoff)
+	}
+
+	if s.cfg.TimelineMaxEntries > 0 {
+		trimmed, err := s.trimTimelines(ctx)
+		if err != nil {
+			return err
+		}
+		s.logger.Info("Trimmed timeline entries by length", "count", trimmed, "keep", s.cfg.TimelineMaxEntries)
+	}
+	return nil
+}
+
+// drain runs step until a batch comes back short or the run is out of
+// batches, and returns the rows touched.
+func (s *retentionService) drain(ctx context.Context, step func(limit int) (int, error)) (int, error) {
+	total := 0
+	for range maxRetentionBatches {
+		n, err := step(retentionBatchSize)
+		total += n
+		if err != nil || n < retentionBatchSize {
+			return total, err
+		}
+		if ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+	}
+	return total, nil
+}
+
+// trimTimelines trims the timelines that grew since they were last trimmed,
+// with the same budget as drain.
+func (s *retentionService) trimTimelines(ctx context.Context) (int, error) {
+	total := 0
+	for range maxRetentionBatches {
+		userIDs, err := s.retentionRepo.GetGrownTimelines(ctx, retentionUsersPerBatch)
+		if err != nil || len(userIDs) == 0 {
+			return total, err
+		}
+
+		n, err := s.retentionRepo.TrimTimelines(ctx, userIDs, s.cfg.TimelineMaxEntries)
+		total += n
+		if err != nil || len(userIDs) < retentionUsersPerBatch {
+			return total, err
+		}
+		if ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRetentionService_ApplyRetention(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("Success: should archive and trim in batches until a batch comes back short", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		retentionService := NewRetentionService(mockRepo, RetentionConfig{
+			TimelineMaxAge: 30 * 24 * time.Hour,
+			ArchiveAfter:   365 * 24 * time.Hour,
+		}, discardLogger, clock)
+
+		archiveCutoff := now.Add(-365 * 24 * time.Hour)
+		trimCutoff := now.Add(-30 * 24 * time.Hour)
+
+		// Mocking
+		mockRepo.On("ArchiveTweets", ctx, archiveCutoff, retentionBatchSize).Return(retentionBatchSize, nil).Twice()
+		mockRepo.On("ArchiveTweets", ctx, archiveCutoff, retentionBatchSize).Return(12, nil).Once()
+		mockRepo.On("TrimTimelinesBefore", ctx, trimCutoff, retentionBatchSize).Return(0, nil).Once()
+
+		// Execute
+		err := retentionService.ApplyRetention(ctx)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "TrimTimelines", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should stop after the batch budget of a run", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		retentionService := NewRetentionService(mockRepo, RetentionConfig{ArchiveAfter: time.Hour}, discardLogger, clock)
+
+		mockRepo.On("ArchiveTweets", ctx, now.Add(-time.Hour), retentionBatchSize).Return(retentionBatchSize, nil)
+
+		err := retentionService.ApplyRetention(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "ArchiveTweets", maxRetentionBatches)
+	})
+
+	t.Run("Success: should trim the timelines that grew batch by batch", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		retentionService := NewRetentionService(mockRepo, RetentionConfig{TimelineMaxEntries: 800}, discardLogger, clock)
+
+		firstBatch := make([]string, retentionUsersPerBatch)
+		for i := range firstBatch {
+			firstBatch[i] = "user-" + string(rune('a'+i%26)) + string(rune('a'+i/26))
+		}
+		mockRepo.On("GetGrownTimelines", ctx, retentionUsersPerBatch).Return(firstBatch, nil).Once()
+		mockRepo.On("TrimTimelines", ctx, firstBatch, 800).Return(40, nil)
+		mockRepo.On("GetGrownTimelines", ctx, retentionUsersPerBatch).Return([]string{"zoe"}, nil).Once()
+		mockRepo.On("TrimTimelines", ctx, []string{"zoe"}, 800).Return(0, nil)
+
+		err := retentionService.ApplyRetention(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should stop trimming timelines after the batch budget of a run", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		retentionService := NewRetentionService(mockRepo, RetentionConfig{TimelineMaxEntries: 800}, discardLogger, clock)
+
+		batch := make([]string, retentionUsersPerBatch)
+		mockRepo.On("GetGrownTimelines", ctx, retentionUsersPerBatch).Return(batch, nil)
+		mockRepo.On("TrimTimelines", ctx, batch, 800).Return(0, nil)
+
+		err := retentionService.ApplyRetention(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "TrimTimelines", maxRetentionBatches)
+	})
+
+	t.Run("Failure: should stop at the first failing batch", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		retentionService := NewRetentionService(mockRepo, RetentionConfig{TimelineMaxEntries: 800, ArchiveAfter: time.Hour}, discardLogger, clock)
+
+		dbErr := errors.New("lock timeout")
+		mockRepo.On("ArchiveTweets", ctx, now.Add(-time.Hour), retentionBatchSize).Return(0, dbErr)
+
+		err := retentionService.ApplyRetention(ctx)
+
+		assert.ErrorIs(t, err, dbErr)
+		mockRepo.AssertNotCalled(t, "GetGrownTimelines", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const (
+	// scheduleClaimLease is how long a replica owns the due tweets it claimed.
+	// A claim left behind by a crashed replica is retried once it expires.
+	scheduleClaimLease = 5 * time.Minute
+	scheduleBatchSize  = 100
+)
+
+type scheduleService struct {
+	scheduledRepo ports.ScheduledTweetRepository
+	tweetSvc      ports.TweetService
+	logger        *slog.Logger
+	now           func() time.Time
+}
+
+func NewScheduleService(scheduledRepo ports.ScheduledTweetRepository, tweetSvc ports.TweetService, logger *slog.Logger, now func() time.Time) ports.ScheduleService {
+	return &scheduleService{
+		scheduledRepo: scheduledRepo,
+		tweetSvc:      tweetSvc,
+		logger:        logger.With("component", "ScheduleService"),
+		now:           now,
+	}
+}
+
+func (s *scheduleService) ScheduleTweet(ctx context.Context, userID, text string, publishAt time.Time) (*domain.ScheduledTweet, error) {
+	scheduled, err := domain.NewScheduledTweet(userID, text, publishAt, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.scheduledRepo.AddScheduledTweet(ctx, scheduled); err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+func (s *scheduleService) GetScheduledTweets(ctx context.Context, userID string) ([]domain.ScheduledTweet, error) {
+	return s.scheduledRepo.GetScheduledTweets(ctx, userID)
+}
+
+func (s *scheduleService) UpdateScheduledTweet(ctx context.Context, userID, tweetID, text string, publishAt time.Time) (*domain.ScheduledTweet, error) {
+	scheduled, err := s.ownedScheduledTweet(ctx, userID, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := scheduled.Update(text, publishAt, s.now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.scheduledRepo.UpdateScheduledTweet(ctx, scheduled); err != nil {
+		return nil, err
+	}
+	return scheduled, nil
+}
+
+func (s *scheduleService) CancelScheduledTweet(ctx context.Context, userID, tweetID string) error {
+	if _, err := s.ownedScheduledTweet(ctx, userID, tweetID); err != nil {
+		return err
+	}
+	return s.scheduledRepo.DeleteScheduledTweet(ctx, tweetID)
+}
+
+// PublishDueTweets publishes the tweets whose time has come through the
+// regular TweetService, so they get the same fan-out, notifications and
+// cache invalidation as any other tweet. Every replica can run it: each due
+// tweet is claimed by exactly one of them.
+func (s *scheduleService) PublishDueTweets(ctx context.Context) error {
+	for {
+		due, err := s.scheduledRepo.ClaimDueTweets(ctx, s.now(), scheduleClaimLease, scheduleBatchSize)
+		if err != nil {
+			return err
+		}
+
+		var errs []error
+		for _, scheduled := range due {
+			if err := s.publish(ctx, scheduled); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 || len(due) < scheduleBatchSize {
+			return errors.Join(errs...)
+		}
+	}
+}
+
+// publish turns a claimed scheduled tweet into a tweet. On failure the claim
+// is released so that the next run retries it, unless moderation rejected or
+// held the tweet: retrying would not change the outcome. When the author's
+// account is not active, the tweet stays scheduled and the claim is left to
+// expire, so it is retried once the lease is over instead of being claimed
+// again right away. If completing fails after the tweet went out, the claim
+// is left to expire too and the retry finds the tweet already published.
+func (s *scheduleService) publish(ctx context.Context, scheduled domain.ScheduledTweet) error {
+	tweet, err := s.tweetSvc.PublishScheduledTweet(ctx, &scheduled)
+	if errors.Is(err, domain.ErrTweetAlreadyPublished) {
+		s.logger.Info("Scheduled tweet was already published", "scheduledTweetID", scheduled.ID)
+		return s.scheduledRepo.CompleteScheduledTweet(ctx, scheduled.ID)
+	}
+	if errors.Is(err, domain.ErrContentRejected) || errors.Is(err, domain.ErrTweetHeldForReview) {
+		s.logger.Info("Scheduled tweet stopped by moderation", "scheduledTweetID", scheduled.ID, "reason", err)
+		return s.scheduledRepo.CompleteScheduledTweet(ctx, scheduled.ID)
+	}
+	if errors.Is(err, domain.ErrAccountSuspended) || errors.Is(err, domain.ErrAccountDeactivated) {
+		s.logger.Info("Scheduled tweet waits for its author's account", "scheduledTweetID", scheduled.ID, "reason", err)
+		return nil
+	}
+	if err != nil {
+		if releaseErr := s.scheduledRepo.ReleaseScheduledTweet(ctx, scheduled.ID); releaseErr != nil {
+			s.logger.Error("Failed to release scheduled tweet", "error", releaseErr, "scheduledTweetID", scheduled.ID)
+		}
+		return err
+	}
+
+	s.logger.Info("Published scheduled tweet", "scheduledTweetID", scheduled.ID, "tweetID", tweet.ID)
+	return s.scheduledRepo.CompleteScheduledTweet(ctx, scheduled.ID)
+}
+
+// ownedScheduledTweet loads a scheduled tweet of the user; other users'
+// scheduled tweets are reported as not found.
+func (s *scheduleService) ownedScheduledTweet(ctx context.Context, userID, tweetID string) (*domain.ScheduledTweet, error) {
+	scheduled, err := s.scheduledRepo.GetScheduledTweet(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if scheduled.UserID != userID {
+		return nil, domain.ErrScheduledTweetNotFound
+	}
+	return scheduled, nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	discardLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	newService := func(mockRepo *mocks.Repository) ports.ScheduleService {
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, clock)
+		return NewScheduleService(mockRepo, tweetService, discardLogger, clock)
+	}
+
+	t.Run("Failure: should not schedule a tweet in the past", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		scheduleService := newService(mockRepo)
+
+		_, err := scheduleService.ScheduleTweet(ctx, "ana", "Hola", now)
+
+		assert.Equal(t, domain.ErrPublishAtInPast, err)
+		mockRepo.AssertNotCalled(t, "AddScheduledTweet", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should publish late due tweets through the tweet service as of now", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		scheduleService := newService(mockRepo)
+
+		due := []domain.ScheduledTweet{{ID: "scheduled-1", UserID: "ana", Text: "Lanzamiento", PublishAt: now.Add(-time.Hour)}}
+		mockRepo.On("ClaimDueTweets", ctx, now, scheduleClaimLease, scheduleBatchSize).Return(due, nil)
+		mockRepo.On("GetTweet", ctx, "scheduled-1").Return(nil, domain.ErrTweetNotFound)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("PublishTx", ctx, mock.MatchedBy(func(tweet *domain.Tweet) bool {
+			return tweet.ID == "scheduled-1" && tweet.CreatedAt.Equal(now) &&
+				tweet.UserID == "ana" && tweet.Text == "Lanzamiento"
+		})).Return(nil)
+		mockRepo.On("CompleteScheduledTweet", ctx, "scheduled-1").Return(nil)
+
+		err := scheduleService.PublishDueTweets(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should complete a retried tweet without publishing it again", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		scheduleService := newService(mockRepo)
+
+		due := []domain.ScheduledTweet{{ID: "scheduled-1", UserID: "ana", Text: "Lanzamiento", PublishAt: now.Add(-10 * time.Minute)}}
+		published := &domain.Tweet{ID: "scheduled-1", UserID: "ana", Text: "Lanzamiento", CreatedAt: due[0].PublishAt}
+		mockRepo.On("ClaimDueTweets", ctx, now, scheduleClaimLease, scheduleBatchSize).Return(due, nil)
+		mockRepo.On("GetTweet", ctx, "scheduled-1").Return(published, nil)
+		mockRepo.On("CompleteScheduledTweet", ctx, "scheduled-1").Return(nil)
+
+		err := scheduleService.PublishDueTweets(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "PublishTx", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should complete a tweet another replica published first", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		scheduleService := newService(mockRepo)
+
+		due := []domain.ScheduledTweet{{ID: "scheduled-1", UserID: "ana", Text: "Lanzamiento", PublishAt: now}}
+		mockRepo.On("ClaimDueTweets", ctx, now, scheduleClaimLease, scheduleBatchSize).Return(due, nil)
+		mockRepo.On("GetTweet", ctx, "scheduled-1").Return(nil, domain.ErrTweetNotFound)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("PublishTx", ctx, mock.AnythingOfType("*domain.Tweet")).Return(domain.ErrTweetAlreadyPublished)
+		mockRepo.On("CompleteScheduledTweet", ctx, "scheduled-1").Return(nil)
+
+		err := scheduleService.PublishDueTweets(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "ReleaseScheduledTweet", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should keep the tweet scheduled while its author is suspended", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		scheduleService := newService(mockRepo)
+
+		due := []domain.ScheduledTweet{{ID: "scheduled-1", UserID: "ana", Text: "Lanzamiento", PublishAt: now}}
+		mockRepo.On("ClaimDueTweets", ctx, now, scheduleClaimLease, scheduleBatchSize).Return(due, nil)
+		mockRepo.On("GetTweet", ctx, "scheduled-1").Return(nil, domain.ErrTweetNotFound)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana", Status: domain.UserSuspended}}, nil)
+
+		err := scheduleService.PublishDueTweets(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "PublishTx", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CompleteScheduledTweet", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "ReleaseScheduledTweet", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should release the claim when publishing fails", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		scheduleService := newService(mockRepo)
+
+		dbErr := errors.New("db error")
+		due := []domain.ScheduledTweet{{ID: "scheduled-1", UserID: "ana", Text: "Lanzamiento", PublishAt: now}}
+		mockRepo.On("ClaimDueTweets", ctx, now, scheduleClaimLease, scheduleBatchSize).Return(due, nil)
+		mockRepo.On("GetTweet", ctx, "scheduled-1").Return(nil, domain.ErrTweetNotFound)
+		mockRepo.On("GetUsers", ctx, []string{"ana"}).Return([]domain.User{{ID: "ana"}}, nil)
+		mockRepo.On("PublishTx", ctx, mock.AnythingOfType("*domain.Tweet")).Return(dbErr)
+		mockRepo.On("ReleaseScheduledTweet", ctx, "scheduled-1").Return(nil)
+
+		err := scheduleService.PublishDueTweets(ctx)
+
+		assert.ErrorIs(t, err, dbErr)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "CompleteScheduledTweet", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should hide other users' scheduled tweets", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		scheduleService := newService(mockRepo)
+
+		scheduled := &domain.ScheduledTweet{ID: "scheduled-1", UserID: "ana", Text: "Lanzamiento", PublishAt: now.Add(time.Hour)}
+		mockRepo.On("GetScheduledTweet", ctx, "scheduled-1").Return(scheduled, nil)
+
+		_, err := scheduleService.UpdateScheduledTweet(ctx, "beto", "scheduled-1", "Otro texto", now.Add(2*time.Hour))
+		require.Equal(t, domain.ErrScheduledTweetNotFound, err)
+
+		err = scheduleService.CancelScheduledTweet(ctx, "beto", "scheduled-1")
+		assert.Equal(t, domain.ErrScheduledTweetNotFound, err)
+		mockRepo.AssertNotCalled(t, "DeleteScheduledTweet", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const searchPageSize = 20
+
+type searchService struct {
+	searchRepo ports.SearchRepository
+	visibility tweetVisibility
+}
+
+func NewSearchService(searchRepo ports.SearchRepository, userRepo ports.UserRepository) ports.SearchService {
+	return &searchService{searchRepo: searchRepo, visibility: tweetVisibility{userRepo: userRepo}}
+}
+
+// Search pages through the raw results and hides protected tweets afterwards,
+// so pages may come back short but cursors never skip a visible tweet.
+func (s *searchService) Search(ctx context.Context, userID, rawQuery, cursor string) (domain.TweetPage, error) {
+	query, err := domain.ParseSearchQuery(rawQuery)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+	if query.Cursor, err = domain.DecodeCursor(cursor); err != nil {
+		return domain.TweetPage{}, err
+	}
+
+	tweets, err := s.searchRepo.Search(ctx, query, searchPageSize+1)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+	page := domain.NewTweetPage(tweets, searchPageSize)
+	if page.Tweets, err = s.visibility.filter(ctx, userID, page.Tweets); err != nil {
+		return domain.TweetPage{}, err
+	}
+	return page, nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success: should return a cursor when there are more results", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		searchService := NewSearchService(mockRepo, mockRepo)
+
+		now := time.Now()
+		tweets := make([]domain.Tweet, searchPageSize+1)
+		for i := range tweets {
+			tweets[i] = domain.Tweet{ID: string(rune('a' + i)), CreatedAt: now.Add(-time.Duration(i) * time.Minute)}
+		}
+		mockRepo.On("Search", ctx, mock.AnythingOfType("domain.SearchQuery"), searchPageSize+1).Return(tweets, nil)
+		mockRepo.On("GetUsers", ctx, mock.Anything).Return([]domain.User{}, nil)
+
+		page, err := searchService.Search(ctx, "user-1", "golang", "")
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Tweets, searchPageSize)
+		cursor, err := domain.DecodeCursor(page.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, tweets[searchPageSize-1].ID, cursor.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should reject an invalid cursor without querying", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		searchService := NewSearchService(mockRepo, mockRepo)
+
+		_, err := searchService.Search(ctx, "user-1", "golang", "%%%")
+
+		assert.Equal(t, domain.ErrInvalidCursor, err)
+		mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
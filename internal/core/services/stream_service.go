@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+type streamService struct {
+	broker           ports.EventBroker
+	relationshipRepo ports.RelationshipRepository
+	filterRepo       ports.FilterRepository
+	visibility       tweetVisibility
+	bufferSize       int
+	now              func() time.Time
+}
+
+func NewStreamService(broker ports.EventBroker, relationshipRepo ports.RelationshipRepository, filterRepo ports.FilterRepository, userRepo ports.UserRepository, bufferSize int, now func() time.Time) ports.StreamService {
+	return &streamService{
+		broker:           broker,
+		relationshipRepo: relationshipRepo,
+		filterRepo:       filterRepo,
+		visibility:       tweetVisibility{userRepo: userRepo},
+		bufferSize:       bufferSize,
+		now:              now,
+	}
+}
+
+// SubscribeTimeline streams the events of the user's timeline, replayed ones
+// included, dropping the tweets GetUserTimeline would hide: those of muted
+// authors, of deactivated or suspended accounts and those matched by the
+// user's filters. Changes to mutes, filters and accounts reach the open
+// streams within timelineFilterTTL.
+func (s *streamService) SubscribeTimeline(ctx context.Context, userID, lastEventID string) (<-chan domain.Event, error) {
+	events, err := s.broker.Subscribe(ctx, domain.TimelineTopic(userID), lastEventID, s.bufferSize)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &timelineFilter{service: s, userID: userID}
+	return s.relay(ctx, events, func(tweet domain.Tweet) bool {
+		return filter.keep(ctx, tweet)
+	}), nil
+}
+
+// timelineFilterTTL is how long a timeline stream reuses the user's filters
+// and what it found out about each author, so that a tweet fanned out to many
+// open streams does not cost a query per stream every time.
+const timelineFilterTTL = time.Minute
+
+// timelineFilter belongs to a single stream and is only used by its relay
+// goroutine. hiddenAuthors caches, per author, whether the author is muted
+// or inactive; failed reads are not cached, and hide the tweet.
+type timelineFilter struct {
+	service       *streamService
+	userID        string
+	loadedAt      time.Time
+	filters       []domain.ContentFilter
+	hiddenAuthors map[string]bool
+}
+
+func (f *timelineFilter) keep(ctx context.Context, tweet domain.Tweet) bool {
+	now := f.service.now()
+	if f.hiddenAuthors == nil || now.Sub(f.loadedAt) >= timelineFilterTTL {
+		filters, err := f.service.filterRepo.GetFilters(ctx, f.userID)
+		if err != nil {
+			return false
+		}
+		f.filters, f.hiddenAuthors, f.loadedAt = filters, make(map[string]bool), now
+	}
+
+	hidden, ok := f.hiddenAuthors[tweet.UserID]
+	if !ok {
+		muted, err := f.service.relationshipRepo.GetMutedUsers(ctx, f.userID, []string{tweet.UserID})
+		if err != nil {
+			return false
+		}
+		hidden = len(muted) > 0
+		if !hidden {
+			active, err := f.service.visibility.active(ctx, f.userID, []domain.Tweet{tweet})
+			if err != nil {
+				return false
+			}
+			hidden = len(active) == 0
+		}
+		f.hiddenAuthors[tweet.UserID] = hidden
+	}
+	return !hidden && len(domain.FilterTweets([]domain.Tweet{tweet}, f.filters, now)) > 0
+}
+
+func (s *streamService) SubscribeNotifications(ctx context.Context, userID, lastEventID string) (<-chan domain.Event, error) {
+	return s.broker.Subscribe(ctx, domain.NotificationsTopic(userID), lastEventID, s.bufferSize)
+}
+
+// SubscribeThread streams the events of a thread, dropping the tweets the
+// user is not allowed to see. Notification topics need no such check: only
+// visible recipients get events there.
+func (s *streamService) SubscribeThread(ctx context.Context, userID, tweetID, lastEventID string) (<-chan domain.Event, error) {
+	events, err := s.broker.Subscribe(ctx, domain.ThreadTopic(tweetID), lastEventID, s.bufferSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.relay(ctx, events, func(tweet domain.Tweet) bool {
+		tweets, err := s.visibility.filter(ctx, userID, []domain.Tweet{tweet})
+		return err == nil && len(tweets) > 0
+	}), nil
+}
+
+// relay forwards the events that carry no tweet or whose tweet keep accepts.
+func (s *streamService) relay(ctx context.Context, events <-chan domain.Event, keep func(domain.Tweet) bool) <-chan domain.Event {
+	kept := make(chan domain.Event, s.bufferSize)
+	go func() {
+		defer close(kept)
+		for event := range events {
+			if event.Tweet != nil && !keep(*event.Tweet) {
+				continue
+			}
+			select {
+			case kept <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return kept
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamService_SubscribeTimeline(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	t.Run("Success: should drop the replayed tweets of muted authors and those matched by filters", func(t *testing.T) {
+		// Setup
+		mockBroker := new(mocks.EventBroker)
+		mockRepo := new(mocks.Repository)
+		streamService := NewStreamService(mockBroker, mockRepo, mockRepo, mockRepo, 8, clock)
+
+		shown := domain.Tweet{ID: "t1", UserID: "author-1", Text: "hello"}
+		muted := domain.Tweet{ID: "t2", UserID: "author-2", Text: "hello"}
+		filtered := domain.Tweet{ID: "t3", UserID: "author-1", Text: "spoilers ahead"}
+		replay := make(chan domain.Event, 3)
+		for _, tweet := range []domain.Tweet{shown, muted, filtered} {
+			replay <- domain.Event{ID: tweet.ID, Type: domain.EventTweet, Tweet: &tweet}
+		}
+		close(replay)
+
+		// Mocking
+		mockBroker.On("Subscribe", ctx, domain.TimelineTopic("user-1"), "0", 8).Return((<-chan domain.Event)(replay), nil)
+		mockRepo.On("GetMutedUsers", ctx, "user-1", []string{"author-1"}).Return(nil, nil)
+		mockRepo.On("GetMutedUsers", ctx, "user-1", []string{"author-2"}).Return([]string{"author-2"}, nil)
+		mockRepo.On("GetFilters", ctx, "user-1").Return([]domain.ContentFilter{{UserID: "user-1", Kind: domain.FilterWord, Value: "spoilers"}}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"author-1"}).Return([]domain.User{{ID: "author-1", Status: domain.UserActive}}, nil)
+
+		// Execute
+		events, err := streamService.SubscribeTimeline(ctx, "user-1", "0")
+
+		// Assert
+		require.NoError(t, err)
+		var ids []string
+		for event := range events {
+			ids = append(ids, event.Tweet.ID)
+		}
+		assert.Equal(t, []string{"t1"}, ids)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should read the filters and authors once per refresh interval", func(t *testing.T) {
+		mockBroker := new(mocks.EventBroker)
+		mockRepo := new(mocks.Repository)
+		// The relay asks for the time once per event.
+		times := []time.Time{now, now.Add(time.Second), now.Add(timelineFilterTTL)}
+		streamService := NewStreamService(mockBroker, mockRepo, mockRepo, mockRepo, 8, func() time.Time {
+			next := times[0]
+			times = times[1:]
+			return next
+		})
+
+		replay := make(chan domain.Event, 3)
+		for _, id := range []string{"t1", "t2", "t3"} {
+			replay <- domain.Event{ID: id, Type: domain.EventTweet, Tweet: &domain.Tweet{ID: id, UserID: "author-1", Text: "hello"}}
+		}
+		close(replay)
+
+		mockBroker.On("Subscribe", ctx, domain.TimelineTopic("user-1"), "0", 8).Return((<-chan domain.Event)(replay), nil)
+		mockRepo.On("GetFilters", ctx, "user-1").Return(nil, nil).Twice()
+		mockRepo.On("GetMutedUsers", ctx, "user-1", []string{"author-1"}).Return(nil, nil).Twice()
+		mockRepo.On("GetUsers", ctx, []string{"author-1"}).Return([]domain.User{{ID: "author-1", Status: domain.UserActive}}, nil).Twice()
+
+		events, err := streamService.SubscribeTimeline(ctx, "user-1", "0")
+
+		require.NoError(t, err)
+		var ids []string
+		for event := range events {
+			ids = append(ids, event.Tweet.ID)
+		}
+		assert.Equal(t, []string{"t1", "t2", "t3"}, ids)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should return the error when the subscription fails", func(t *testing.T) {
+		mockBroker := new(mocks.EventBroker)
+		mockRepo := new(mocks.Repository)
+		streamService := NewStreamService(mockBroker, mockRepo, mockRepo, mockRepo, 8, clock)
+
+		mockBroker.On("Subscribe", ctx, domain.TimelineTopic("user-1"), "", 8).Return(nil, assert.AnError)
+
+		_, err := streamService.SubscribeTimeline(ctx, "user-1", "")
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+const (
+	maxSuggestions          = 20
+	maxSuggestionCandidates = 100
+	suggestionRefreshBatch  = 500
+)
+
+type suggestionService struct {
+	suggestionRepo   ports.SuggestionRepository
+	cache            ports.SuggestionCache
+	userRepo         ports.UserRepository
+	relationshipRepo ports.RelationshipRepository
+	refreshLease     time.Duration
+	now              func() time.Time
+}
+
+func NewSuggestionService(
+	suggestionRepo ports.SuggestionRepository,
+	cache ports.SuggestionCache,
+	userRepo ports.UserRepository,
+	relationshipRepo ports.RelationshipRepository,
+	refreshLease time.Duration,
+	now func() time.Time,
+) ports.SuggestionService {
+	return &suggestionService{
+		suggestionRepo:   suggestionRepo,
+		cache:            cache,
+		userRepo:         userRepo,
+		relationshipRepo: relationshipRepo,
+		refreshLease:     refreshLease,
+		now:              now,
+	}
+}
+
+// GetSuggestions serves the suggestions precomputed by RefreshSuggestions,
+// computing them on the spot for users the job has not reached yet. Accounts
+// followed or blocked since the last refresh are dropped on read.
+func (s *suggestionService) GetSuggestions(ctx context.Context, userID string) ([]domain.Suggestion, error) {
+	suggestions, ok, err := s.cache.GetSuggestions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if suggestions, err = s.refreshUser(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	if len(suggestions) == 0 {
+		return []domain.Suggestion{}, nil
+	}
+
+	candidateIDs := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		candidateIDs[i] = suggestion.UserID
+	}
+	followed, err := s.userRepo.GetFollowedUsers(ctx, userID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := s.relationshipRepo.GetBlockedUsers(ctx, userID, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(suggestions, func(suggestion domain.Suggestion) bool {
+		return slices.Contains(followed, suggestion.UserID) || slices.Contains(blocked, suggestion.UserID)
+	}), nil
+}
+
+// RefreshSuggestions recomputes the suggestions of every user. It is meant
+// to be run periodically by a background job; a failure for one user is
+// reported at the end without stopping the others. Only the replica that
+// gets the refresh lease runs it, and the lease lasts refreshLease, so the
+// users are refreshed once per period however many replicas run the job.
+func (s *suggestionService) RefreshSuggestions(ctx context.Context) error {
+	acquired, err := s.cache.AcquireRefreshLease(ctx, s.refreshLease)
+	if err != nil || !acquired {
+		return err
+	}
+
+	var errs []error
+	afterID := ""
+	for {
+		userIDs, err := s.suggestionRepo.GetUserIDs(ctx, afterID, suggestionRefreshBatch)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		for _, userID := range userIDs {
+			if ctx.Err() != nil {
+				return errors.Join(append(errs, ctx.Err())...)
+			}
+			if _, err := s.refreshUser(ctx, userID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if len(userIDs) < suggestionRefreshBatch {
+			return errors.Join(errs...)
+		}
+		afterID = userIDs[len(userIDs)-1]
+	}
+}
+
+func (s *suggestionService) refreshUser(ctx context.Context, userID string) ([]domain.Suggestion, error) {
+	candidates, err := s.suggestionRepo.GetSuggestionCandidates(ctx, userID, maxSuggestionCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	domain.RankSuggestions(candidates, s.now())
+	suggestions := candidates[:min(maxSuggestions, len(candidates))]
+
+	if err := s.cache.SaveSuggestions(ctx, userID, suggestions); err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
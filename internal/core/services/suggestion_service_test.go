@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestionService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	t.Run("Success: should drop accounts followed or blocked since the last refresh", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		suggestionService := NewSuggestionService(mockRepo, mockRepo, mockRepo, mockRepo, time.Hour, clock)
+
+		cached := []domain.Suggestion{{UserID: "beto"}, {UserID: "carla"}, {UserID: "dario"}}
+		mockRepo.On("GetSuggestions", ctx, "ana").Return(cached, true, nil)
+		mockRepo.On("GetFollowedUsers", ctx, "ana", []string{"beto", "carla", "dario"}).Return([]string{"beto"}, nil)
+		mockRepo.On("GetBlockedUsers", ctx, "ana", []string{"beto", "carla", "dario"}).Return([]string{"dario"}, nil)
+
+		suggestions, err := suggestionService.GetSuggestions(ctx, "ana")
+
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, "carla", suggestions[0].UserID)
+		mockRepo.AssertNotCalled(t, "GetSuggestionCandidates", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should compute and store suggestions on a cache miss", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		suggestionService := NewSuggestionService(mockRepo, mockRepo, mockRepo, mockRepo, time.Hour, clock)
+
+		recent := now.Add(-time.Hour)
+		candidates := []domain.Suggestion{{UserID: "beto", MutualCount: 4}, {UserID: "carla", MutualCount: 1, LastTweetAt: &recent}}
+		mockRepo.On("GetSuggestions", ctx, "ana").Return(nil, false, nil)
+		mockRepo.On("GetSuggestionCandidates", ctx, "ana", maxSuggestionCandidates).Return(candidates, nil)
+		mockRepo.On("SaveSuggestions", ctx, "ana", mock.MatchedBy(func(s []domain.Suggestion) bool {
+			return len(s) == 2 && s[0].UserID == "carla"
+		})).Return(nil)
+		mockRepo.On("GetFollowedUsers", ctx, "ana", []string{"carla", "beto"}).Return([]string{}, nil)
+		mockRepo.On("GetBlockedUsers", ctx, "ana", []string{"carla", "beto"}).Return([]string{}, nil)
+
+		suggestions, err := suggestionService.GetSuggestions(ctx, "ana")
+
+		require.NoError(t, err)
+		assert.Len(t, suggestions, 2)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should refresh the remaining users when one of them fails", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		suggestionService := NewSuggestionService(mockRepo, mockRepo, mockRepo, mockRepo, time.Hour, clock)
+
+		dbErr := errors.New("db error")
+		mockRepo.On("AcquireRefreshLease", ctx, time.Hour).Return(true, nil)
+		mockRepo.On("GetUserIDs", ctx, "", suggestionRefreshBatch).Return([]string{"ana", "beto"}, nil)
+		mockRepo.On("GetSuggestionCandidates", ctx, "ana", maxSuggestionCandidates).Return(nil, dbErr)
+		mockRepo.On("GetSuggestionCandidates", ctx, "beto", maxSuggestionCandidates).Return([]domain.Suggestion{}, nil)
+		mockRepo.On("SaveSuggestions", ctx, "beto", []domain.Suggestion{}).Return(nil)
+
+		err := suggestionService.RefreshSuggestions(ctx)
+
+		assert.ErrorIs(t, err, dbErr)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should skip the refresh while another replica holds the lease", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		suggestionService := NewSuggestionService(mockRepo, mockRepo, mockRepo, mockRepo, time.Hour, clock)
+
+		mockRepo.On("AcquireRefreshLease", ctx, time.Hour).Return(false, nil)
+
+		err := suggestionService.RefreshSuggestions(ctx)
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "GetUserIDs", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
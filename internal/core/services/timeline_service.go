@@ -2,19 +2,74 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/EstefiS/uala-challenge/internal/core/ports"
 )
 
+const (
+	timelinePageSize = 50
+	// maxTimelineScans bounds how many batches are read to fill a page when
+	// the user's filters hide most of the timeline.
+	maxTimelineScans = 5
+)
+
 type timelineService struct {
 	timelineRepo ports.TimelineRepository
+	filterRepo   ports.FilterRepository
+	visibility   tweetVisibility
+	now          func() time.Time
 }
 
-func NewTimelineService(timelineRepo ports.TimelineRepository) ports.TimelineService {
-	return &timelineService{timelineRepo: timelineRepo}
+func NewTimelineService(timelineRepo ports.TimelineRepository, filterRepo ports.FilterRepository, userRepo ports.UserRepository, now func() time.Time) ports.TimelineService {
+	return &timelineService{
+		timelineRepo: timelineRepo,
+		filterRepo:   filterRepo,
+		visibility:   tweetVisibility{userRepo: userRepo},
+		now:          now,
+	}
 }
 
-func (s *timelineService) GetUserTimeline(ctx context.Context, userID string) ([]domain.Tweet, error) {
-	return s.timelineRepo.Get(ctx, userID, 50)
+// GetUserTimeline reads the timeline in batches, dropping the tweets matched
+// by the user's filters and those of deactivated or suspended accounts,
+// until the page is full or the timeline runs out. Both are applied here
+// rather than in the repositories so that cached timelines stay independent
+// of them.
+func (s *timelineService) GetUserTimeline(ctx context.Context, userID, cursor string) (domain.TweetPage, error) {
+	scanCursor, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+
+	filters, err := s.filterRepo.GetFilters(ctx, userID)
+	if err != nil {
+		return domain.TweetPage{}, err
+	}
+	now := s.now()
+
+	var visible []domain.Tweet
+	for range maxTimelineScans {
+		batch, err := s.timelineRepo.Get(ctx, userID, scanCursor, timelinePageSize+1)
+		if err != nil {
+			return domain.TweetPage{}, err
+		}
+
+		active, err := s.visibility.active(ctx, userID, batch)
+		if err != nil {
+			return domain.TweetPage{}, err
+		}
+		visible = append(visible, domain.FilterTweets(active, filters, now)...)
+		if len(visible) > timelinePageSize {
+			return domain.NewTweetPage(visible, timelinePageSize), nil
+		}
+		if len(batch) <= timelinePageSize {
+			return domain.TweetPage{Tweets: visible}, nil
+		}
+		scanCursor = domain.CursorAfter(batch[len(batch)-1])
+	}
+
+	// Out of scan budget: hand back what was found and let the client resume
+	// after the last tweet read.
+	return domain.TweetPage{Tweets: visible, NextCursor: scanCursor.Encode()}, nil
 }
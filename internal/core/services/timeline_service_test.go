@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimelineService_GetUserTimeline(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	timeline := func(n int, textOf func(i int) string) []domain.Tweet {
+		tweets := make([]domain.Tweet, n)
+		for i := range tweets {
+			tweets[i] = domain.Tweet{ID: fmt.Sprintf("t%03d", n-i), UserID: "user-2", Text: textOf(i), CreatedAt: now.Add(-time.Duration(i) * time.Minute)}
+		}
+		return tweets
+	}
+
+	author := []domain.User{{ID: "user-2", Status: domain.UserActive}}
+
+	t.Run("Success: should refill the page when filters hide tweets", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		timelineService := NewTimelineService(mockRepo, mockRepo, mockRepo, clock)
+
+		// Every other tweet of the first batch is a spoiler.
+		tweets := timeline(2*timelinePageSize+2, func(i int) string {
+			if i%2 == 0 && i <= timelinePageSize {
+				return "spoiler alert"
+			}
+			return "hola"
+		})
+		filter, err := domain.NewContentFilter("user-1", "spoiler", nil, now)
+		require.NoError(t, err)
+
+		mockRepo.On("GetFilters", ctx, "user-1").Return([]domain.ContentFilter{*filter}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-2"}).Return(author, nil)
+		mockRepo.On("Get", ctx, "user-1", (*domain.Cursor)(nil), timelinePageSize+1).Return(tweets[:timelinePageSize+1], nil)
+		mockRepo.On("Get", ctx, "user-1", domain.CursorAfter(tweets[timelinePageSize]), timelinePageSize+1).Return(tweets[timelinePageSize+1:2*timelinePageSize+2], nil)
+
+		page, err := timelineService.GetUserTimeline(ctx, "user-1", "")
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Tweets, timelinePageSize)
+		for _, tweet := range page.Tweets {
+			assert.Equal(t, "hola", tweet.Text)
+		}
+		cursor, err := domain.DecodeCursor(page.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, page.Tweets[timelinePageSize-1].ID, cursor.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success: should return a short page without cursor at the end of the timeline", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		timelineService := NewTimelineService(mockRepo, mockRepo, mockRepo, clock)
+
+		tweets := timeline(3, func(int) string { return "hola" })
+		mockRepo.On("GetFilters", ctx, "user-1").Return([]domain.ContentFilter{}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-2"}).Return(author, nil)
+		mockRepo.On("Get", ctx, "user-1", (*domain.Cursor)(nil), timelinePageSize+1).Return(tweets, nil)
+
+		page, err := timelineService.GetUserTimeline(ctx, "user-1", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, tweets, page.Tweets)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Success: should stop scanning after a bounded number of batches", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		timelineService := NewTimelineService(mockRepo, mockRepo, mockRepo, clock)
+
+		filter, err := domain.NewContentFilter("user-1", "spoiler", nil, now)
+		require.NoError(t, err)
+		hidden := timeline(timelinePageSize+1, func(int) string { return "spoiler" })
+
+		mockRepo.On("GetFilters", ctx, "user-1").Return([]domain.ContentFilter{*filter}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-2"}).Return(author, nil)
+		mockRepo.On("Get", ctx, "user-1", mock.Anything, timelinePageSize+1).Return(hidden, nil)
+
+		page, err := timelineService.GetUserTimeline(ctx, "user-1", "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, page.Tweets)
+		assert.NotEmpty(t, page.NextCursor)
+		mockRepo.AssertNumberOfCalls(t, "Get", maxTimelineScans)
+	})
+
+	t.Run("Success: should hide the tweets of inactive accounts without touching the timeline", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		timelineService := NewTimelineService(mockRepo, mockRepo, mockRepo, clock)
+
+		tweets := []domain.Tweet{
+			{ID: "t3", UserID: "user-2", CreatedAt: now},
+			{ID: "t2", UserID: "user-3", CreatedAt: now.Add(-time.Minute)},
+			{ID: "t1", UserID: "user-1", CreatedAt: now.Add(-2 * time.Minute)},
+		}
+
+		// Mocking
+		mockRepo.On("GetFilters", ctx, "user-1").Return([]domain.ContentFilter{}, nil)
+		mockRepo.On("Get", ctx, "user-1", (*domain.Cursor)(nil), timelinePageSize+1).Return(tweets, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-2", "user-3"}).Return([]domain.User{
+			{ID: "user-2", Status: domain.UserSuspended},
+			{ID: "user-3", Status: domain.UserActive},
+		}, nil)
+
+		// Execute
+		page, err := timelineService.GetUserTimeline(ctx, "user-1", "")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Tweet{tweets[1], tweets[2]}, page.Tweets)
+	})
+
+	t.Run("Failure: should reject an invalid cursor", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		timelineService := NewTimelineService(mockRepo, mockRepo, mockRepo, clock)
+
+		_, err := timelineService.GetUserTimeline(ctx, "user-1", "%%%")
+
+		assert.Equal(t, domain.ErrInvalidCursor, err)
+		mockRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -2,23 +2,331 @@ package services
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
 	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/EstefiS/uala-challenge/internal/core/ports"
 )
 
 type tweetService struct {
-	tweetRepo ports.TweetRepository
+	tweetRepo  ports.TweetRepository
+	userRepo   ports.UserRepository
+	mediaRepo  ports.MediaRepository
+	heldRepo   ports.HeldTweetRepository
+	moderation ports.ModerationPolicy
+	notifier   ports.Notifier
+	visibility tweetVisibility
+	editWindow time.Duration
+	now        func() time.Time
 }
 
-func NewTweetService(tweetRepo ports.TweetRepository) ports.TweetService {
-	return &tweetService{tweetRepo: tweetRepo}
+// NewTweetService creates the TweetService. New tweets and edits go through
+// the moderation policy; tweets it holds are kept in heldRepo until a
+// moderator decides on them. Tweets can be edited by their author for
+// editWindow after being published.
+func NewTweetService(
+	tweetRepo ports.TweetRepository,
+	userRepo ports.UserRepository,
+	mediaRepo ports.MediaRepository,
+	heldRepo ports.HeldTweetRepository,
+	moderation ports.ModerationPolicy,
+	notifier ports.Notifier,
+	editWindow time.Duration,
+	now func() time.Time,
+) ports.TweetService {
+	return &tweetService{
+		tweetRepo:  tweetRepo,
+		userRepo:   userRepo,
+		mediaRepo:  mediaRepo,
+		heldRepo:   heldRepo,
+		moderation: moderation,
+		notifier:   notifier,
+		visibility: tweetVisibility{userRepo: userRepo},
+		editWindow: editWindow,
+		now:        now,
+	}
+}
+
+// PublishTweet validates the content before moderating it, so held tweets
+// only wait for the moderator's decision. Only active users can publish.
+func (s *tweetService) PublishTweet(ctx context.Context, userID string, content domain.TweetContent) (*domain.Tweet, error) {
+	tweet, err := s.newTweet(ctx, userID, content)
+	if err != nil {
+		return nil, err
+	}
+	return s.moderateAndPublish(ctx, tweet, content, s.tweetRepo.PublishTx)
+}
+
+// PublishScheduledTweet reuses the scheduled tweet's ID, which is the same on
+// every attempt: a retry after the tweet went out finds it, or loses the race
+// in PublishTx, instead of publishing it again. The tweet is dated when it is
+// actually published, so one that went out late, after an outage or while
+// its author was inactive, still reaches the top of the timelines.
+func (s *tweetService) PublishScheduledTweet(ctx context.Context, scheduled *domain.ScheduledTweet) (*domain.Tweet, error) {
+	published, err := s.tweetRepo.GetTweet(ctx, scheduled.ID)
+	if err == nil {
+		return published, domain.ErrTweetAlreadyPublished
+	}
+	if !errors.Is(err, domain.ErrTweetNotFound) {
+		return nil, err
+	}
+
+	content := domain.TweetContent{Text: scheduled.Text}
+	tweet, err := s.newTweet(ctx, scheduled.UserID, content)
+	if err != nil {
+		return nil, err
+	}
+	tweet.ID = scheduled.ID
+	tweet.CreatedAt = s.now()
+	return s.moderateAndPublish(ctx, tweet, content, s.tweetRepo.PublishTx)
+}
+
+// PublishDraft moderates the draft like any new tweet. The draft is only
+// deleted together with the tweet insert, so a failed publish leaves it in
+// place and two concurrent publishes of the same draft yield a single tweet.
+func (s *tweetService) PublishDraft(ctx context.Context, draft *domain.Draft) (*domain.Tweet, error) {
+	content := domain.TweetContent{Text: draft.Text}
+	tweet, err := s.newTweet(ctx, draft.UserID, content)
+	if err != nil {
+		return nil, err
+	}
+	return s.moderateAndPublish(ctx, tweet, content, func(ctx context.Context, tweet *domain.Tweet) error {
+		return s.tweetRepo.PublishDraftTx(ctx, draft, tweet)
+	})
+}
+
+func (s *tweetService) moderateAndPublish(ctx context.Context, tweet *domain.Tweet, content domain.TweetContent, publishTx publishFunc) (*domain.Tweet, error) {
+	userID := tweet.UserID
+	if err := checkActive(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	decision, err := s.moderation.Review(ctx, userID, content)
+	if err != nil {
+		return nil, err
+	}
+	switch decision.Action {
+	case domain.ModerationReject:
+		return nil, &domain.RejectionError{Reason: decision.Reason}
+	case domain.ModerationHold:
+		held := domain.NewHeldTweet(userID, content, decision.Reason, s.now())
+		if err := s.heldRepo.AddHeldTweet(ctx, held); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrTweetHeldForReview
+	}
+
+	return s.publish(ctx, tweet, publishTx)
+}
+
+// PublishHeldTweet publishes an approved tweet without moderating it again.
+// It is published as of now, so it reaches timelines as a new tweet and its
+// poll, if any, runs for its full duration.
+func (s *tweetService) PublishHeldTweet(ctx context.Context, held *domain.HeldTweet) (*domain.Tweet, error) {
+	tweet, err := s.newTweet(ctx, held.UserID, held.Content)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkActive(ctx, s.userRepo, held.UserID); err != nil {
+		return nil, err
+	}
+	return s.publish(ctx, tweet, s.tweetRepo.PublishTx)
+}
+
+func (s *tweetService) newTweet(ctx context.Context, userID string, content domain.TweetContent) (*domain.Tweet, error) {
+	tweet, err := domain.NewTweet(userID, content.Text)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachMedia(ctx, tweet, content.MediaIDs); err != nil {
+		return nil, err
+	}
+	if content.Poll != nil {
+		if tweet.Poll, err = domain.NewPoll(*content.Poll, tweet.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	return tweet, nil
+}
+
+// publishFunc stores a new tweet: TweetRepository.PublishTx or a variant of
+// it that does more in the same transaction.
+type publishFunc func(ctx context.Context, tweet *domain.Tweet) error
+
+func (s *tweetService) publish(ctx context.Context, tweet *domain.Tweet, publishTx publishFunc) (*domain.Tweet, error) {
+	if err := s.resolveMentions(ctx, tweet); err != nil {
+		return nil, err
+	}
+
+	if err := publishTx(ctx, tweet); err != nil {
+		return tweet, err
+	}
+
+	s.notifyMentions(ctx, tweet, nil)
+	return tweet, nil
+}
+
+// DeleteTweet lets authors delete their own tweets. Any other user gets
+// ErrNotTweetAuthor.
+func (s *tweetService) DeleteTweet(ctx context.Context, userID, tweetID string) error {
+	tweet, err := s.tweetRepo.GetTweet(ctx, tweetID)
+	if err != nil {
+		return err
+	}
+	if tweet.UserID != userID {
+		return domain.ErrNotTweetAuthor
+	}
+	return s.tweetRepo.DeleteTx(ctx, tweet)
 }
 
-func (s *tweetService) PublishTweet(ctx context.Context, userID, text string) (*domain.Tweet, error) {
-	tweet, err := domain.NewTweet(userID, text)
+// EditTweet lets authors fix the text of their own tweets within the edit
+// window, up to domain.MaxTweetEdits times. Every previous version is kept
+// and can be read with GetTweetHistory. Users mentioned for the first time
+// by the edit are notified. Edits are moderated like new tweets, except that
+// an edit the policy would hold is rejected: the tweet is already public.
+// Like publishing, editing needs an active account.
+func (s *tweetService) EditTweet(ctx context.Context, userID, tweetID, text string) (*domain.Tweet, error) {
+	tweet, err := s.tweetRepo.GetTweet(ctx, tweetID)
 	if err != nil {
 		return nil, err
 	}
-	return tweet, s.tweetRepo.PublishTx(ctx, tweet)
+	if tweet.UserID != userID {
+		return nil, domain.ErrNotTweetAuthor
+	}
+
+	now := s.now()
+	if now.Sub(tweet.CreatedAt) > s.editWindow {
+		return nil, domain.ErrEditWindowExpired
+	}
+	revisions, err := s.tweetRepo.GetTweetRevisions(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) >= domain.MaxTweetEdits {
+		return nil, domain.ErrTooManyTweetEdits
+	}
+
+	edited, err := tweet.Edit(text, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkActive(ctx, s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	decision, err := s.moderation.Review(ctx, userID, domain.TweetContent{Text: text, MediaIDs: tweet.MediaIDs})
+	if err != nil {
+		return nil, err
+	}
+	if decision.Action == domain.ModerationReject || decision.Action == domain.ModerationHold {
+		return nil, &domain.RejectionError{Reason: decision.Reason}
+	}
+	if err := s.resolveMentions(ctx, edited); err != nil {
+		return nil, err
+	}
+
+	if err := s.tweetRepo.EditTx(ctx, tweet, edited); err != nil {
+		return nil, err
+	}
+
+	s.notifyMentions(ctx, edited, tweet.Mentions)
+	return edited, nil
+}
+
+// GetTweetHistory returns every version of the tweet, oldest first and the
+// current one last. Tweets the viewer is not allowed to see are reported as
+// not found.
+func (s *tweetService) GetTweetHistory(ctx context.Context, viewerID, tweetID string) ([]domain.TweetRevision, error) {
+	tweet, err := s.tweetRepo.GetTweet(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := s.visibility.filter(ctx, viewerID, []domain.Tweet{*tweet})
+	if err != nil {
+		return nil, err
+	}
+	if len(visible) == 0 {
+		return nil, domain.ErrTweetNotFound
+	}
+
+	revisions, err := s.tweetRepo.GetTweetRevisions(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	return append(revisions, tweet.Revision()), nil
+}
+
+// attachMedia attaches the media to the tweet. Only media uploaded by the
+// author can be attached; anyone else's is reported as not found.
+func (s *tweetService) attachMedia(ctx context.Context, tweet *domain.Tweet, mediaIDs []string) error {
+	if len(mediaIDs) == 0 {
+		return nil
+	}
+	if len(mediaIDs) > domain.MaxTweetMedia {
+		return domain.ErrTooManyMedia
+	}
+
+	for _, mediaID := range mediaIDs {
+		if slices.Contains(tweet.MediaIDs, mediaID) {
+			continue
+		}
+		media, err := s.mediaRepo.GetMedia(ctx, mediaID)
+		if err != nil {
+			return err
+		}
+		if media.UserID != tweet.UserID {
+			return domain.ErrMediaNotFound
+		}
+		tweet.MediaIDs = append(tweet.MediaIDs, mediaID)
+	}
+	return nil
+}
+
+// resolveMentions links the tweet's mentions to existing users and drops the
+// rest.
+func (s *tweetService) resolveMentions(ctx context.Context, tweet *domain.Tweet) error {
+	handles := tweet.MentionedHandles()
+	if len(handles) == 0 {
+		return nil
+	}
+	users, err := s.userRepo.GetUsers(ctx, handles)
+	if err != nil {
+		return err
+	}
+	tweet.ResolveMentions(users)
+	return nil
+}
+
+// notifyMentions notifies the mentioned users who are allowed to see the
+// tweet, skipping those already notified through previous mentions; a
+// failure here does not fail the already published tweet.
+func (s *tweetService) notifyMentions(ctx context.Context, tweet *domain.Tweet, previous []domain.Mention) {
+	var mentioned []string
+	notified := map[string]bool{tweet.UserID: true}
+	for _, m := range previous {
+		notified[m.UserID] = true
+	}
+	for _, m := range tweet.Mentions {
+		if !notified[m.UserID] {
+			notified[m.UserID] = true
+			mentioned = append(mentioned, m.UserID)
+		}
+	}
+	if len(mentioned) == 0 {
+		return
+	}
+
+	recipients, err := s.visibility.audience(ctx, tweet.UserID, mentioned)
+	if err != nil {
+		return
+	}
+
+	notifications := make([]domain.Notification, len(recipients))
+	for i, recipientID := range recipients {
+		notifications[i] = domain.NewNotification(recipientID, domain.NotificationMention, tweet.UserID, tweet.ID)
+	}
+	if len(notifications) > 0 {
+		s.notifier.Notify(ctx, notifications...)
+	}
 }
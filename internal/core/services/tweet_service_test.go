@@ -3,27 +3,38 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
 	"github.com/EstefiS/uala-challenge/internal/core/services/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// allowAll is a moderation policy that lets every tweet through.
+type allowAll struct{}
+
+func (allowAll) Review(context.Context, string, domain.TweetContent) (domain.ModerationDecision, error) {
+	return domain.ModerationDecision{Action: domain.ModerationAllow}, nil
+}
+
 func TestTweetService_PublishTweet(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("Success: should publish a valid tweet", func(t *testing.T) {
 		// Setup
 		mockRepo := new(mocks.Repository)
-		tweetService := NewTweetService(mockRepo)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
 
 		userID := "user-1"
 		text := "Hola mundo"
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1"}}, nil)
 		mockRepo.On("PublishTx", ctx, mock.AnythingOfType("*domain.Tweet")).Return(nil)
 
 		// Execute
-		tweet, err := tweetService.PublishTweet(ctx, userID, text)
+		tweet, err := tweetService.PublishTweet(ctx, userID, domain.TweetContent{Text: text})
 
 		// Assert
 		assert.NoError(t, err)
@@ -35,19 +46,351 @@ func TestTweetService_PublishTweet(t *testing.T) {
 	t.Run("Failure: repository returns an error", func(t *testing.T) {
 		// Setup
 		mockRepo := new(mocks.Repository)
-		tweetService := NewTweetService(mockRepo)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
 
 		expectedError := errors.New("database is down")
 
 		// Mocking
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1"}}, nil)
 		mockRepo.On("PublishTx", ctx, mock.AnythingOfType("*domain.Tweet")).Return(expectedError)
 
 		// Execute
-		_, err := tweetService.PublishTweet(ctx, "user-1", "un tweet")
+		_, err := tweetService.PublishTweet(ctx, "user-1", domain.TweetContent{Text: "un tweet"})
 
 		// Assert
 		assert.Error(t, err)
 		assert.Equal(t, expectedError, err)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Success: should keep only mentions of existing users and notify them", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		mockNotifier := new(mocks.Notifier)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, mockNotifier, time.Hour, time.Now)
+
+		// Mocking
+		mockRepo.On("GetUsers", ctx, []string{"user-2", "nadie"}).Return([]domain.User{{ID: "user-2"}}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1"}}, nil)
+		mockRepo.On("PublishTx", ctx, mock.AnythingOfType("*domain.Tweet")).Return(nil)
+		mockNotifier.On("Notify", ctx, mock.MatchedBy(func(ns []domain.Notification) bool {
+			return len(ns) == 1 && ns[0].RecipientID == "user-2" && ns[0].Type == domain.NotificationMention
+		})).Return()
+
+		// Execute
+		tweet, err := tweetService.PublishTweet(ctx, "user-1", domain.TweetContent{Text: "Hola @user-2 y @nadie"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, tweet.Mentions, 1)
+		assert.Equal(t, "user-2", tweet.Mentions[0].UserID)
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("Success: should only notify followers when the author is protected", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		mockNotifier := new(mocks.Notifier)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, mockNotifier, time.Hour, time.Now)
+
+		// Mocking
+		mockRepo.On("GetUsers", ctx, []string{"user-2", "user-3"}).Return([]domain.User{{ID: "user-2"}, {ID: "user-3"}}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1", Protected: true}}, nil)
+		mockRepo.On("GetFollowers", ctx, "user-1").Return([]string{"user-3"}, nil)
+		mockRepo.On("PublishTx", ctx, mock.AnythingOfType("*domain.Tweet")).Return(nil)
+		mockNotifier.On("Notify", ctx, mock.MatchedBy(func(ns []domain.Notification) bool {
+			return len(ns) == 1 && ns[0].RecipientID == "user-3"
+		})).Return()
+
+		// Execute
+		_, err := tweetService.PublishTweet(ctx, "user-1", domain.TweetContent{Text: "Hola @user-2 y @user-3"})
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+}
+
+func TestTweetService_PublishTweetWithMedia(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success: should attach the author's media", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+
+		mockRepo.On("GetMedia", ctx, "media-1").Return(&domain.Media{ID: "media-1", UserID: "user-1"}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1"}}, nil)
+		mockRepo.On("PublishTx", ctx, mock.MatchedBy(func(tweet *domain.Tweet) bool {
+			return len(tweet.MediaIDs) == 1 && tweet.MediaIDs[0] == "media-1"
+		})).Return(nil)
+
+		tweet, err := tweetService.PublishTweet(ctx, "user-1", domain.TweetContent{Text: "Mirá", MediaIDs: []string{"media-1"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"media-1"}, tweet.MediaIDs)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not attach media uploaded by someone else", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+
+		mockRepo.On("GetMedia", ctx, "media-1").Return(&domain.Media{ID: "media-1", UserID: "user-2"}, nil)
+
+		_, err := tweetService.PublishTweet(ctx, "user-1", domain.TweetContent{Text: "Mirá", MediaIDs: []string{"media-1"}})
+
+		assert.Equal(t, domain.ErrMediaNotFound, err)
+		mockRepo.AssertNotCalled(t, "PublishTx", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should reject more than four media", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+
+		_, err := tweetService.PublishTweet(ctx, "user-1", domain.TweetContent{Text: "Mirá", MediaIDs: []string{"a", "b", "c", "d", "e"}})
+
+		assert.Equal(t, domain.ErrTooManyMedia, err)
+	})
+}
+
+func TestTweetService_PublishTweetModeration(t *testing.T) {
+	ctx := context.Background()
+	content := domain.TweetContent{Text: "Compra seguidores baratos"}
+
+	t.Run("Failure: should surface the reason of a rejection", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		mockPolicy := new(mocks.ModerationPolicy)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, mockPolicy, new(mocks.Notifier), time.Hour, time.Now)
+
+		// Mocking
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1"}}, nil)
+		mockPolicy.On("Review", ctx, "user-1", content).Return(domain.ModerationDecision{Action: domain.ModerationReject, Reason: "SPAM"}, nil)
+
+		// Execute
+		_, err := tweetService.PublishTweet(ctx, "user-1", content)
+
+		// Assert
+		var rejection *domain.RejectionError
+		assert.ErrorAs(t, err, &rejection)
+		assert.Equal(t, "SPAM", rejection.Reason)
+		assert.ErrorIs(t, err, domain.ErrContentRejected)
+		mockRepo.AssertNotCalled(t, "PublishTx", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should queue held tweets instead of publishing them", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockPolicy := new(mocks.ModerationPolicy)
+		now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, mockPolicy, new(mocks.Notifier), time.Hour, func() time.Time { return now })
+
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1"}}, nil)
+		mockPolicy.On("Review", ctx, "user-1", content).Return(domain.ModerationDecision{Action: domain.ModerationHold, Reason: "SUSPICIOUS"}, nil)
+		mockRepo.On("AddHeldTweet", ctx, mock.MatchedBy(func(held *domain.HeldTweet) bool {
+			return held.UserID == "user-1" && held.Content.Text == content.Text && held.Reason == "SUSPICIOUS" && held.CreatedAt.Equal(now)
+		})).Return(nil)
+
+		_, err := tweetService.PublishTweet(ctx, "user-1", content)
+
+		assert.ErrorIs(t, err, domain.ErrTweetHeldForReview)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "PublishTx", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should validate the tweet before moderating it", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockPolicy := new(mocks.ModerationPolicy)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, mockPolicy, new(mocks.Notifier), time.Hour, time.Now)
+
+		_, err := tweetService.PublishTweet(ctx, "user-1", domain.TweetContent{Text: strings.Repeat("a", 281)})
+
+		assert.Equal(t, domain.ErrTweetTooLong, err)
+		mockPolicy.AssertNotCalled(t, "Review", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success: should publish approved tweets without moderating them again", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		mockPolicy := new(mocks.ModerationPolicy)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, mockPolicy, new(mocks.Notifier), time.Hour, time.Now)
+
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1"}}, nil)
+		mockRepo.On("PublishTx", ctx, mock.AnythingOfType("*domain.Tweet")).Return(nil)
+
+		tweet, err := tweetService.PublishHeldTweet(ctx, &domain.HeldTweet{ID: "held-1", UserID: "user-1", Content: content})
+
+		assert.NoError(t, err)
+		assert.Equal(t, content.Text, tweet.Text)
+		mockPolicy.AssertNotCalled(t, "Review", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTweetService_PublishTweetSuspended(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Failure: should not let suspended users publish", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+
+		// Mocking
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1", Status: domain.UserSuspended}}, nil)
+
+		// Execute
+		_, err := tweetService.PublishTweet(ctx, "user-1", domain.TweetContent{Text: "Hola"})
+
+		// Assert
+		assert.Equal(t, domain.ErrAccountSuspended, err)
+		mockRepo.AssertNotCalled(t, "PublishTx", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTweetService_DeleteTweet(t *testing.T) {
+	ctx := context.Background()
+	tweet := &domain.Tweet{ID: "tweet-1", UserID: "user-1"}
+
+	t.Run("Success: should delete the author's tweet", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("DeleteTx", ctx, tweet).Return(nil)
+
+		// Execute
+		err := tweetService.DeleteTweet(ctx, "user-1", "tweet-1")
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not delete someone else's tweet", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+
+		// Execute
+		err := tweetService.DeleteTweet(ctx, "user-2", "tweet-1")
+
+		// Assert
+		assert.Equal(t, domain.ErrNotTweetAuthor, err)
+		mockRepo.AssertNotCalled(t, "DeleteTx", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTweetService_EditTweet(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := createdAt.Add(10 * time.Minute)
+	clock := func() time.Time { return now }
+	newTweet := func() *domain.Tweet {
+		return &domain.Tweet{
+			ID: "tweet-1", UserID: "user-1", Text: "Hola @user-2", CreatedAt: createdAt,
+			Mentions: []domain.Mention{{Handle: "user-2", UserID: "user-2"}},
+		}
+	}
+
+	t.Run("Success: should store the edit and only notify newly mentioned users", func(t *testing.T) {
+		// Setup
+		mockRepo := new(mocks.Repository)
+		mockNotifier := new(mocks.Notifier)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, mockNotifier, time.Hour, clock)
+		original := newTweet()
+
+		// Mocking
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(original, nil)
+		mockRepo.On("GetTweetRevisions", ctx, "tweet-1").Return([]domain.TweetRevision{}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-2", "user-3"}).Return([]domain.User{{ID: "user-2"}, {ID: "user-3"}}, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1"}}, nil)
+		mockRepo.On("EditTx", ctx, original, mock.MatchedBy(func(after *domain.Tweet) bool {
+			return after.Text == "Hola @user-2 y @user-3" && after.EditedAt.Equal(now)
+		})).Return(nil)
+		mockNotifier.On("Notify", ctx, mock.MatchedBy(func(ns []domain.Notification) bool {
+			return len(ns) == 1 && ns[0].RecipientID == "user-3"
+		})).Return()
+
+		// Execute
+		edited, err := tweetService.EditTweet(ctx, "user-1", "tweet-1", "Hola @user-2 y @user-3")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Hola @user-2 y @user-3", edited.Text)
+		assert.Equal(t, "Hola @user-2", original.Text)
+		mockRepo.AssertExpectations(t)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("Failure: should not edit someone else's tweet", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, clock)
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(newTweet(), nil)
+
+		_, err := tweetService.EditTweet(ctx, "user-2", "tweet-1", "Otro texto")
+
+		assert.Equal(t, domain.ErrNotTweetAuthor, err)
+		mockRepo.AssertNotCalled(t, "EditTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should not edit once the edit window is over", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), 5*time.Minute, clock)
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(newTweet(), nil)
+
+		_, err := tweetService.EditTweet(ctx, "user-1", "tweet-1", "Otro texto")
+
+		assert.Equal(t, domain.ErrEditWindowExpired, err)
+		mockRepo.AssertNotCalled(t, "EditTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure: should not edit a tweet past the edit limit", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, clock)
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(newTweet(), nil)
+		mockRepo.On("GetTweetRevisions", ctx, "tweet-1").Return(make([]domain.TweetRevision, domain.MaxTweetEdits), nil)
+
+		_, err := tweetService.EditTweet(ctx, "user-1", "tweet-1", "Otro texto")
+
+		assert.Equal(t, domain.ErrTooManyTweetEdits, err)
+		mockRepo.AssertNotCalled(t, "EditTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTweetService_GetTweetHistory(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	editedAt := createdAt.Add(time.Minute)
+	tweet := &domain.Tweet{ID: "tweet-1", UserID: "user-1", Text: "Hola mundo", CreatedAt: createdAt, EditedAt: &editedAt}
+
+	t.Run("Success: should return the previous versions followed by the current one", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1"}}, nil)
+		mockRepo.On("GetTweetRevisions", ctx, "tweet-1").Return([]domain.TweetRevision{{Text: "Hola mudno", CreatedAt: createdAt}}, nil)
+
+		history, err := tweetService.GetTweetHistory(ctx, "user-2", "tweet-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.TweetRevision{
+			{Text: "Hola mudno", CreatedAt: createdAt},
+			{Text: "Hola mundo", CreatedAt: editedAt},
+		}, history)
+	})
+
+	t.Run("Failure: should hide the history of a protected account from non-followers", func(t *testing.T) {
+		mockRepo := new(mocks.Repository)
+		tweetService := NewTweetService(mockRepo, mockRepo, mockRepo, mockRepo, allowAll{}, new(mocks.Notifier), time.Hour, time.Now)
+		mockRepo.On("GetTweet", ctx, "tweet-1").Return(tweet, nil)
+		mockRepo.On("GetUsers", ctx, []string{"user-1"}).Return([]domain.User{{ID: "user-1", Protected: true}}, nil)
+		mockRepo.On("GetFollowedUsers", ctx, "user-2", []string{"user-1"}).Return([]string{}, nil)
+
+		_, err := tweetService.GetTweetHistory(ctx, "user-2", "tweet-1")
+
+		assert.Equal(t, domain.ErrTweetNotFound, err)
+		mockRepo.AssertNotCalled(t, "GetTweetRevisions", mock.Anything, mock.Anything)
+	})
 }
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/EstefiS/uala-challenge/internal/core/domain"
+	"github.com/EstefiS/uala-challenge/internal/core/ports"
+)
+
+// tweetVisibility hides the tweets of protected accounts from everyone but
+// the author and their approved followers, and the tweets of deactivated or
+// suspended accounts from everyone but the author. Read APIs run their
+// results through it so that every repository adapter behaves the same.
+type tweetVisibility struct {
+	userRepo ports.UserRepository
+}
+
+func (v tweetVisibility) filter(ctx context.Context, viewerID string, tweets []domain.Tweet) ([]domain.Tweet, error) {
+	authors, err := v.authors(ctx, viewerID, tweets)
+	if err != nil || len(authors) == 0 {
+		return tweets, err
+	}
+
+	hidden := make(map[string]bool)
+	var protectedIDs []string
+	for _, author := range authors {
+		switch {
+		case !author.Active():
+			hidden[author.ID] = true
+		case author.Protected:
+			protectedIDs = append(protectedIDs, author.ID)
+		}
+	}
+	if len(protectedIDs) > 0 {
+		followed, err := v.userRepo.GetFollowedUsers(ctx, viewerID, protectedIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range protectedIDs {
+			hidden[id] = !slices.Contains(followed, id)
+		}
+	}
+	return without(tweets, hidden), nil
+}
+
+// active only hides the tweets of deactivated or suspended accounts. It is
+// enough for timelines, which only ever get tweets the user was allowed to
+// see when they were fanned out; the timeline rows themselves are kept, so
+// the tweets come back once the account is active again.
+func (v tweetVisibility) active(ctx context.Context, viewerID string, tweets []domain.Tweet) ([]domain.Tweet, error) {
+	authors, err := v.authors(ctx, viewerID, tweets)
+	if err != nil || len(authors) == 0 {
+		return tweets, err
+	}
+
+	hidden := make(map[string]bool)
+	for _, author := range authors {
+		if !author.Active() {
+			hidden[author.ID] = true
+		}
+	}
+	return without(tweets, hidden), nil
+}
+
+// authors returns the known authors of the tweets, except the viewer.
+func (v tweetVisibility) authors(ctx context.Context, viewerID string, tweets []domain.Tweet) ([]domain.User, error) {
+	var authorIDs []string
+	for _, t := range tweets {
+		if t.UserID != viewerID && !slices.Contains(authorIDs, t.UserID) {
+			authorIDs = append(authorIDs, t.UserID)
+		}
+	}
+	if len(authorIDs) == 0 {
+		return nil, nil
+	}
+	return v.userRepo.GetUsers(ctx, authorIDs)
+}
+
+func without(tweets []domain.Tweet, hiddenAuthors map[string]bool) []domain.Tweet {
+	if len(hiddenAuthors) == 0 {
+		return tweets
+	}
+	visible := make([]domain.Tweet, 0, len(tweets))
+	for _, t := range tweets {
+		if !hiddenAuthors[t.UserID] {
+			visible = append(visible, t)
+		}
+	}
+	return visible
+}
+
+// canSee reports whether viewerID may see the tweets of authorID, by the
+// rules of filter, and whether everyone may.
+func (v tweetVisibility) canSee(ctx context.Context, viewerID, authorID string) (visible, public bool, err error) {
+	authors, err := v.userRepo.GetUsers(ctx, []string{authorID})
+	if err != nil {
+		return false, false, err
+	}
+	if len(authors) == 0 {
+		return true, true, nil
+	}
+	author := authors[0]
+	public = author.Active() && !author.Protected
+
+	switch {
+	case public || viewerID == authorID:
+		return true, public, nil
+	case !author.Active():
+		return false, false, nil
+	}
+	followed, err := v.userRepo.GetFollowedUsers(ctx, viewerID, []string{authorID})
+	if err != nil {
+		return false, false, err
+	}
+	return len(followed) > 0, false, nil
+}
+
+// checkActive fails with ErrAccountSuspended or ErrAccountDeactivated when
+// the user cannot act. Users the repository does not know yet are active.
+func checkActive(ctx context.Context, userRepo ports.UserRepository, userID string) error {
+	users, err := userRepo.GetUsers(ctx, []string{userID})
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return nil
+	}
+	return users[0].StatusError()
+}
+
+// audience returns the users among userIDs allowed to see authorID's tweets.
+func (v tweetVisibility) audience(ctx context.Context, authorID string, userIDs []string) ([]string, error) {
+	authors, err := v.userRepo.GetUsers(ctx, []string{authorID})
+	if err != nil {
+		return nil, err
+	}
+	if len(authors) == 0 || !authors[0].Protected {
+		return userIDs, nil
+	}
+
+	followers, err := v.userRepo.GetFollowers(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	var allowed []string
+	for _, id := range userIDs {
+		if id == authorID || slices.Contains(followers, id) {
+			allowed = append(allowed, id)
+		}
+	}
+	return allowed, nil
+}
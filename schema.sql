@@ -1,10 +1,41 @@
+DROP SCHEMA IF EXISTS detached CASCADE;
+DROP TABLE IF EXISTS archived_tweets;
+DROP TABLE IF EXISTS erasure_requests;
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS held_tweets;
+DROP TABLE IF EXISTS link_unfurls;
+DROP TABLE IF EXISTS link_previews;
+DROP TABLE IF EXISTS tweet_links;
+DROP TABLE IF EXISTS poll_votes;
+DROP TABLE IF EXISTS polls;
+DROP TABLE IF EXISTS media;
+DROP TABLE IF EXISTS tweet_revisions;
+DROP TABLE IF EXISTS drafts;
+DROP TABLE IF EXISTS scheduled_tweets;
+DROP TABLE IF EXISTS list_members;
+DROP TABLE IF EXISTS lists;
+DROP TABLE IF EXISTS bookmarks;
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS conversation_participants;
+DROP TABLE IF EXISTS conversations;
+DROP TABLE IF EXISTS follow_requests;
+DROP TABLE IF EXISTS content_filters;
+DROP TABLE IF EXISTS mutes;
+DROP TABLE IF EXISTS blocks;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS tweet_hashtags;
+DROP TABLE IF EXISTS tweet_mentions;
+DROP TABLE IF EXISTS grown_timelines;
 DROP TABLE IF EXISTS timelines;
 DROP TABLE IF EXISTS followers;
 DROP TABLE IF EXISTS tweets;
+DROP TABLE IF EXISTS tweet_ids;
 DROP TABLE IF EXISTS users;
 
 CREATE TABLE users (
     id VARCHAR(255) PRIMARY KEY,
+    protected BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
@@ -15,17 +46,312 @@ CREATE TABLE followers (
 );
 CREATE INDEX idx_followers_user_id ON followers(user_id);
 
+-- tweets is partitioned on created_at, so its primary key alone does not keep
+-- IDs unique. Every tweet ID is registered here first, which also tells the
+-- lookups by ID which partition to read. An archived tweet keeps its ID, so
+-- the revisions, polls, bookmarks and notifications referencing tweet_ids
+-- rather than tweets outlive the archiving.
+CREATE TABLE tweet_ids (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (id, created_at)
+);
+
 CREATE TABLE tweets (
+    id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    text VARCHAR(280) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    edited_at TIMESTAMPTZ,
+    media_ids TEXT[] NOT NULL DEFAULT '{}',
+    search_vector TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('spanish', text) || to_tsvector('english', text)
+    ) STORED,
+    PRIMARY KEY (id, created_at),
+    FOREIGN KEY (id, created_at) REFERENCES tweet_ids(id, created_at) ON DELETE CASCADE
+) PARTITION BY RANGE (created_at);
+-- Catches the tweets of months the partition job has not created yet, so a
+-- late job never fails a publish. The README describes how to move them into
+-- their month once it is created.
+CREATE TABLE tweets_default PARTITION OF tweets DEFAULT;
+CREATE INDEX idx_tweets_search_vector ON tweets USING GIN (search_vector);
+CREATE INDEX idx_tweets_user_created_at ON tweets(user_id, created_at DESC);
+
+CREATE TABLE timelines (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tweet_id VARCHAR(255) NOT NULL,
+    tweet_created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, tweet_id, tweet_created_at),
+    FOREIGN KEY (tweet_id, tweet_created_at) REFERENCES tweets(id, created_at) ON DELETE CASCADE
+) PARTITION BY RANGE (tweet_created_at);
+CREATE TABLE timelines_default PARTITION OF timelines DEFAULT;
+CREATE INDEX idx_timelines_user_created_at ON timelines(user_id, tweet_created_at DESC, tweet_id DESC);
+CREATE INDEX idx_timelines_created_at ON timelines(tweet_created_at);
+CREATE INDEX idx_timelines_tweet_id ON timelines(tweet_id, tweet_created_at);
+
+-- The partitions detached by the partition job are kept here until dropped.
+CREATE SCHEMA detached;
+
+-- Users whose timelines got entries since retention last trimmed them.
+CREATE TABLE grown_timelines (
+    user_id VARCHAR(255) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE tweet_mentions (
+    tweet_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    byte_start INT NOT NULL,
+    byte_end INT NOT NULL,
+    char_start INT NOT NULL,
+    char_end INT NOT NULL,
+    tweet_created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tweet_id, byte_start),
+    FOREIGN KEY (tweet_id, tweet_created_at) REFERENCES tweets(id, created_at) ON DELETE CASCADE
+);
+CREATE INDEX idx_tweet_mentions_user_created_at ON tweet_mentions(user_id, tweet_created_at DESC);
+
+CREATE TABLE tweet_hashtags (
+    tweet_id VARCHAR(255) NOT NULL,
+    tag VARCHAR(280) NOT NULL,
+    byte_start INT NOT NULL,
+    byte_end INT NOT NULL,
+    char_start INT NOT NULL,
+    char_end INT NOT NULL,
+    tweet_created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tweet_id, byte_start),
+    FOREIGN KEY (tweet_id, tweet_created_at) REFERENCES tweets(id, created_at) ON DELETE CASCADE
+);
+CREATE INDEX idx_tweet_hashtags_tag_created_at ON tweet_hashtags(tag, tweet_created_at DESC);
+CREATE INDEX idx_tweet_hashtags_created_at ON tweet_hashtags(tweet_created_at);
+
+CREATE TABLE notifications (
+    id VARCHAR(255) PRIMARY KEY,
+    recipient_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    actor_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tweet_id VARCHAR(255),
+    tweet_created_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    read_at TIMESTAMPTZ,
+    FOREIGN KEY (tweet_id, tweet_created_at) REFERENCES tweet_ids(id, created_at) MATCH FULL ON DELETE CASCADE
+);
+CREATE INDEX idx_notifications_recipient_created_at ON notifications(recipient_id, created_at DESC);
+CREATE INDEX idx_notifications_actor ON notifications(actor_id);
+
+CREATE TABLE blocks (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, blocked_id)
+);
+CREATE INDEX idx_blocks_blocked_id ON blocks(blocked_id);
+
+CREATE TABLE mutes (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, muted_id)
+);
+
+CREATE TABLE content_filters (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    value VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_content_filters_user_id ON content_filters(user_id);
+
+CREATE TABLE follow_requests (
+    target_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requester_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (target_id, requester_id)
+);
+CREATE INDEX idx_follow_requests_target_created_at ON follow_requests(target_id, created_at DESC);
+
+CREATE TABLE conversations (
+    id VARCHAR(255) PRIMARY KEY,
+    direct_key VARCHAR(511) UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL,
+    last_message_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE conversation_participants (
+    conversation_id VARCHAR(255) NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_at TIMESTAMPTZ,
+    PRIMARY KEY (conversation_id, user_id)
+);
+CREATE INDEX idx_conversation_participants_user_id ON conversation_participants(user_id);
+
+CREATE TABLE messages (
+    id VARCHAR(255) PRIMARY KEY,
+    conversation_id VARCHAR(255) NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    sender_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    text VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_messages_conversation_created_at ON messages(conversation_id, created_at DESC, id DESC);
+
+CREATE TABLE bookmarks (
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tweet_id VARCHAR(255) NOT NULL,
+    tweet_created_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, tweet_id),
+    FOREIGN KEY (tweet_id, tweet_created_at) REFERENCES tweet_ids(id, created_at) ON DELETE CASCADE
+);
+CREATE INDEX idx_bookmarks_user_created_at ON bookmarks(user_id, created_at DESC, tweet_id DESC);
+CREATE INDEX idx_bookmarks_tweet_id ON bookmarks(tweet_id);
+
+CREATE TABLE lists (
+    id VARCHAR(255) PRIMARY KEY,
+    owner_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(25) NOT NULL,
+    description VARCHAR(100) NOT NULL DEFAULT '',
+    private BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_lists_owner_id ON lists(owner_id);
+
+CREATE TABLE list_members (
+    list_id VARCHAR(255) NOT NULL REFERENCES lists(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, user_id)
+);
+CREATE INDEX idx_list_members_user_id ON list_members(user_id);
+
+CREATE TABLE scheduled_tweets (
     id VARCHAR(255) PRIMARY KEY,
     user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     text VARCHAR(280) NOT NULL,
-    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+    publish_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    claimed_until TIMESTAMPTZ
 );
+CREATE INDEX idx_scheduled_tweets_publish_at ON scheduled_tweets(publish_at);
+CREATE INDEX idx_scheduled_tweets_user_publish_at ON scheduled_tweets(user_id, publish_at);
 
-CREATE TABLE timelines (
+CREATE TABLE drafts (
+    id VARCHAR(255) PRIMARY KEY,
     user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    tweet_id VARCHAR(255) NOT NULL REFERENCES tweets(id) ON DELETE CASCADE,
+    text VARCHAR(2000) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_drafts_user_updated_at ON drafts(user_id, updated_at DESC);
+
+CREATE TABLE tweet_revisions (
+    tweet_id VARCHAR(255) NOT NULL,
     tweet_created_at TIMESTAMPTZ NOT NULL,
-    PRIMARY KEY (user_id, tweet_id)
+    text VARCHAR(280) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tweet_id, created_at),
+    FOREIGN KEY (tweet_id, tweet_created_at) REFERENCES tweet_ids(id, created_at) ON DELETE CASCADE
+);
+
+CREATE TABLE media (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_type VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE polls (
+    tweet_id VARCHAR(255) PRIMARY KEY,
+    tweet_created_at TIMESTAMPTZ NOT NULL,
+    options TEXT[] NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (tweet_id, tweet_created_at) REFERENCES tweet_ids(id, created_at) ON DELETE CASCADE
+);
+
+CREATE TABLE poll_votes (
+    tweet_id VARCHAR(255) NOT NULL REFERENCES polls(tweet_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    option_index SMALLINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tweet_id, user_id)
+);
+
+CREATE TABLE tweet_links (
+    tweet_id VARCHAR(255) NOT NULL,
+    url VARCHAR(280) NOT NULL,
+    byte_start INT NOT NULL,
+    byte_end INT NOT NULL,
+    char_start INT NOT NULL,
+    char_end INT NOT NULL,
+    tweet_created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tweet_id, byte_start),
+    FOREIGN KEY (tweet_id, tweet_created_at) REFERENCES tweets(id, created_at) ON DELETE CASCADE
+);
+CREATE INDEX idx_tweet_links_url ON tweet_links(url);
+CREATE INDEX idx_tweet_links_created_at ON tweet_links(tweet_created_at);
+
+CREATE TABLE link_previews (
+    url VARCHAR(280) PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    description VARCHAR(300) NOT NULL,
+    image_url TEXT NOT NULL,
+    site_name VARCHAR(200) NOT NULL,
+    fetched_at TIMESTAMPTZ NOT NULL
+);
+
+-- Links waiting for a preview. next_attempt_at is both the lease of the
+-- replica fetching the page and the retry-after of a failed fetch.
+CREATE TABLE link_unfurls (
+    url VARCHAR(280) PRIMARY KEY,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_link_unfurls_next_attempt_at ON link_unfurls(next_attempt_at);
+
+CREATE TABLE held_tweets (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    text VARCHAR(280) NOT NULL,
+    media_ids TEXT[] NOT NULL DEFAULT '{}',
+    poll_options TEXT[],
+    poll_duration_seconds INTEGER,
+    reason VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_held_tweets_created_at ON held_tweets(created_at);
+
+CREATE TABLE reports (
+    id VARCHAR(255) PRIMARY KEY,
+    reporter_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(16) NOT NULL,
+    target_id VARCHAR(255) NOT NULL,
+    reported_user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(32) NOT NULL,
+    comment VARCHAR(500) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    moderator_id VARCHAR(255) NOT NULL DEFAULT '',
+    resolution VARCHAR(16) NOT NULL DEFAULT '',
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_reports_status_created_at ON reports(status, created_at);
+CREATE UNIQUE INDEX idx_reports_open_target ON reports(reporter_id, target_type, target_id) WHERE status <> 'resolved';
+
+CREATE TABLE erasure_requests (
+    user_id VARCHAR(255) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    requested_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_erasure_requests_requested_at ON erasure_requests(requested_at);
+
+CREATE TABLE archived_tweets (
+    id VARCHAR(255) PRIMARY KEY REFERENCES tweet_ids(id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    data JSONB NOT NULL
 );
-CREATE INDEX idx_timelines_user_created_at ON timelines(user_id, tweet_created_at DESC);
\ No newline at end of file
+CREATE INDEX idx_archived_tweets_user_created_at ON archived_tweets(user_id, created_at DESC, id DESC);